@@ -19,13 +19,25 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"eventlist/pkg/ctf"
 	"eventlist/pkg/elf"
 	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"eventlist/pkg/fault"
+	"eventlist/pkg/memory"
+	"eventlist/pkg/object"
 	"eventlist/pkg/output"
+	"eventlist/pkg/pack"
 	"eventlist/pkg/xml/scvd"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"net"
 	"os"
+	"os/signal"
 	"strings"
 )
 
@@ -64,6 +76,7 @@ var paths includes
 // infoOpt prints information about a command-line option.
 //
 // Parameters:
+//   - w: The writer of the usage text.
 //   - flags: A FlagSet containing the defined command-line flags.
 //   - sopt: The short option name (e.g., "h" for "-h").
 //   - lopt: The long option name (e.g., "help" for "--help").
@@ -76,60 +89,358 @@ var paths includes
 // "arg" if the option requires an argument. It then prints the usage
 // information for the option, or "unknown option" if the option is not
 // found in the FlagSet.
-func infoOpt(flags *flag.FlagSet, sopt string, lopt string, arg bool) error {
-	pos, err := fmt.Print("  ")
+func infoOpt(w io.Writer, flags *flag.FlagSet, sopt string, lopt string, arg bool) error {
+	pos, err := fmt.Fprint(w, "  ")
 	if err != nil {
 		return err
 	}
 	var n int
 	if sopt != "" {
-		if n, err = fmt.Printf("-%s", sopt); err != nil {
+		if n, err = fmt.Fprintf(w, "-%s", sopt); err != nil {
 			return err
 		}
 		pos += n
 	}
 	if lopt != "" {
 		if sopt == "" {
-			if n, err = fmt.Printf("    "); err != nil {
+			if n, err = fmt.Fprintf(w, "    "); err != nil {
 				return err
 			}
 		} else {
-			if n, err = fmt.Printf(", "); err != nil {
+			if n, err = fmt.Fprintf(w, ", "); err != nil {
 				return err
 			}
 		}
 		pos += n
-		if n, err = fmt.Printf("--%s", lopt); err != nil {
+		if n, err = fmt.Fprintf(w, "--%s", lopt); err != nil {
 			return err
 		}
 		pos += n
 	}
 	if arg {
 		if sopt == "" && lopt == "" {
-			if n, err = fmt.Printf("  "); err != nil {
+			if n, err = fmt.Fprintf(w, "  "); err != nil {
 				return err
 			}
 			pos += n
 		}
-		if n, err = fmt.Printf(" arg"); err != nil {
+		if n, err = fmt.Fprintf(w, " arg"); err != nil {
 			return err
 		}
 		pos += n
 	}
-	fmt.Printf("%*s", 22-pos, " ")
+	fmt.Fprintf(w, "%*s", 22-pos, " ")
 	if lopt == "help" {
-		fmt.Printf("%s\n", "Print usage")
+		fmt.Fprintf(w, "%s\n", "Print usage")
 	} else {
-		f := flags.Lookup(sopt)
+		name := sopt
+		if name == "" {
+			name = lopt
+		}
+		f := flags.Lookup(name)
 		if f == nil {
-			fmt.Printf("%s\n", "unknown option")
+			fmt.Fprintf(w, "%s\n", "unknown option")
 		} else {
-			fmt.Printf("%s\n", f.Usage)
+			fmt.Fprintf(w, "%s\n", f.Usage)
 		}
 	}
 	return nil
 }
 
+// printUsage prints the usage text with the options of flags.
+//
+// Parameters:
+//   - w: The writer of the usage text, stdout for -h and stderr for usage errors.
+//   - flags: A FlagSet containing the defined command-line flags.
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintf(w, "%s: Event Listing %s\n\n", Progname, versionInfo)
+	fmt.Fprintf(w, "Usage:\n  %s [options] <logFile>\n", Progname)
+	fmt.Fprintf(w, "  %s [options] <ctfTraceDir>\n", Progname)
+	fmt.Fprintf(w, "  %s [options] tcp://<host>:<port>\n", Progname)
+	fmt.Fprintf(w, "  %s [options] -a <elf/axfFile> -m <memoryImage>\n", Progname)
+	fmt.Fprintf(w, "  %s [options] --objects -a <elf/axfFile> [-m <memoryImage>]\n", Progname)
+	fmt.Fprintf(w, "  %s fault [options] -a <elf/axfFile> -m <memoryImage>\n", Progname)
+	fmt.Fprintf(w, "  %s fault [options] [-a <elf/axfFile>] <faultFile>\n", Progname)
+	fmt.Fprintf(w, "  %s scvd check [options] -I <scvdFile>...\n", Progname)
+	fmt.Fprintf(w, "  %s scvd lint [options] -I <scvdFile>...\n\n", Progname)
+	fmt.Fprintf(w, "Options:\n")
+	_ = infoOpt(w, flags, "a", "", true)
+	_ = infoOpt(w, flags, "b", "begin", false)
+	_ = infoOpt(w, flags, "", "columns", true)
+	_ = infoOpt(w, flags, "", "conflict", true)
+	_ = infoOpt(w, flags, "", "filter", true)
+	_ = infoOpt(w, flags, "", "follow", false)
+	_ = infoOpt(w, flags, "h", "help", false)
+	_ = infoOpt(w, flags, "", "hierarchy", false)
+	_ = infoOpt(w, flags, "I", "", true)
+	_ = infoOpt(w, flags, "m", "", true)
+	_ = infoOpt(w, flags, "", "objects", false)
+	_ = infoOpt(w, flags, "o", "", true)
+	_ = infoOpt(w, flags, "r", "", true)
+	_ = infoOpt(w, flags, "", "resync", false)
+	_ = infoOpt(w, flags, "s", "statistic", false)
+	_ = infoOpt(w, flags, "V", "version", false)
+	_ = infoOpt(w, flags, "f", "format", true)
+	_ = infoOpt(w, flags, "l", "level", true)
+}
+
+// readMemory loads a memory image and reconstructs the events of the
+// Event Recorder buffer. Memory which is not contained in the image is
+// taken from the initialized data of the application file, which must be
+// loaded before. If the Event Recorder knows the timestamp frequency, it
+// is used for the time calculation.
+//
+// Parameters:
+//   - name: The memory image file name, binary dumps as file@address.
+//   - regs: The core registers for the expressions of the SCVD files, may be nil.
+//
+// Returns:
+//   - A buffered reader providing the events in log file format.
+//   - An error if the image cannot be loaded or does not contain the Event Recorder.
+func readMemory(name string, regs memory.Registers) (*bufio.Reader, error) {
+	img, err := memory.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	mem := memory.Readers{img, &elf.Sections}
+	eval.SetTarget(memory.Target{Reader: mem, Registers: regs})
+	in, rec, err := event.ReadRecorder(mem)
+	if err != nil {
+		return nil, err
+	}
+	if rec.TsFreq != 0 {
+		output.TimeFactor = new(float64)
+		*output.TimeFactor = 1.0 / float64(rec.TsFreq)
+	}
+	return in, nil
+}
+
+// readCTF converts the events of a CTF trace to log file format. Unless
+// the trace holds the timestamps of the Event Recorder, the events are
+// timed by the clock of the trace.
+//
+// Parameters:
+//   - dir: The CTF trace directory.
+//
+// Returns:
+//   - A buffered reader providing the events in log file format.
+//   - An error if the trace cannot be read.
+func readCTF(dir string) (*bufio.Reader, error) {
+	in, factor, err := ctf.Read(dir)
+	if err != nil {
+		return nil, err
+	}
+	if factor != 0 {
+		output.TimeFactor = new(float64)
+		*output.TimeFactor = factor
+	}
+	return in, nil
+}
+
+// readViews executes the object definitions of the SCVD files and renders
+// the component views. The target memory is taken from the memory image, if
+// given, and from the initialized data of the application file, which must
+// be loaded before.
+//
+// Parameters:
+//   - name: The memory image file name, empty to use the application file only.
+//   - objects: The object definitions of the SCVD files.
+//   - typedefs: The typedefs of the SCVD files.
+//   - regs: The core registers for the expressions of the SCVD files, may be nil.
+//
+// Returns:
+//   - The component views.
+//   - An error if the image cannot be loaded or an object cannot be evaluated.
+func readViews(name string, objects scvd.Objects, typedefs eval.Typedefs, regs memory.Registers) ([]object.View, error) {
+	mem := memory.Readers{&elf.Sections}
+	if name != "" {
+		img, err := memory.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		mem = memory.Readers{img, &elf.Sections}
+	}
+	eval.SetTarget(memory.Target{Reader: mem, Registers: regs})
+	return object.Evaluate(objects, typedefs, mem)
+}
+
+// readFault reads and validates the fault information saved by the Fault
+// component. It is taken either from a memory image, located via the symbols
+// of the application file, which must be loaded before, or from a file
+// containing the raw content of ARM_FaultInfo_t.
+//
+// Parameters:
+//   - name: The fault file name, empty to use the memory image.
+//   - memFile: The memory image file name.
+//
+// Returns:
+//   - The decoded fault information.
+//   - An error if the file cannot be loaded or the fault information is invalid.
+func readFault(name string, memFile string) (*fault.Info, error) {
+	if name == "" {
+		img, err := memory.Load(memFile)
+		if err != nil {
+			return nil, err
+		}
+		return fault.Read(memory.Readers{img, &elf.Sections})
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return fault.Decode(data)
+}
+
+// printStream decodes the events of a live source as they arrive. The
+// stream ends when the source is closed or on user interrupt, then the
+// statistics are printed.
+//
+// Parameters:
+//   - name: The log file, named pipe or tcp://host:port address.
+//   - follow: Wait for more data at the end of the log file.
+//   - print: Prints the events provided by the reader.
+//
+// Returns:
+//   - An error if the source cannot be opened or the output fails.
+func printStream(name string, follow bool, print func(in *bufio.Reader) error) error {
+	var s event.Stream
+	in, err := s.Open(name, follow)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupt:
+			s.Stop()
+		case <-done:
+		}
+	}()
+
+	output.Live = true
+	return print(in)
+}
+
+// Exit codes of the tool for the classes of failures.
+const (
+	exitOK      = 0
+	exitFailure = 1 // other errors, e.g. the output cannot be written
+	exitUsage   = 2
+	exitInput   = 3
+	exitSCVD    = 4
+	exitELF     = 5
+	exitDecode  = 6
+)
+
+// fail reports an error on stderr.
+//
+// Parameters:
+//   - code: The exit code.
+//   - msg: The error or message.
+//
+// Returns:
+//   - The exit code.
+func fail(code int, msg any) int {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", Progname, msg)
+	return code
+}
+
+// warn reports a problem on stderr which does not stop the tool.
+//
+// Parameters:
+//   - msg: The error or message.
+func warn(msg any) {
+	fmt.Fprintf(os.Stderr, "%s: warning: %v\n", Progname, msg)
+}
+
+// exitCode returns the exit code of an error: exitInput if an input file or
+// the tcp source cannot be opened, exitFailure if the output cannot be
+// created, otherwise the exit code of the step which failed.
+//
+// Parameters:
+//   - err: The error.
+//   - class: The exit code of the step.
+//
+// Returns:
+//   - The exit code.
+func exitCode(err error, class int) int {
+	var pathErr *fs.PathError
+	var netErr *net.OpError
+	switch {
+	case errors.Is(err, output.ErrOutput):
+		return exitFailure
+	case errors.Is(err, output.ErrNoEvents), errors.As(err, &netErr):
+		return exitInput
+	case errors.As(err, &pathErr) && pathErr.Op == "open":
+		return exitInput
+	}
+	return class
+}
+
+// checkSCVD runs the scvd commands: check reports the event IDs and
+// typedef names defined more than once in the SCVD files, lint reports the
+// problems of the SCVD files with their positions.
+//
+// Parameters:
+//   - command: The scvd command.
+//   - args: The arguments after the options, none are allowed.
+//   - outputFile: The output file, stdout if empty.
+//   - formatType: The output format: txt, json or xml.
+//
+// Returns:
+//   - The exit code, exitSCVD if definitions conflict or problems are found.
+func checkSCVD(command string, args []string, outputFile *string, formatType *string) int {
+	switch {
+	case command == "":
+		return fail(exitUsage, "missing scvd command: check or lint")
+	case command != "check" && command != "lint":
+		return fail(exitUsage, "unknown scvd command: "+command)
+	case len(args) != 0:
+		return fail(exitUsage, "scvd "+command+" does not take input files, use -I")
+	case len(paths) == 0:
+		return fail(exitUsage, "missing SCVD file (-I)")
+	}
+	if err := output.CheckFormat(*formatType, output.ReportFormats); err != nil {
+		return fail(exitUsage, err)
+	}
+	files, err := pack.Files(paths)
+	if err != nil {
+		return fail(exitCode(err, exitSCVD), err)
+	}
+	if command == "lint" {
+		problems, err := event.Lint(files)
+		if err == nil {
+			err = output.PrintLint(outputFile, formatType, problems)
+		}
+		if err != nil {
+			return fail(exitCode(err, exitSCVD), err)
+		}
+		if len(problems) != 0 {
+			return exitSCVD
+		}
+		return exitOK
+	}
+	conflicts, err := scvd.Check(files)
+	if err == nil {
+		err = output.PrintCheck(outputFile, formatType, conflicts)
+	}
+	if err != nil {
+		return fail(exitCode(err, exitSCVD), err)
+	}
+	for _, c := range conflicts {
+		if !c.Identical {
+			return exitSCVD
+		}
+	}
+	return exitOK
+}
+
 // main is the entry point of the event listing tool. It parses command-line
 // arguments, sets up the necessary configurations, and processes the event
 // log file. The tool supports various options such as specifying an output
@@ -139,19 +450,56 @@ func infoOpt(flags *flag.FlagSet, sopt string, lopt string, arg bool) error {
 // Usage:
 //
 //	eventlist [options] <logFile>
+//	eventlist [options] <ctfTraceDir>
+//	eventlist [options] tcp://<host>:<port>
+//	eventlist [options] -a <file> -m <memoryImage>
+//	eventlist [options] --objects -a <file> [-m <memoryImage>]
+//	eventlist fault [options] -a <file> -m <memoryImage>
+//	eventlist fault [options] [-a <file>] <faultFile>
+//	eventlist scvd check [options] -I <file>...
+//	eventlist scvd lint [options] -I <file>...
 //
 // Options:
 //
 //	-a <file>        Application file: elf/axf file name
 //	-b, --begin      Output order: show statistic before events
+//	    --columns <list> Columns: column names of the csv and tsv formats
+//	    --conflict <policy> SCVD: warn, error, first-wins or last-wins for conflicting definitions
+//	    --filter <expr> Filter: expression selecting the events
+//	    --follow     Follow the log file: wait for appended events
 //	-h, --help       Show help message
-//	-I <file>        Include SCVD file name(s)
-//	-o <file>        Output file
+//	    --hierarchy  Output: show the group and name of the components
+//	-I <file>        Include SCVD file name(s), .pdsc, pack directory or cbuild(-run).yml
+//	-m <file>        Memory image: binary dump (file@address), Intel HEX or SREC
+//	    --objects    Output: show the component views of the SCVD objects
+//	-o <file>        Output file, directory for the ctf format
+//	-r <file>        Register file: core register values for __GetRegVal
+//	    --resync     Input: skip damaged data of the log file
 //	-s, --statistic  Output: show statistic but no events
 //	-V, --version    Show version info
-//	-f <format>      Output format: txt, json, xml
-//	-l <level>       Level: Error|API|Op|Detail
+//	-f <format>      Output format: txt, json, xml, csv, tsv, perfetto, ctf, vcd
+//	-l <level>       Level: Error|API|Op|Detail, list or >=level threshold
+//
+// Exit codes:
+//
+//	0  success
+//	1  other errors, e.g. the output cannot be written
+//	2  usage: invalid options or arguments
+//	3  input: a file or the tcp source cannot be opened
+//	4  SCVD: an SCVD file cannot be parsed or has conflicting definitions
+//	5  ELF: the application file cannot be parsed
+//	6  decode: the events or the memory image cannot be decoded
 func main() {
+	if code := run(); code != exitOK {
+		os.Exit(code)
+	}
+}
+
+// run executes the tool, errors are reported on stderr.
+//
+// Returns:
+//   - The exit code.
+func run() int {
 	var err error
 	Progname = os.Args[0]
 	idx := strings.LastIndexByte(Progname, '/')
@@ -165,12 +513,12 @@ func main() {
 		Progname = Progname[:idx]
 	}
 
-	commFlag := flag.CommandLine
+	// errors are handled after parsing, so the usage goes to the right output
+	commFlag := flag.NewFlagSet(Progname, flag.ContinueOnError)
 
 	// --- this is only for unit tests of main()
 	testRun := flag.Lookup("test.run")
 	if testRun != nil {
-		commFlag = flag.NewFlagSet("test", flag.ContinueOnError)
 		flag.CommandLine.VisitAll(func(flag *flag.Flag) {
 			commFlag.Var(flag.Value, flag.Name, flag.Usage)
 		})
@@ -180,29 +528,30 @@ func main() {
 	usage := false
 
 	commFlag.Usage = func() {
-		fmt.Printf("%s: Event Listing %s\n\n", Progname, versionInfo)
-		fmt.Printf("Usage:\n  %s [options] <logFile>\n\n", Progname)
-		fmt.Printf("Options:\n")
-		_ = infoOpt(commFlag, "a", "", true)
-		_ = infoOpt(commFlag, "b", "begin", false)
-		_ = infoOpt(commFlag, "h", "help", false)
-		_ = infoOpt(commFlag, "I", "", true)
-		_ = infoOpt(commFlag, "o", "", true)
-		_ = infoOpt(commFlag, "s", "statistic", false)
-		_ = infoOpt(commFlag, "V", "version", false)
-		_ = infoOpt(commFlag, "f", "format", true)
-		_ = infoOpt(commFlag, "l", "level", true)
 		usage = true
 	}
 	// parse command line
-	commFlag.Var(&paths, "I", "[...] Include SCVD file name(s)")
-	outputFile := commFlag.String("o", "", "Output file")
+	commFlag.Var(&paths, "I", "[...] Include SCVD file name(s), .pdsc, pack directory or cbuild(-run).yml")
+	outputFile := commFlag.String("o", "", "Output file, directory for the ctf format")
 	elfFile := commFlag.String("a", "", "Application file: elf/axf file name")
-	formatType := commFlag.String("f", "", "Output format: txt, json, xml")
-	level := commFlag.String("l", "", "Level: Error|API|Op|Detail")
+	memFile := commFlag.String("m", "", "Memory image: binary dump (file@address), Intel HEX or SREC")
+	regFile := commFlag.String("r", "", "Register file: core register values for __GetRegVal")
+	formatType := commFlag.String("f", "", "Output format: txt, json, xml, csv, tsv, perfetto, ctf, vcd")
+	level := commFlag.String("l", "", "Level: Error|API|Op|Detail, list or >=level threshold")
+	filter := commFlag.String("filter", "", "Filter: expression selecting the events")
+	columns := commFlag.String("columns", "", "Columns: column names of the csv and tsv formats")
+	conflict := commFlag.String("conflict", "warn", "SCVD: warn, error, first-wins or last-wins for conflicting definitions")
 	var statBegin bool
 	commFlag.BoolVar(&statBegin, "b", false, "Output order: show statistic before events")
 	commFlag.BoolVar(&statBegin, "begin", false, "Output order: show statistic before events")
+	var follow bool
+	commFlag.BoolVar(&follow, "follow", false, "Follow the log file: wait for appended events")
+	var hierarchy bool
+	commFlag.BoolVar(&hierarchy, "hierarchy", false, "Output: show the group and name of the components")
+	var resync bool
+	commFlag.BoolVar(&resync, "resync", false, "Input: skip damaged data of the log file")
+	var showObjects bool
+	commFlag.BoolVar(&showObjects, "objects", false, "Output: show the component views of the SCVD objects")
 	var showVersion bool
 	commFlag.BoolVar(&showVersion, "V", false, "Show version info")
 	commFlag.BoolVar(&showVersion, "version", false, "Show version info")
@@ -211,50 +560,199 @@ func main() {
 	commFlag.BoolVar(&showStatistic, "statistic", false, "Output: show statistic but no events")
 	err = commFlag.Parse(os.Args[1:])
 
-	if usage || err != nil {
-		return
+	// options may be given before and after the fault and scvd commands
+	showFault := false
+	showSCVD := false
+	var scvdCommand string
+	if err == nil && commFlag.NArg() != 0 {
+		switch commFlag.Arg(0) {
+		case "fault":
+			showFault = true
+			err = commFlag.Parse(commFlag.Args()[1:])
+		case "scvd":
+			showSCVD = true
+			if scvdCommand = commFlag.Arg(1); scvdCommand != "" {
+				err = commFlag.Parse(commFlag.Args()[2:])
+			}
+		}
 	}
 
-	if len(os.Args) == 1 {
-		commFlag.Usage()
-		return
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage(os.Stdout, commFlag)
+		return exitOK
+	}
+	if usage || err != nil || len(os.Args) == 1 {
+		printUsage(os.Stderr, commFlag)
+		return exitUsage
 	}
 
 	if showVersion {
 		fmt.Printf("%s: Event Listing %s\n", Progname, versionInfo)
-		return
+		return exitOK
 	}
 
 	eventFile := commFlag.Args()
 
-	if len(eventFile) == 0 {
-		fmt.Println(Progname + ": missing input file")
-		return
+	if showSCVD {
+		return checkSCVD(scvdCommand, eventFile, outputFile, formatType)
 	}
-	if len(eventFile) > 1 {
-		fmt.Println(Progname + ": only one binary input file allowed")
-		return
+	if showFault {
+		if showObjects {
+			return fail(exitUsage, "fault information and component views cannot be used together")
+		}
+		if follow {
+			return fail(exitUsage, "fault information cannot be followed")
+		}
+		if len(*memFile) != 0 {
+			if len(eventFile) != 0 {
+				return fail(exitUsage, "fault file and memory image cannot be used together")
+			}
+			if len(*elfFile) == 0 {
+				return fail(exitUsage, "memory image requires application file (-a)")
+			}
+		} else {
+			if len(eventFile) == 0 {
+				return fail(exitUsage, "missing fault file or memory image (-m)")
+			}
+			if len(eventFile) > 1 {
+				return fail(exitUsage, "only one fault file allowed")
+			}
+		}
+	} else if showObjects {
+		if len(eventFile) != 0 {
+			return fail(exitUsage, "log file and component views cannot be used together")
+		}
+		if len(*elfFile) == 0 {
+			return fail(exitUsage, "component views require application file (-a)")
+		}
+		if follow {
+			return fail(exitUsage, "component views cannot be followed")
+		}
+	} else if len(*memFile) != 0 {
+		if len(eventFile) != 0 {
+			return fail(exitUsage, "log file and memory image cannot be used together")
+		}
+		if len(*elfFile) == 0 {
+			return fail(exitUsage, "memory image requires application file (-a)")
+		}
+	} else {
+		if len(eventFile) == 0 {
+			return fail(exitUsage, "missing input file")
+		}
+		if len(eventFile) > 1 {
+			return fail(exitUsage, "only one binary input file allowed")
+		}
+	}
+	if follow && len(*memFile) != 0 {
+		return fail(exitUsage, "memory image cannot be followed")
+	}
+
+	formats := output.Formats
+	if showFault || showObjects {
+		formats = output.ReportFormats
+	}
+	if err = output.CheckFormat(*formatType, formats); err != nil {
+		return fail(exitUsage, err)
+	}
+	if err = output.CheckLevel(*level); err != nil {
+		return fail(exitUsage, err)
+	}
+	if len(*columns) != 0 {
+		if *formatType != "csv" && *formatType != "tsv" {
+			return fail(exitUsage, "columns require the csv or tsv format")
+		}
+		if err = output.CheckColumns(*columns, showStatistic); err != nil {
+			return fail(exitUsage, err)
+		}
+	}
+	output.Columns = *columns
+	output.Resync = resync
+	output.Hierarchy = hierarchy
+	if err = output.SetFilter(*filter); err != nil {
+		return fail(exitUsage, err)
+	}
+	if scvd.OnConflict, err = scvd.ParsePolicy(*conflict); err != nil {
+		return fail(exitUsage, err)
 	}
 
 	if elfFile != nil && len(*elfFile) != 0 {
 		if err = elf.Sections.Readelf(elfFile); err != nil {
-			fmt.Print(Progname + ": ")
-			fmt.Println(err)
-			return
+			return fail(exitCode(err, exitELF), err)
+		}
+		if err = elf.Symbols.LineError(); err != nil {
+			warn(fmt.Errorf("no source lines in %s: %w", *elfFile, err))
 		}
 	}
+
+	if showFault {
+		var name string
+		if len(eventFile) != 0 {
+			name = eventFile[0]
+		}
+		var info *fault.Info
+		if info, err = readFault(name, *memFile); err == nil {
+			err = output.PrintFault(outputFile, formatType, info.Report())
+		}
+		if err != nil {
+			return fail(exitCode(err, exitDecode), err)
+		}
+		return exitOK
+	}
+
+	var regs memory.Registers
+	if len(*regFile) != 0 {
+		if regs, err = memory.LoadRegisters(*regFile); err != nil {
+			return fail(exitCode(err, exitDecode), err)
+		}
+	}
+	eval.SetTarget(memory.Target{Reader: &elf.Sections, Registers: regs})
+
 	evdefs := make(scvd.Events)
 	typedefs := make(eval.Typedefs)
 
-	var p []string = paths
-	if err = scvd.Get(&p, evdefs, typedefs); err != nil {
-		fmt.Print(Progname + ": ")
-		fmt.Println(err)
-		return
+	var p []string
+	if p, err = pack.Files(paths); err != nil {
+		return fail(exitCode(err, exitSCVD), err)
+	}
+	var objects scvd.Objects
+	if err = scvd.GetObjects(&p, evdefs, typedefs, &objects); err != nil {
+		return fail(exitCode(err, exitSCVD), err)
+	}
+	for _, c := range scvd.Warnings {
+		warn(fmt.Errorf("%w: %s, the last definition is used", scvd.ErrConflict, c))
 	}
 
-	if err := output.Print(outputFile, formatType, level, &eventFile[0], evdefs, typedefs, statBegin, showStatistic); err != nil {
-		fmt.Print(Progname + ": ")
-		fmt.Println(err)
+	if showObjects {
+		var views []object.View
+		if views, err = readViews(*memFile, objects, typedefs, regs); err == nil {
+			err = output.PrintViews(outputFile, formatType, views)
+		}
+	} else if len(*memFile) != 0 {
+		var in *bufio.Reader
+		if in, err = readMemory(*memFile, regs); err != nil {
+			return fail(exitCode(err, exitDecode), err)
+		}
+		err = output.PrintReader(outputFile, formatType, level, in, evdefs, typedefs, statBegin, showStatistic)
+	} else if ctf.IsTrace(eventFile[0]) {
+		if follow {
+			return fail(exitUsage, "CTF trace cannot be followed")
+		}
+		var in *bufio.Reader
+		if in, err = readCTF(eventFile[0]); err == nil {
+			err = output.PrintReader(outputFile, formatType, level, in, evdefs, typedefs, statBegin, showStatistic)
+		}
+	} else if follow || event.IsStream(eventFile[0]) {
+		if statBegin {
+			return fail(exitUsage, "statistic cannot be shown before the events of a live input")
+		}
+		err = printStream(eventFile[0], follow, func(in *bufio.Reader) error {
+			return output.PrintReader(outputFile, formatType, level, in, evdefs, typedefs, statBegin, showStatistic)
+		})
+	} else {
+		err = output.Print(outputFile, formatType, level, &eventFile[0], evdefs, typedefs, statBegin, showStatistic)
+	}
+	if err != nil {
+		return fail(exitCode(err, exitDecode), err)
 	}
+	return exitOK
 }
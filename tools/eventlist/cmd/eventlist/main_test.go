@@ -19,9 +19,12 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"io"
+	"net"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"testing"
@@ -121,24 +124,16 @@ func Test_infoOpt(t *testing.T) { //nolint:golint,paralleltest
 	_ = flag.Set("test.run", "yy")
 	for _, tt := range tests { //nolint:golint,paralleltest
 		t.Run(tt.name, func(t *testing.T) {
-			oldOut := os.Stdout
-			restore := func() {
-				os.Stdout = oldOut
-			}
-			defer restore()
-			r, w, _ := os.Pipe()
-			os.Stdout = w
-			_ = infoOpt(flag.CommandLine, tt.args.sopt, tt.args.lopt, tt.args.arg)
-			w.Close()
-			buf, _ := io.ReadAll(r)
-			if string(buf) != tt.want {
-				t.Errorf("infoOpt() %s = %v, want %v", tt.name, string(buf), tt.want)
+			var buf bytes.Buffer
+			_ = infoOpt(&buf, flag.CommandLine, tt.args.sopt, tt.args.lopt, tt.args.arg)
+			if buf.String() != tt.want {
+				t.Errorf("infoOpt() %s = %v, want %v", tt.name, buf.String(), tt.want)
 			}
 		})
 	}
 }
 
-func Test_main(t *testing.T) { //nolint:golint,paralleltest
+func Test_run(t *testing.T) { //nolint:golint,paralleltest
 	outFile := "out.out"
 
 	lines1 :=
@@ -156,6 +151,11 @@ func Test_main(t *testing.T) { //nolint:golint,paralleltest
 			"Event count      total       min         max         average     first       last\\n" +
 			"----- -----      -----       ---         ---         -------     -----       ----\\n"
 
+	filtered :=
+		"----- --------   --------- -------------- -----\\n" +
+			"    1 7\\.75000000 0xFE      0xFE00         \"hello wo\"\\n" +
+			"\\n"
+
 	lines2 :=
 		"   Start/Stop event statistic\\n" +
 			"   --------------------------\\n" +
@@ -164,18 +164,69 @@ func Test_main(t *testing.T) { //nolint:golint,paralleltest
 			"----- -----      -----       ---         ---         -------     -----       ----\\n"
 
 	help :=
-		"Usage:\\n" +
-			"  [^ ]+ \\[-options\\] <logFile>\\n\\n" +
+		"^[^ ]+: Event Listing .*\\n\\n" +
+			"Usage:\\n" +
+			"  [^ ]+ \\[options\\] <logFile>\\n" +
+			"(?s:.*)" +
 			"Options:\\n" +
-			"  -a arg            Application file: telf/axf file name\\n" +
-			"  -b --begin        Output order: show statistic at beginning\\n" +
-			"  -h --help         Print usage\\n" +
-			"  -I arg            \\[\\.\\.\\.\\] Include SCVD file name\\n" +
-			"  -o arg            Output file\\n" +
-			"  -s --statistic    Output: show statistic but no events\\n" +
-			"  -V --version      Show version info\\n" +
-			"  -f --format arg   Output format: txt, json, xml\\n" +
-			"  -l --level arg    Level: Error|API|Op|Detail\\n"
+			"  -a arg              Application file: elf/axf file name\\n" +
+			"  -b, --begin         Output order: show statistic before events\\n" +
+			"(?s:.*)" +
+			"  -l, --level arg     Level: Error\\|API\\|Op\\|Detail, list or >=level threshold\\n$"
+
+	views :=
+		"   Component view: Application\n" +
+			"   ---------------------------\n\n" +
+			"  Vectors\n" +
+			"    Initial SP  0x380011b8\n" +
+			"    Reset       0x10001561\n" +
+			"  Background    0x1234\n"
+
+	regViews :=
+		"   Component view: Registers\n" +
+			"   -------------------------\n\n" +
+			"  PSP         0x20001f80\n" +
+			"  Background  0x38000010\n"
+
+	faultInfo :=
+		"   Fault information\n" +
+			"   -----------------\n\n" +
+			"  Fault count:         1\n\n" +
+			"  Exception Handler:   HardFault\n" +
+			"  Mode:                Thread\n" +
+			"  Fault:               HardFault - Escalated fault \\(original fault was disabled or it caused another lower priority fault\\)\n" +
+			"  Fault:               UsageFault - Divide by 0\n" +
+			"  Program Counter:     0x10000360 \\(main\\+0x4\\)\n\n" +
+			"  Registers:\n" +
+			"   - R0:               0x20000010\n"
+
+	// local stand-in for a debug probe bridge
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			data, _ := os.ReadFile("../../testdata/test10.binary")
+			_, _ = conn.Write(data)
+			conn.Close()
+		}
+	}()
+
+	// log file cut in the middle of the last record
+	truncated := filepath.Join(t.TempDir(), "truncated.binary")
+	data, err := os.ReadFile("../../testdata/test10.binary")
+	if err == nil {
+		err = os.WriteFile(truncated, data[:len(data)-4], 0o600)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	versionInfo = "1.2.3 (C) 2023 Arm Ltd. and Contributors"
 	tests := []struct {
@@ -183,45 +234,121 @@ func Test_main(t *testing.T) { //nolint:golint,paralleltest
 		args       []string
 		want       string
 		removefile string
+		code       int
 	}{
-		{"-a", []string{"-a", "../../testdata/nix", "xxx"}, ".*: open ../../testdata/nix: (no such file or directory|The system cannot find the file specified.)\\n", ""},
-		{"-s stdout", []string{"-s", "../../testdata/test10.binary"}, lines2, ""},
-		{"-s", []string{"-s", "-o", outFile, "../../testdata/test10.binary"}, "", outFile},
-		{"-statistic", []string{"-statistic", "-o", outFile, "../../testdata/test10.binary"}, "", outFile},
-		{"-help", []string{"-help"}, help, ""},
-		{"stdout", []string{"../../testdata/test10.binary"}, lines1, ""},
-		{"-o -begin", []string{"-begin", "-o", outFile, "../../testdata/test10.binary"}, "", outFile},
-		{"-o -b", []string{"-b", "-o", outFile, "../../testdata/test10.binary"}, "", outFile},
-		{"-o", []string{"-o", outFile, "../../testdata/test10.binary"}, "", outFile},
-		{"-o", []string{"-o", outFile, "../../testdata/nix"}, ".*: cannot open event file\\n", outFile},
-		{"-V", []string{"-V"}, ".* [0-9]+\\.[0-9]+\\.[0-9]+ \\(C\\) [0-9]+ Arm Ltd. and Contributors\\n", ""},
-		{"-version", []string{"-version"}, ".* [0-9]+\\.[0-9]+\\.[0-9]+ \\(C\\) [0-9]+ Arm Ltd. and Contributors\\n", ""},
-		{"err", []string{"xxx", "yyy"}, ".*: only one binary input file allowed\n", ""},
-		{"missing", nil, ".*: missing input file\n", ""},
-		// -I must be the last test
-		{"-I", []string{"-I", "../../testdata/nix", "xxx"}, ".*: open ../../testdata/nix: (no such file or directory|The system cannot find the file specified.)\\n", ""},
+		{"-a", []string{"-a", "../../testdata/nix", "xxx"}, ".*: open ../../testdata/nix: (no such file or directory|The system cannot find the file specified.)\\n", "", 3},
+		{"-s stdout", []string{"-s", "../../testdata/test10.binary"}, lines2, "", 0},
+		{"-s", []string{"-s", "-o", outFile, "../../testdata/test10.binary"}, "", outFile, 0},
+		{"-statistic", []string{"-statistic", "-o", outFile, "../../testdata/test10.binary"}, "", outFile, 0},
+		{"-help", []string{"-help"}, help, "", 0},
+		{"-h", []string{"-h"}, help, "", 0},
+		{"-x", []string{"-x"}, "^flag provided but not defined: -x\n[^ ]+: Event Listing ", "", 2},
+		{"stdout", []string{"../../testdata/test10.binary"}, lines1, "", 0},
+		{"-o -begin", []string{"-begin", "-o", outFile, "../../testdata/test10.binary"}, "", outFile, 0},
+		{"-o -b", []string{"-b", "-o", outFile, "../../testdata/test10.binary"}, "", outFile, 0},
+		{"-o", []string{"-o", outFile, "../../testdata/test10.binary"}, "", outFile, 0},
+		{"-o", []string{"-o", outFile, "../../testdata/nix"}, ".*: cannot open event file\\n", outFile, 3},
+		{"-V", []string{"-V"}, ".* [0-9]+\\.[0-9]+\\.[0-9]+ \\(C\\) [0-9]+ Arm Ltd. and Contributors\\n", "", 0},
+		{"-version", []string{"-version"}, ".* [0-9]+\\.[0-9]+\\.[0-9]+ \\(C\\) [0-9]+ Arm Ltd. and Contributors\\n", "", 0},
+		{"err", []string{"xxx", "yyy"}, ".*: only one binary input file allowed\n", "", 2},
+		{"missing", nil, ".*: missing input file\n", "", 2},
+		{"-m log", []string{"-m", "../../testdata/nix.bin@0", "xxx"}, ".*: log file and memory image cannot be used together\n", "", 2},
+		{"-m no -a", []string{"-m", "../../testdata/nix.bin@0"}, ".*: memory image requires application file \\(-a\\)\n", "", 2},
+		{"-m nix", []string{"-a", "../../testdata/elfsym.elf", "-m", "../../testdata/nix.bin@0"}, ".*: open ../../testdata/nix.bin: (no such file or directory|The system cannot find the file specified.)\n", "", 3},
+		{"-m no recorder", []string{"-a", "../../testdata/elfsym.elf", "-m", "../../testdata/test.binary@0"}, ".*: event recorder not found in memory image\n", "", 6},
+		{"-follow -m", []string{"-follow", "-a", "../../testdata/elfsym.elf", "-m", "../../testdata/nix.bin@0"}, ".*: memory image cannot be followed\n", "", 2},
+		{"-filter", []string{"-filter", "id == 0xFE00 && time < 8", "../../testdata/test10.binary"}, filtered, "", 0},
+		{"-filter invalid", []string{"-filter", "thread == 3", "../../testdata/test10.binary"}, ".*: filter \"thread == 3\": .*\n", "", 2},
+		{"-l", []string{"-l", ">=Op", "../../testdata/test10.binary"}, "-----\\n\\n   Level filter >=Op: 2 events dropped \\(none: 2\\)\\n\\n", "", 0},
+		{"-f invalid", []string{"-f", "yaml", "../../testdata/test10.binary"}, ".*: invalid output format: yaml\n", "", 2},
+		{"-columns", []string{"-columns", "time", "../../testdata/test10.binary"}, ".*: columns require the csv or tsv format\n", "", 2},
+		{"-columns invalid", []string{"-f", "csv", "-columns", "time,count", "../../testdata/test10.binary"}, ".*: invalid column: count\n", "", 2},
+		{"-o nix dir", []string{"-o", "../../testdata/nix/out.out", "../../testdata/test10.binary"}, ".*: cannot create output: open ../../testdata/nix/out.out: .*\n", "", 1},
+		{"-a invalid", []string{"-a", "../../testdata/test.xml", "../../testdata/test10.binary"}, ".*: bad magic number .*\n", "", 5},
+		{"-I invalid", []string{"-I", "../../testdata/test_err1.xml", "../../testdata/test10.binary"}, ".*: strconv.ParseUint: .*\n", "", 4},
+		{"-hierarchy", []string{"-hierarchy", "-I", "../../testdata/groups.scvd", "../../testdata/test10.binary"}, "(?s).* Recorder Control/Event Recorder Control +EventRecorderClock +Clock=4\n.* STDIO/C Standard I/O +stdout +.*\n   Component hierarchy\n.*\nSTDIO\n    C Standard I/O \\(STDIO\\): C Standard I/O Events\n.*", "", 0},
+		{"-I conflict", []string{"-I", "../../testdata/test.xml", "-I", "../../testdata/conflict.scvd", "../../testdata/test10.binary"}, "(?s).*\"hello wo\".*: warning: conflicting definition: event 0xEF00: ../../testdata/test.xml:18 and ../../testdata/conflict.scvd:18, the last definition is used\n.*: warning: conflicting definition: typedef attr: .*", "", 0},
+		{"-conflict error", []string{"-conflict", "error", "-I", "../../testdata/test.xml", "-I", "../../testdata/conflict.scvd", "../../testdata/test10.binary"}, ".*: conflicting definition: event 0xEF00: ../../testdata/test.xml:18 and ../../testdata/conflict.scvd:18\n", "", 4},
+		{"-conflict last-wins", []string{"-conflict", "last-wins", "-I", "../../testdata/test.xml", "-I", "../../testdata/conflict.scvd", "../../testdata/test10.binary"}, "(?s).*\"hello wo\".*", "", 0},
+		{"-conflict invalid", []string{"-conflict", "first", "../../testdata/test10.binary"}, ".*: invalid conflict policy: first\n", "", 2},
+		{"scvd check", []string{"scvd", "check", "-I", "../../testdata/test.xml"}, "(?s).*SCVD check.*  Conflicts: 0, identical definitions: 0\n", "", 0},
+		{"scvd check conflicts", []string{"scvd", "check", "-o", outFile, "-I", "../../testdata/test.xml", "-I", "../../testdata/conflict.scvd"}, "", outFile, 4},
+		{"-I pack", []string{"-I", "../../testdata/pack", "../../testdata/test10.binary"}, "(?s).*\"hello wo\".*", "", 0},
+		{"-I cbuild-run", []string{"-I", "../../testdata/pack/out/test+Target.cbuild-run.yml", "../../testdata/test10.binary"}, "(?s).*\"hello wo\".*", "", 0},
+		{"-I no scvd", []string{"-I", "../../testdata/pack/out/empty.cbuild.yml", "../../testdata/test10.binary"}, ".*: no SCVD files found: ../../testdata/pack/out/empty.cbuild.yml\n", "", 4},
+		{"scvd lint pack", []string{"scvd", "lint", "-I", "../../testdata/pack/Vendor.Test.pdsc"}, "(?s).*SCVD lint.*  Problems: 0\n", "", 0},
+		{"scvd lint", []string{"scvd", "lint", "-I", "../../testdata/test.xml"}, "(?s).*SCVD lint.*  Problems: 0\n", "", 0},
+		{"scvd lint problems", []string{"scvd", "lint", "-o", outFile, "-I", "../../testdata/lint.scvd"}, "", outFile, 4},
+		{"scvd lint file", []string{"scvd", "lint", "-I", "../../testdata/test.xml", "../../testdata/test10.binary"}, ".*: scvd lint does not take input files, use -I\n", "", 2},
+		{"scvd lint nix", []string{"scvd", "lint", "-I", "../../testdata/nix.scvd"}, ".*: open ../../testdata/nix.scvd: .*\n", "", 3},
+		{"scvd", []string{"scvd"}, ".*: missing scvd command: check or lint\n", "", 2},
+		{"scvd nix", []string{"scvd", "nix", "-I", "../../testdata/test.xml"}, ".*: unknown scvd command: nix\n", "", 2},
+		{"scvd check no -I", []string{"scvd", "check"}, ".*: missing SCVD file \\(-I\\)\n", "", 2},
+		{"scvd check file", []string{"scvd", "check", "-I", "../../testdata/test.xml", "../../testdata/test10.binary"}, ".*: scvd check does not take input files, use -I\n", "", 2},
+		{"scvd check nix", []string{"scvd", "check", "-I", "../../testdata/nix.scvd"}, ".*: open ../../testdata/nix.scvd: .*\n", "", 3},
+		{"-l invalid", []string{"-l", "Op,Debug", "../../testdata/test10.binary"}, ".*: invalid level: Debug\n", "", 2},
+		{"ctf", []string{"../../testdata/ctf"}, lines1, "", 0},
+		{"ctf -follow", []string{"-follow", "../../testdata/ctf"}, ".*: CTF trace cannot be followed\n", "", 2},
+		{"tcp nix", []string{"tcp://127.0.0.1:0"}, ".*: dial tcp .*\n", "", 3},
+		{"tcp", []string{"tcp://" + ln.Addr().String()}, lines1, "", 0},
+		{"truncated", []string{"-o", outFile, truncated}, ".*: decode problems: truncated: 1\n", outFile, 6},
+		{"tcp -b", []string{"-b", "tcp://" + ln.Addr().String()}, ".*: statistic cannot be shown before the events of a live input\n", "", 2},
+		{"-follow -b", []string{"-follow", "-b", "../../testdata/test10.binary"}, ".*: statistic cannot be shown before the events of a live input\n", "", 2},
+		{"-objects log", []string{"-objects", "-a", "../../testdata/elfsym.elf", "xxx"}, ".*: log file and component views cannot be used together\n", "", 2},
+		{"-objects no -a", []string{"-objects"}, ".*: component views require application file \\(-a\\)\n", "", 2},
+		{"-objects -follow", []string{"-objects", "-follow", "-a", "../../testdata/elfsym.elf"}, ".*: component views cannot be followed\n", "", 2},
+		{"-objects -m nix", []string{"-objects", "-a", "../../testdata/elfsym.elf", "-m", "../../testdata/nix.bin@0"}, ".*: open ../../testdata/nix.bin: (no such file or directory|The system cannot find the file specified.)\n", "", 3},
+		{"-objects", []string{"-objects", "-I", "../../testdata/objects_elf.scvd", "-a", "../../testdata/elfsym.elf", "-m", "../../testdata/color.bin@0x38000010"}, views, "", 0},
+		{"-r nix", []string{"-objects", "-a", "../../testdata/elfsym.elf", "-r", "../../testdata/nix.txt"}, ".*: open ../../testdata/nix.txt: (no such file or directory|The system cannot find the file specified.)\n", "", 3},
+		{"-objects -r", []string{"-objects", "-I", "../../testdata/objects_regs.scvd", "-a", "../../testdata/elfsym.elf", "-r", "../../testdata/registers.txt"}, regViews, "", 0},
+		{"fault -objects", []string{"fault", "-objects", "../../testdata/fault.bin"}, ".*: fault information and component views cannot be used together\n", "", 2},
+		{"fault -follow", []string{"-follow", "fault", "../../testdata/fault.bin"}, ".*: fault information cannot be followed\n", "", 2},
+		{"fault -m file", []string{"fault", "-a", "../../testdata/elfsym.elf", "-m", "../../testdata/nix.bin@0", "xxx"}, ".*: fault file and memory image cannot be used together\n", "", 2},
+		{"fault -m no -a", []string{"fault", "-m", "../../testdata/nix.bin@0"}, ".*: memory image requires application file \\(-a\\)\n", "", 2},
+		{"fault missing", []string{"fault"}, ".*: missing fault file or memory image \\(-m\\)\n", "", 2},
+		{"fault two", []string{"fault", "xxx", "yyy"}, ".*: only one fault file allowed\n", "", 2},
+		{"fault -f csv", []string{"fault", "-f", "csv", "../../testdata/fault.bin"}, ".*: invalid output format: csv\n", "", 2},
+		{"fault nix", []string{"fault", "../../testdata/nix"}, ".*: open ../../testdata/nix: (no such file or directory|The system cannot find the file specified.)\n", "", 3},
+		{"fault invalid", []string{"fault", "../../testdata/test.binary"}, ".*: fault information too short\n", "", 6},
+		{"fault -m no symbol", []string{"fault", "-a", "../../testdata/elfsym.elf", "-m", "../../testdata/color.bin@0"}, ".*: fault information not found: symbol ARM_FaultInfo missing\n", "", 6},
+		{"fault", []string{"-a", "../../testdata/elfsym.elf", "fault", "../../testdata/fault.bin"}, faultInfo, "", 0},
+		{"-I", []string{"-I", "../../testdata/nix", "xxx"}, ".*: open ../../testdata/nix: (no such file or directory|The system cannot find the file specified.)\\n", "", 3},
 	}
 	savedArgs := os.Args
 	for _, tt := range tests { //nolint:golint,paralleltest
 		t.Run(tt.name, func(t *testing.T) {
 			oldOut := os.Stdout
+			oldErr := os.Stderr
 			restore := func() {
 				os.Stdout = oldOut
+				os.Stderr = oldErr
 			}
 			defer restore()
 			defer os.Remove(tt.removefile)
 			r, w, _ := os.Pipe()
+			rErr, wErr, _ := os.Pipe()
 			os.Stdout = w
+			os.Stderr = wErr
 			os.Args = append(savedArgs, tt.args...)
-			main()
+			paths = nil
+			code := run()
 			w.Close()
+			wErr.Close()
 			buf, _ := io.ReadAll(r)
+			bufErr, _ := io.ReadAll(rErr)
+			if code != tt.code {
+				t.Errorf("run() %s exit code = %d, want %d", tt.name, code, tt.code)
+			}
+			if code != exitOK && len(buf) != 0 {
+				t.Errorf("run() %s stdout = %v, want error on stderr", tt.name, string(buf))
+			}
+			buf = append(buf, bufErr...)
 			match, err := regexp.Match(tt.want, buf)
 			if err != nil {
-				t.Errorf("main() %s regexp match error %v, want %v", tt.name, err, tt.want)
+				t.Errorf("run() %s regexp match error %v, want %v", tt.name, err, tt.want)
 			}
 			if !match {
-				t.Errorf("main() %s = %v, want %v", tt.name, string(buf), tt.want)
+				t.Errorf("run() %s = %v, want %v", tt.name, string(buf), tt.want)
 			}
 		})
 	}
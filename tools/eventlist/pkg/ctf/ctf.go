@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ctf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"eventlist/pkg/event"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errNoEvents = errors.New("no Event Recorder events in CTF trace")
+
+// IsTrace checks if a path is a CTF trace directory, i.e. a directory
+// containing a metadata file.
+func IsTrace(path string) bool {
+	info, err := os.Stat(filepath.Join(path, "metadata"))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// ReadTrace reads the metadata and all streams of a trace directory.
+//
+// Parameters:
+//   - dir: The trace directory.
+//
+// Returns:
+//   - The events of all streams merged by their time, the events of a
+//     stream remain in the order of the stream.
+//   - An error if the trace cannot be read.
+func ReadTrace(dir string) ([]Event, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "metadata"))
+	if err != nil {
+		return nil, err
+	}
+	m, err := ParseMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var streams [][]Event
+	for _, e := range entries {
+		if e.Name() == "metadata" || strings.HasPrefix(e.Name(), ".") || !e.Type().IsRegular() {
+			continue
+		}
+		if data, err = os.ReadFile(filepath.Join(dir, e.Name())); err != nil {
+			return nil, err
+		}
+		ev, err := ReadStream(m, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		streams = append(streams, ev)
+	}
+	return merge(streams), nil
+}
+
+// merge merges the events of the streams by their time, the first stream
+// is taken for equal times.
+func merge(streams [][]Event) []Event {
+	var events []Event
+	for {
+		next := -1
+		for i, s := range streams {
+			if len(s) != 0 && (next < 0 || s[0].Nanoseconds() < streams[next][0].Nanoseconds()) {
+				next = i
+			}
+		}
+		if next < 0 {
+			return events
+		}
+		events = append(events, streams[next][0])
+		streams[next] = streams[next][1:]
+	}
+}
+
+// Nanoseconds returns the time of an event in nanoseconds, including the
+// offset of its clock.
+func (ev *Event) Nanoseconds() uint64 {
+	c := ev.Clock
+	if c == nil {
+		return ev.Timestamp
+	}
+	ts := ev.Timestamp + uint64(c.Offset)
+	return ts/c.Freq*1e9 + ts%c.Freq*1e9/c.Freq + uint64(c.OffsetS)*1e9
+}
+
+// value returns an integer field of the payload, the path names the
+// fields of nested structures separated by dots.
+func (ev *Event) value(path string) (uint64, bool) {
+	switch v, _ := member(ev.Fields, strings.Split(path, ".")); n := v.(type) {
+	case uint64:
+		return n, true
+	case int64:
+		return uint64(n), true
+	}
+	return 0, false
+}
+
+// first returns the first of the integer fields of the payload.
+func (ev *Event) first(paths ...string) (uint64, bool) {
+	for _, path := range paths {
+		if v, ok := ev.value(path); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// id returns the event ID given by er_id or by _info._id of
+// docs/eventlist.tsdl, which is an integer or the structure of the fields
+// class, group, stop and idx, from the most significant bits down.
+func (ev *Event) id() (uint64, bool) {
+	if id, ok := ev.first("er_id", "_info._id"); ok {
+		return id, true
+	}
+	class, ok := ev.value("_info._id.class")
+	if !ok {
+		return 0, false
+	}
+	group, _ := ev.value("_info._id.group")
+	stop, _ := ev.value("_info._id.stop")
+	idx, _ := ev.value("_info._id.idx")
+	return class<<8 | (group&7)<<5 | (stop&1)<<4 | idx&0xF, true
+}
+
+// data returns a byte array or sequence field of the payload.
+func (ev *Event) data(name string) ([]byte, bool) {
+	switch v := ev.Fields[name].(type) {
+	case []byte:
+		return v, true
+	case []any:
+		b := make([]byte, 0, len(v))
+		for _, e := range v {
+			switch n := e.(type) {
+			case uint64:
+				b = append(b, byte(n))
+			case int64:
+				b = append(b, byte(n))
+			default:
+				return nil, false
+			}
+		}
+		return b, true
+	}
+	return nil, false
+}
+
+// Read converts the events of a CTF trace to the log file format of the
+// Event Recorder. Events are converted if their payload contains the event
+// ID, further fields are the timestamp of the Event Recorder, the irq flag,
+// up to four values or data with the length of the data given in any
+// preceding field. The fields are named as written by eventlist or as in
+// docs/eventlist.tsdl:
+//
+//	event ID    er_id     _info._id
+//	timestamp   er_time   _time
+//	irq flag    irq       _info._len.irq
+//	values      val1..4   value1..4
+//	data        data      data
+//
+// Other events are skipped.
+//
+// Parameters:
+//   - dir: The trace directory.
+//
+// Returns:
+//   - A buffered reader providing the events in log file format.
+//   - The factor converting the timestamps to seconds, 0 if the timestamps
+//     are those of the Event Recorder.
+//   - An error if the trace cannot be read or contains no events to be converted.
+func Read(dir string) (*bufio.Reader, float64, error) {
+	events, err := ReadTrace(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	recorderTime := true
+	var n int
+	for i := range events {
+		if _, ok := events[i].id(); ok {
+			n++
+			if _, ok = events[i].first("er_time", "_time"); !ok {
+				recorderTime = false
+			}
+		}
+	}
+	if n == 0 {
+		return nil, 0, errNoEvents
+	}
+
+	var out bytes.Buffer
+	for i := range events {
+		ev := &events[i]
+		id, ok := ev.id()
+		if !ok {
+			continue
+		}
+		ts := ev.Nanoseconds()
+		if recorderTime {
+			ts, _ = ev.first("er_time", "_time")
+		}
+		irq, _ := ev.first("irq", "_info._len.irq")
+		var vals []uint32
+		for i := 1; i <= 4; i++ {
+			v, ok := ev.first(fmt.Sprintf("val%d", i), fmt.Sprintf("value%d", i))
+			if !ok {
+				break
+			}
+			vals = append(vals, uint32(v))
+		}
+		typ := uint16(2)
+		data, isData := ev.data("data")
+		switch {
+		case isData:
+			typ = 1
+		case len(vals) > 2:
+			typ = 3
+		}
+		event.AppendRecord(&out, typ, uint16(id), ts, irq != 0, vals, data)
+	}
+	var factor float64
+	if !recorderTime {
+		factor = 1e-9
+	}
+	return bufio.NewReader(&out), factor, nil
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ctf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// foreignMetadata describes the trace of another tracer: without
+// timestamps of the Event Recorder and with the data as sequence.
+const foreignMetadata = `/* CTF 1.8 */
+typealias integer { size = 8; align = 8; signed = false; } := uint8_t;
+typealias integer { size = 16; align = 8; signed = false; } := uint16_t;
+typealias integer { size = 32; align = 8; signed = false; } := uint32_t;
+typealias integer { size = 32; align = 8; signed = false; map = clock.mono.value; } := uint32_clock_t;
+trace { major = 1; minor = 8; byte_order = le; };
+clock { name = mono; freq = 1000000; offset = 5; };
+stream { event.header := struct { uint8_t id; uint32_clock_t timestamp; }; };
+event { name = "log"; id = 0; fields := struct { uint8_t level; }; };
+event { name = "er2"; id = 1; fields := struct { uint16_t er_id; uint32_t val1; uint32_t val2; }; };
+event { name = "er4"; id = 2; fields := struct { uint16_t er_id; uint8_t irq; uint32_t val1; uint32_t val2; uint32_t val3; uint32_t val4; }; };
+event { name = "data"; id = 3; fields := struct { uint16_t er_id; uint8_t n; uint8_t data[n]; }; };
+`
+
+// foreignEvent returns an event of the foreign trace.
+func foreignEvent(id uint8, ts uint32, payload ...byte) []byte {
+	b := binary.LittleEndian.AppendUint32([]byte{id}, ts)
+	return append(b, payload...)
+}
+
+// writeTrace writes a trace directory with the metadata and the streams.
+func writeTrace(t *testing.T, metadata string, streams ...[]byte) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "metadata"), []byte(metadata), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range streams {
+		if err := os.WriteFile(filepath.Join(dir, "stream_"+string(rune('0'+i))), s, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	log, err := os.ReadFile("../../testdata/test10.binary")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// events of two streams, merged by their time
+	stream0 := bytes.Join([][]byte{
+		foreignEvent(0, 1, 3),
+		foreignEvent(1, 10, 0x03, 0xFF, 4, 0, 0, 0, 2, 0, 0, 0),
+		foreignEvent(3, 30, 0x00, 0xFE, 2, 'h', 'i'),
+	}, nil)
+	stream1 := foreignEvent(2, 20, 0x00, 0xEF, 1, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0)
+	var foreign bytes.Buffer
+	for _, r := range []struct {
+		typ  uint16
+		id   uint16
+		ts   uint64
+		data []byte
+	}{
+		{2, 0xFF03, 15000, []byte{4, 0, 0, 0, 2, 0, 0, 0}},
+		{3, 0xEF00, 25000, []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0}},
+		{1, 0xFE00, 35000, []byte{'h', 'i'}},
+	} {
+		size := uint16(12 + len(r.data))
+		length := uint16(len(r.data))
+		if r.typ != 1 {
+			length = 0
+		}
+		if r.id == 0xEF00 {
+			length |= 0x8000 // irq
+		}
+		foreign.Write(binary.LittleEndian.AppendUint16(nil, r.typ))
+		foreign.Write(binary.LittleEndian.AppendUint16(nil, size))
+		foreign.Write(binary.LittleEndian.AppendUint64(nil, r.ts))
+		foreign.Write(binary.LittleEndian.AppendUint16(nil, r.id))
+		foreign.Write(binary.LittleEndian.AppendUint16(nil, length))
+		foreign.Write(r.data)
+	}
+
+	tests := []struct {
+		name       string
+		dir        string
+		want       []byte
+		wantFactor float64
+		wantErr    bool
+	}{
+		{"eventlist", "../../testdata/ctf", log, 0, false},
+		{"foreign", writeTrace(t, foreignMetadata, stream0, stream1), foreign.Bytes(), 1e-9, false},
+		{"eventlist.tsdl", "../../testdata/ctf_tsdl", foreign.Bytes(), 0, false},
+		{"invalid stream", writeTrace(t, foreignMetadata, foreignEvent(7, 1)), nil, 0, true},
+		{"invalid metadata", writeTrace(t, "trace {"), nil, 0, true},
+		{"missing", "../../testdata/nix", nil, 0, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			in, factor, err := Read(tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, _ := io.ReadAll(in)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Read() %s = %v, want %v", tt.name, got, tt.want)
+			}
+			if factor != tt.wantFactor {
+				t.Errorf("Read() %s factor = %v, want %v", tt.name, factor, tt.wantFactor)
+			}
+		})
+	}
+}
+
+func TestRead_noEvents(t *testing.T) {
+	t.Parallel()
+
+	dir := writeTrace(t, foreignMetadata, foreignEvent(0, 1, 3))
+	if _, _, err := Read(dir); !errors.Is(err, errNoEvents) {
+		t.Errorf("Read() error = %v, want %v", err, errNoEvents)
+	}
+}
+
+func TestIsTrace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"trace", "../../testdata/ctf", true},
+		{"log file", "../../testdata/test10.binary", false},
+		{"directory", "../../testdata", false},
+		{"missing", "../../testdata/nix", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsTrace(tt.path); got != tt.want {
+				t.Errorf("IsTrace() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
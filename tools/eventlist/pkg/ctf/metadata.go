@@ -0,0 +1,811 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ctf reads traces in the Common Trace Format (CTF) 1.8. The
+// metadata is parsed for the subset of TSDL used by the usual tracers of
+// embedded systems: integers, floating point numbers, strings, enums,
+// structures, arrays and sequences. Variants are not supported.
+package ctf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errSyntax      = errors.New("invalid CTF metadata")
+	errUnsupported = errors.New("unsupported CTF metadata")
+)
+
+// kind is the class of a TSDL type.
+type kind int
+
+const (
+	kindInteger kind = iota
+	kindFloat
+	kindString
+	kindStruct
+	kindArray
+	kindSequence
+)
+
+// Byte order of a type, native is the byte order of the trace.
+const (
+	orderNative = iota
+	orderLE
+	orderBE
+)
+
+type field struct {
+	name string
+	typ  *typ
+}
+
+// typ is a TSDL type, the sizes and alignments are given in bits.
+type typ struct {
+	kind   kind
+	size   int
+	align  int
+	signed bool
+	order  int
+	clock  string  // name of the clock an integer is mapped to
+	fields []field // struct
+	elem   *typ    // array, sequence
+	length int     // array
+	ref    string  // sequence: the field holding the length
+}
+
+// lookup returns the type of a field of a struct.
+func (t *typ) lookup(name string) *typ {
+	for _, f := range t.fields {
+		if f.name == name {
+			return f.typ
+		}
+	}
+	return nil
+}
+
+// Clock is a clock of the trace.
+type Clock struct {
+	Name    string
+	Freq    uint64 // in Hz
+	Offset  int64  // in cycles
+	OffsetS int64  // in seconds
+}
+
+type eventClass struct {
+	name     string
+	id       uint64
+	streamID uint64
+	context  *typ
+	fields   *typ
+}
+
+type stream struct {
+	eventHeader   *typ
+	packetContext *typ
+	eventContext  *typ
+	events        map[uint64]*eventClass
+}
+
+// Metadata describes the layout of the streams of a trace.
+type Metadata struct {
+	bigEndian    bool
+	packetHeader *typ
+	clocks       map[string]*Clock
+	streams      map[uint64]*stream
+}
+
+type tokKind int
+
+const (
+	tokIdent tokKind = iota
+	tokNumber
+	tokString
+	tokPunct
+	tokEOF
+)
+
+type token struct {
+	kind tokKind
+	text string
+}
+
+// lex splits the metadata text into tokens, comments are skipped.
+func lex(text string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated comment", errSyntax)
+			}
+			i += end + 4
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			j := i + 1
+			for j < len(text) && (text[j] == '_' || text[j] >= 'a' && text[j] <= 'z' ||
+				text[j] >= 'A' && text[j] <= 'Z' || text[j] >= '0' && text[j] <= '9') {
+				j++
+			}
+			toks = append(toks, token{tokIdent, text[i:j]})
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(text) && (text[j] >= '0' && text[j] <= '9' || text[j] >= 'a' && text[j] <= 'z' || text[j] >= 'A' && text[j] <= 'Z') {
+				j++
+			}
+			toks = append(toks, token{tokNumber, text[i:j]})
+			i = j
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(text) && text[j] != '"'; j++ {
+				if text[j] == '\\' && j+1 < len(text) {
+					j++
+				}
+				b.WriteByte(text[j])
+			}
+			if j >= len(text) {
+				return nil, fmt.Errorf("%w: unterminated string", errSyntax)
+			}
+			toks = append(toks, token{tokString, b.String()})
+			i = j + 1
+		case strings.HasPrefix(text[i:], ":="):
+			toks = append(toks, token{tokPunct, ":="})
+			i += 2
+		case strings.ContainsRune("{}[]();=:<>,.-+", rune(c)):
+			toks = append(toks, token{tokPunct, string(c)})
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q", errSyntax, c)
+		}
+	}
+	return toks, nil
+}
+
+type parser struct {
+	toks    []token
+	pos     int
+	aliases map[string]*typ
+	structs map[string]*typ // named structs and enums, the enums as "enum name"
+	meta    *Metadata
+	events  []*eventClass
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return token{kind: tokEOF}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) is(text string) bool {
+	t := p.peek()
+	return (t.kind == tokPunct || t.kind == tokIdent) && t.text == text
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.next(); t.text != text || t.kind == tokString {
+		return fmt.Errorf("%w: expected %q instead of %q", errSyntax, text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) ident() (string, error) {
+	t := p.next()
+	if t.kind != tokIdent {
+		return "", fmt.Errorf("%w: expected name instead of %q", errSyntax, t.text)
+	}
+	return t.text, nil
+}
+
+// number parses an integer constant.
+func (p *parser) number() (int64, error) {
+	neg := false
+	if p.is("-") {
+		p.next()
+		neg = true
+	}
+	t := p.next()
+	text := strings.TrimRight(t.text, "uUlL")
+	if t.kind != tokNumber {
+		return 0, fmt.Errorf("%w: expected number instead of %q", errSyntax, t.text)
+	}
+	if len(text) > 1 && text[0] == '0' && text[1] >= '0' && text[1] <= '7' {
+		text = "0o" + text[1:]
+	}
+	v, err := strconv.ParseUint(text, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid number %q", errSyntax, t.text)
+	}
+	if neg {
+		return -int64(v), nil
+	}
+	return int64(v), nil
+}
+
+// path parses a name with dots, e.g. packet.header or clock.monotonic.value.
+func (p *parser) path() (string, error) {
+	name, err := p.ident()
+	if err != nil {
+		return "", err
+	}
+	for p.is(".") {
+		p.next()
+		part, err := p.ident()
+		if err != nil {
+			return "", err
+		}
+		name += "." + part
+	}
+	return name, nil
+}
+
+// value parses the value of an attribute until the semicolon.
+func (p *parser) value() (string, error) {
+	var parts []string
+	for !p.is(";") {
+		t := p.next()
+		if t.kind == tokEOF {
+			return "", fmt.Errorf("%w: missing ';'", errSyntax)
+		}
+		parts = append(parts, t.text)
+	}
+	p.next()
+	return strings.Join(parts, ""), nil
+}
+
+// attributes parses the attributes of a block, e.g. of an integer or a clock.
+// Type declarations of the block (name := type) are returned separately.
+func (p *parser) attributes() (map[string]string, map[string]*typ, error) {
+	attrs := make(map[string]string)
+	types := make(map[string]*typ)
+	if err := p.expect("{"); err != nil {
+		return nil, nil, err
+	}
+	for !p.is("}") {
+		if p.peek().kind == tokEOF {
+			return nil, nil, fmt.Errorf("%w: missing '}'", errSyntax)
+		}
+		if p.is("typealias") || p.is("typedef") {
+			if err := p.declaration(); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		name, err := p.path()
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.is(":=") {
+			p.next()
+			t, err := p.typeSpec()
+			if err != nil {
+				return nil, nil, err
+			}
+			types[name] = t
+			if err = p.expect(";"); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		if err = p.expect("="); err != nil {
+			return nil, nil, err
+		}
+		if attrs[name], err = p.value(); err != nil {
+			return nil, nil, err
+		}
+	}
+	p.next()
+	return attrs, types, nil
+}
+
+// order returns the byte order of a byte_order attribute.
+func order(value string) (int, error) {
+	switch value {
+	case "le", "little":
+		return orderLE, nil
+	case "be", "big", "network":
+		return orderBE, nil
+	case "native", "":
+		return orderNative, nil
+	}
+	return 0, fmt.Errorf("%w: byte_order = %s", errSyntax, value)
+}
+
+// integer parses the attributes of an integer or a floating point number.
+func (p *parser) integer(k kind) (*typ, error) {
+	attrs, _, err := p.attributes()
+	if err != nil {
+		return nil, err
+	}
+	t := &typ{kind: k}
+	if k == kindFloat {
+		exp, _ := strconv.Atoi(attrs["exp_dig"])
+		mant, _ := strconv.Atoi(attrs["mant_dig"])
+		t.size = exp + mant
+		if t.size != 32 && t.size != 64 {
+			return nil, fmt.Errorf("%w: floating point size %d", errUnsupported, t.size)
+		}
+	} else {
+		size, err := strconv.ParseUint(attrs["size"], 0, 8)
+		if err != nil || size == 0 || size > 64 {
+			return nil, fmt.Errorf("%w: integer size = %s", errSyntax, attrs["size"])
+		}
+		t.size = int(size)
+		t.signed = attrs["signed"] == "true" || attrs["signed"] == "1"
+		if m := attrs["map"]; strings.HasPrefix(m, "clock.") && strings.HasSuffix(m, ".value") {
+			t.clock = strings.TrimSuffix(strings.TrimPrefix(m, "clock."), ".value")
+		}
+	}
+	t.align = 1
+	if t.size%8 == 0 {
+		t.align = 8
+	}
+	if a, ok := attrs["align"]; ok {
+		align, err := strconv.ParseUint(a, 0, 8)
+		if err != nil || align == 0 || align&(align-1) != 0 {
+			return nil, fmt.Errorf("%w: align = %s", errSyntax, a)
+		}
+		t.align = int(align)
+	}
+	if t.order, err = order(attrs["byte_order"]); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// aliasName collects the words of an alias name, e.g. unsigned long.
+func (p *parser) aliasName() (string, error) {
+	var words []string
+	for p.peek().kind == tokIdent {
+		words = append(words, p.next().text)
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("%w: expected type name instead of %q", errSyntax, p.peek().text)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// typeSpec parses a type. A type name consisting of several words is
+// only accepted as a whole, see fieldDecl for the field names.
+func (p *parser) typeSpec() (*typ, error) {
+	t := p.peek()
+	if t.kind != tokIdent {
+		return nil, fmt.Errorf("%w: expected type instead of %q", errSyntax, t.text)
+	}
+	switch t.text {
+	case "integer":
+		p.next()
+		return p.integer(kindInteger)
+	case "floating_point":
+		p.next()
+		return p.integer(kindFloat)
+	case "string":
+		p.next()
+		if p.is("{") {
+			if _, _, err := p.attributes(); err != nil {
+				return nil, err
+			}
+		}
+		return &typ{kind: kindString, align: 8}, nil
+	case "struct":
+		p.next()
+		return p.structSpec()
+	case "enum":
+		p.next()
+		return p.enumSpec()
+	case "variant":
+		return nil, fmt.Errorf("%w: variant", errUnsupported)
+	}
+	name, err := p.aliasName()
+	if err != nil {
+		return nil, err
+	}
+	a, ok := p.aliases[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %s", errSyntax, name)
+	}
+	return a, nil
+}
+
+// structSpec parses a struct, either its definition or a reference by name.
+func (p *parser) structSpec() (*typ, error) {
+	var name string
+	if p.peek().kind == tokIdent {
+		name = p.next().text
+	}
+	if !p.is("{") {
+		s, ok := p.structs[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown struct %s", errSyntax, name)
+		}
+		return s, nil
+	}
+	p.next()
+	s := &typ{kind: kindStruct, align: 1}
+	for !p.is("}") {
+		if p.peek().kind == tokEOF {
+			return nil, fmt.Errorf("%w: missing '}'", errSyntax)
+		}
+		if p.is("typealias") || p.is("typedef") {
+			if err := p.declaration(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		f, err := p.fieldDecl()
+		if err != nil {
+			return nil, err
+		}
+		s.fields = append(s.fields, f)
+		if f.typ.align > s.align {
+			s.align = f.typ.align
+		}
+	}
+	p.next()
+	if p.is("align") {
+		p.next()
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		a, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		if int(a) > s.align {
+			s.align = int(a)
+		}
+	}
+	if name != "" {
+		p.structs[name] = s
+	}
+	return s, nil
+}
+
+// enumSpec parses an enum, its values are decoded as integers.
+func (p *parser) enumSpec() (*typ, error) {
+	var name string
+	if p.peek().kind == tokIdent {
+		name = p.next().text
+	}
+	if name != "" && !p.is(":") && !p.is("{") {
+		e, ok := p.structs["enum "+name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown enum %s", errSyntax, name)
+		}
+		return e, nil
+	}
+	var t *typ
+	if p.is(":") {
+		p.next()
+		var err error
+		if t, err = p.typeSpec(); err != nil {
+			return nil, err
+		}
+	} else if t = p.aliases["int"]; t == nil {
+		return nil, fmt.Errorf("%w: enum without integer type", errSyntax)
+	}
+	if t.kind != kindInteger {
+		return nil, fmt.Errorf("%w: enum of non integer type", errSyntax)
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.is("}") {
+		if p.next().kind == tokEOF {
+			return nil, fmt.Errorf("%w: missing '}'", errSyntax)
+		}
+	}
+	p.next()
+	if name != "" {
+		p.structs["enum "+name] = t
+	}
+	return t, nil
+}
+
+// fieldDecl parses the declaration of a struct field including array,
+// sequence and bit field suffixes.
+func (p *parser) fieldDecl() (field, error) {
+	var f field
+	var err error
+	if t := p.peek(); t.kind == tokIdent && !isKeyword(t.text) {
+		// alias name of one or more words followed by the field name
+		words, _ := p.aliasName()
+		if len(words) == 0 || !strings.Contains(words, " ") {
+			return f, fmt.Errorf("%w: expected type and name instead of %q", errSyntax, words)
+		}
+		i := strings.LastIndexByte(words, ' ')
+		f.name = words[i+1:]
+		if f.typ = p.aliases[words[:i]]; f.typ == nil {
+			return f, fmt.Errorf("%w: unknown type %s", errSyntax, words[:i])
+		}
+	} else {
+		if f.typ, err = p.typeSpec(); err != nil {
+			return f, err
+		}
+		if f.name, err = p.ident(); err != nil {
+			return f, err
+		}
+	}
+	if p.is(":") { // bit field
+		p.next()
+		bits, err := p.number()
+		if err != nil {
+			return f, err
+		}
+		if f.typ.kind != kindInteger || bits <= 0 || bits > 64 {
+			return f, fmt.Errorf("%w: bit field %s", errSyntax, f.name)
+		}
+		bf := *f.typ
+		bf.size, bf.align = int(bits), 1
+		f.typ = &bf
+	}
+	var dims []string
+	for p.is("[") {
+		p.next()
+		if p.peek().kind == tokNumber {
+			n, err := p.number()
+			if err != nil {
+				return f, err
+			}
+			dims = append(dims, strconv.FormatInt(n, 10))
+		} else {
+			ref, err := p.path()
+			if err != nil {
+				return f, err
+			}
+			dims = append(dims, ref)
+		}
+		if err = p.expect("]"); err != nil {
+			return f, err
+		}
+	}
+	for i := len(dims) - 1; i >= 0; i-- {
+		t := &typ{elem: f.typ, align: f.typ.align}
+		if n, err := strconv.Atoi(dims[i]); err == nil {
+			t.kind, t.length = kindArray, n
+		} else {
+			t.kind, t.ref = kindSequence, dims[i]
+		}
+		f.typ = t
+	}
+	return f, p.expect(";")
+}
+
+func isKeyword(s string) bool {
+	switch s {
+	case "integer", "floating_point", "string", "struct", "enum", "variant":
+		return true
+	}
+	return false
+}
+
+// declaration parses a typealias or typedef.
+func (p *parser) declaration() error {
+	kw := p.next().text
+	t, err := p.typeSpec()
+	if err != nil {
+		return err
+	}
+	var name string
+	if kw == "typealias" {
+		if err = p.expect(":="); err != nil {
+			return err
+		}
+		if name, err = p.aliasName(); err != nil {
+			return err
+		}
+	} else if name, err = p.aliasName(); err != nil {
+		return err
+	}
+	p.aliases[name] = t
+	return p.expect(";")
+}
+
+// parse parses the top level declarations of the metadata.
+func (p *parser) parse() error {
+	for p.peek().kind != tokEOF {
+		t := p.peek()
+		switch t.text {
+		case "typealias", "typedef":
+			if err := p.declaration(); err != nil {
+				return err
+			}
+			continue
+		case "trace", "stream", "event", "clock", "env", "callsite":
+			p.next()
+			attrs, types, err := p.attributes()
+			if err != nil {
+				return err
+			}
+			if err = p.block(t.text, attrs, types); err != nil {
+				return err
+			}
+		default: // declaration of a named struct or enum
+			if _, err := p.typeSpec(); err != nil {
+				return err
+			}
+		}
+		if err := p.expect(";"); err != nil {
+			return err
+		}
+	}
+	for _, ev := range p.events {
+		s, ok := p.meta.streams[ev.streamID]
+		if !ok {
+			if ev.streamID != 0 || len(p.meta.streams) != 0 {
+				return fmt.Errorf("%w: event %s of unknown stream %d", errSyntax, ev.name, ev.streamID)
+			}
+			s = &stream{events: make(map[uint64]*eventClass)}
+			p.meta.streams[0] = s
+		}
+		s.events[ev.id] = ev
+	}
+	return nil
+}
+
+// block stores the information of a top level block.
+func (p *parser) block(kind string, attrs map[string]string, types map[string]*typ) error {
+	num := func(name string) (int64, error) {
+		v, ok := attrs[name]
+		if !ok {
+			return 0, nil
+		}
+		n, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s %s = %s", errSyntax, kind, name, v)
+		}
+		return n, nil
+	}
+	switch kind {
+	case "trace":
+		o, err := order(attrs["byte_order"])
+		if err != nil {
+			return err
+		}
+		p.meta.bigEndian = o == orderBE
+		p.meta.packetHeader = types["packet.header"]
+	case "clock":
+		c := &Clock{Name: attrs["name"], Freq: 1000000000}
+		freq, err := num("freq")
+		if err != nil {
+			return err
+		}
+		if freq > 0 {
+			c.Freq = uint64(freq)
+		}
+		if c.Offset, err = num("offset"); err != nil {
+			return err
+		}
+		if c.OffsetS, err = num("offset_s"); err != nil {
+			return err
+		}
+		p.meta.clocks[c.Name] = c
+	case "stream":
+		id, err := num("id")
+		if err != nil {
+			return err
+		}
+		p.meta.streams[uint64(id)] = &stream{
+			eventHeader:   types["event.header"],
+			packetContext: types["packet.context"],
+			eventContext:  types["event.context"],
+			events:        make(map[uint64]*eventClass),
+		}
+	case "event":
+		ev := &eventClass{name: attrs["name"], context: types["context"], fields: types["fields"]}
+		id, err := num("id")
+		if err != nil {
+			return err
+		}
+		sid, err := num("stream_id")
+		if err != nil {
+			return err
+		}
+		ev.id, ev.streamID = uint64(id), uint64(sid)
+		p.events = append(p.events, ev)
+	}
+	return nil
+}
+
+// metadataMagic starts the packets of packetized metadata.
+const metadataMagic = 0x75D11D57
+
+// unpack returns the text of packetized metadata.
+func unpack(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return data, nil
+	}
+	var bo binary.ByteOrder = binary.LittleEndian
+	if binary.LittleEndian.Uint32(data) != metadataMagic {
+		if binary.BigEndian.Uint32(data) != metadataMagic {
+			return data, nil // plain text
+		}
+		bo = binary.BigEndian
+	}
+	const headerSize = 37
+	var text []byte
+	for len(data) != 0 {
+		if len(data) < headerSize || bo.Uint32(data) != metadataMagic {
+			return nil, fmt.Errorf("%w: metadata packet", errSyntax)
+		}
+		content := int(bo.Uint32(data[24:]) / 8)
+		size := int(bo.Uint32(data[28:]) / 8)
+		if content < headerSize || content > size || size > len(data) {
+			return nil, fmt.Errorf("%w: metadata packet size", errSyntax)
+		}
+		if data[32] != 0 || data[33] != 0 {
+			return nil, fmt.Errorf("%w: compressed or encrypted metadata", errUnsupported)
+		}
+		text = append(text, data[headerSize:content]...)
+		data = data[size:]
+	}
+	return text, nil
+}
+
+// ParseMetadata parses the metadata of a trace, either as text or packetized.
+//
+// Parameters:
+//   - data: The content of the metadata file.
+//
+// Returns:
+//   - The metadata.
+//   - An error if the metadata is invalid or uses unsupported types.
+func ParseMetadata(data []byte) (*Metadata, error) {
+	text, err := unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	toks, err := lex(string(text))
+	if err != nil {
+		return nil, err
+	}
+	p := parser{
+		toks:    toks,
+		aliases: make(map[string]*typ),
+		structs: make(map[string]*typ),
+		meta:    &Metadata{clocks: make(map[string]*Clock), streams: make(map[uint64]*stream)},
+	}
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+	return p.meta, nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ctf
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+const testTypes = `/* CTF 1.8 */
+typealias integer { size = 8; align = 8; signed = false; } := uint8_t;
+typealias integer { size = 16; align = 8; signed = false; } := uint16_t;
+typealias integer { size = 32; align = 8; signed = false; } := uint32_t;
+typealias integer { size = 64; align = 8; signed = false; } := unsigned long;
+typealias integer { size = 27; align = 1; signed = false; map = clock.cycles.value; } := uint27_clock_t;
+`
+
+func TestParseMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		check   func(m *Metadata) bool
+		wantErr error
+	}{
+		{"trace", testTypes + `
+			trace { major = 1; minor = 8; byte_order = be;
+				packet.header := struct { uint32_t magic; uint32_t stream_id; }; };
+			clock { name = cycles; freq = 25000000; offset_s = 2; };
+			stream { id = 0; event.header := struct { uint16_t id; uint27_clock_t timestamp; } align(8); };
+			event { name = "a"; id = 0; stream_id = 0; fields := struct { uint8_t x; }; };`,
+			func(m *Metadata) bool {
+				s := m.streams[0]
+				ts := s.eventHeader.lookup("timestamp")
+				return m.bigEndian && m.packetHeader != nil && m.clocks["cycles"].Freq == 25000000 &&
+					m.clocks["cycles"].OffsetS == 2 && s.eventHeader.align == 8 &&
+					ts.size == 27 && ts.clock == "cycles" && s.events[0].name == "a"
+			}, nil},
+		{"default stream", testTypes + `
+			// event without stream declaration
+			event { name = "b"; id = 3; fields := struct { unsigned long x; }; };`,
+			func(m *Metadata) bool {
+				x := m.streams[0].events[3].fields.lookup("x")
+				return x.size == 64 && x.align == 8
+			}, nil},
+		{"fields", testTypes + `
+			typedef struct point { uint16_t x; uint16_t y; } point_t;
+			enum color : uint8_t { RED, GREEN = 3, BLUE = 5 ... 7, };
+			event { name = "c"; fields := struct {
+				uint8_t len;
+				uint8_t bits : 3;
+				integer { size = 5; signed = true; byte_order = be; } sbits;
+				floating_point { exp_dig = 8; mant_dig = 24; align = 32; } f;
+				string s;
+				enum color c;
+				struct point p;
+				point_t q;
+				uint16_t arr[2][3];
+				uint8_t seq[len];
+			}; };`,
+			func(m *Metadata) bool {
+				f := m.streams[0].events[0].fields
+				arr := f.lookup("arr")
+				return f.lookup("bits").size == 3 && f.lookup("bits").align == 1 &&
+					f.lookup("sbits").signed && f.lookup("sbits").order == orderBE && f.lookup("sbits").align == 1 &&
+					f.lookup("f").kind == kindFloat && f.lookup("f").align == 32 &&
+					f.lookup("s").kind == kindString && f.lookup("c").size == 8 &&
+					f.lookup("p").kind == kindStruct && f.lookup("q") == f.lookup("p") &&
+					arr.kind == kindArray && arr.length == 2 && arr.elem.length == 3 &&
+					f.lookup("seq").kind == kindSequence && f.lookup("seq").ref == "len" && f.align == 32
+			}, nil},
+		{"variant", testTypes + `event { fields := struct { variant <tag> { uint8_t a; } v; }; };`, nil, errUnsupported},
+		{"unknown type", `event { fields := struct { uint8_t a; }; };`, nil, errSyntax},
+		{"integer size", `typealias integer { size = 65; } := big;`, nil, errSyntax},
+		{"float size", `typealias floating_point { exp_dig = 5; mant_dig = 11; } := half;`, nil, errUnsupported},
+		{"missing semicolon", testTypes + `clock { name = c }`, nil, errSyntax},
+		{"unterminated", `/* CTF 1.8`, nil, errSyntax},
+		{"unknown stream", testTypes + `stream { id = 1; }; event { stream_id = 2; };`, nil, errSyntax},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := ParseMetadata([]byte(tt.text))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseMetadata() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if tt.check != nil && !tt.check(m) {
+				t.Errorf("ParseMetadata() %s = %+v, unexpected", tt.name, m)
+			}
+		})
+	}
+}
+
+// packetize returns the metadata text as packetized metadata.
+func packetize(text string, packets int) []byte {
+	var data []byte
+	n := (len(text) + packets - 1) / packets
+	for len(text) != 0 {
+		if n > len(text) {
+			n = len(text)
+		}
+		head := make([]byte, 37)
+		binary.LittleEndian.PutUint32(head[0:], metadataMagic)
+		binary.LittleEndian.PutUint32(head[24:], uint32(8*(37+n))) // content size
+		binary.LittleEndian.PutUint32(head[28:], uint32(8*(40+n))) // packet size
+		data = append(append(append(data, head...), text[:n]...), 0, 0, 0)
+		text = text[n:]
+	}
+	return data
+}
+
+func Test_unpack(t *testing.T) {
+	t.Parallel()
+
+	compressed := packetize("x", 1)
+	compressed[32] = 1
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{"text", []byte("trace {};"), "trace {};", nil},
+		{"packets", packetize("clock { name = c; };", 3), "clock { name = c; };", nil},
+		{"truncated", packetize("clock { name = c; };", 1)[:40], "", errSyntax},
+		{"compressed", compressed, "", errUnsupported},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := unpack(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unpack() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("unpack() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ctf
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var errStream = errors.New("invalid CTF stream")
+
+// packetMagic starts the packet header of the streams.
+const packetMagic = 0xC1FC1FC1
+
+// Event is an event of a stream.
+type Event struct {
+	Name      string
+	Timestamp uint64         // in cycles of the clock
+	Clock     *Clock         // clock of the timestamp, nil if unknown
+	Fields    map[string]any // payload: uint64, int64, float64, string, []byte or []any
+}
+
+// decoder reads the fields of a stream bit by bit.
+type decoder struct {
+	data      []byte
+	pos       int // in bits
+	end       int // in bits
+	bigEndian bool
+	clocks    map[string]*Clock
+	clock     *Clock // clock of the last timestamp
+	timestamp uint64 // last value of the clock
+}
+
+func (d *decoder) align(bits int) {
+	if bits > 1 {
+		d.pos = (d.pos + bits - 1) / bits * bits
+	}
+}
+
+func (d *decoder) check(bits int) error {
+	if d.pos+bits > d.end {
+		return fmt.Errorf("%w: truncated at byte %d", errStream, d.pos/8)
+	}
+	return nil
+}
+
+// bits reads an unsigned integer of up to 64 bits.
+func (d *decoder) bits(size int, bigEndian bool) (uint64, error) {
+	if err := d.check(size); err != nil {
+		return 0, err
+	}
+	var v uint64
+	if d.pos%8 == 0 && size%8 == 0 {
+		b := d.data[d.pos/8 : d.pos/8+size/8]
+		for i := range b {
+			if bigEndian {
+				v = v<<8 | uint64(b[i])
+			} else {
+				v |= uint64(b[i]) << (8 * i)
+			}
+		}
+		d.pos += size
+		return v, nil
+	}
+	for i := 0; i < size; i++ {
+		p := d.pos + i
+		if bigEndian {
+			bit := d.data[p/8] >> (7 - p%8) & 1
+			v = v<<1 | uint64(bit)
+		} else {
+			bit := d.data[p/8] >> (p % 8) & 1
+			v |= uint64(bit) << i
+		}
+	}
+	d.pos += size
+	return v, nil
+}
+
+// scope is the chain of the structures being read, used to look up the
+// length of sequences.
+type scope struct {
+	fields map[string]any
+	parent *scope
+}
+
+func (s *scope) lookup(path string) (any, bool) {
+	names := strings.Split(path, ".")
+	// leading names may be scope prefixes like event.fields
+	for i := range names {
+		for sc := s; sc != nil; sc = sc.parent {
+			if v, ok := member(sc.fields, names[i:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// member returns a field of nested structures given by the names of the path.
+func member(fields map[string]any, names []string) (any, bool) {
+	v, ok := fields[names[0]]
+	for _, name := range names[1:] {
+		if !ok {
+			break
+		}
+		var m map[string]any
+		if m, ok = v.(map[string]any); ok {
+			v, ok = m[name]
+		}
+	}
+	return v, ok
+}
+
+// read reads a value of a type.
+func (d *decoder) read(t *typ, sc *scope) (any, error) {
+	d.align(t.align)
+	switch t.kind {
+	case kindInteger:
+		bigEndian := t.order == orderBE || t.order == orderNative && d.bigEndian
+		v, err := d.bits(t.size, bigEndian)
+		if err != nil {
+			return nil, err
+		}
+		if t.clock != "" {
+			d.clock = d.clocks[t.clock]
+			d.updateClock(v, t.size)
+		}
+		if t.signed && t.size < 64 && v&(1<<(t.size-1)) != 0 {
+			return int64(v | ^uint64(0)<<t.size), nil
+		} else if t.signed {
+			return int64(v), nil
+		}
+		return v, nil
+	case kindFloat:
+		bigEndian := t.order == orderBE || t.order == orderNative && d.bigEndian
+		v, err := d.bits(t.size, bigEndian)
+		if err != nil {
+			return nil, err
+		}
+		if t.size == 32 {
+			return float64(math.Float32frombits(uint32(v))), nil
+		}
+		return math.Float64frombits(v), nil
+	case kindString:
+		start := d.pos / 8
+		for i := start; i < d.end/8; i++ {
+			if d.data[i] == 0 {
+				d.pos = (i + 1) * 8
+				return string(d.data[start:i]), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unterminated string", errStream)
+	case kindStruct:
+		return d.readStruct(t, sc)
+	case kindArray, kindSequence:
+		n := t.length
+		if t.kind == kindSequence {
+			v, ok := sc.lookup(t.ref)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown sequence length %s", errStream, t.ref)
+			}
+			switch l := v.(type) {
+			case uint64:
+				n = int(l)
+			case int64:
+				n = int(l)
+			default:
+				return nil, fmt.Errorf("%w: sequence length %s", errStream, t.ref)
+			}
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("%w: negative length", errStream)
+		}
+		if t.elem.kind == kindInteger && t.elem.size == 8 && t.elem.clock == "" && d.pos%8 == 0 {
+			if err := d.check(8 * n); err != nil {
+				return nil, err
+			}
+			b := make([]byte, n)
+			copy(b, d.data[d.pos/8:])
+			d.pos += 8 * n
+			return b, nil
+		}
+		values := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := d.read(t.elem, sc)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("%w: type", errUnsupported)
+}
+
+// readStruct reads the fields of a structure.
+func (d *decoder) readStruct(t *typ, parent *scope) (map[string]any, error) {
+	sc := &scope{fields: make(map[string]any, len(t.fields)), parent: parent}
+	d.align(t.align)
+	for _, f := range t.fields {
+		v, err := d.read(f.typ, sc)
+		if err != nil {
+			return nil, err
+		}
+		sc.fields[f.name] = v
+	}
+	return sc.fields, nil
+}
+
+// updateClock extends a timestamp of less than 64 bits to the full value of
+// the clock, assuming less than one wrap around since the last timestamp.
+func (d *decoder) updateClock(v uint64, size int) {
+	if size >= 64 {
+		d.timestamp = v
+		return
+	}
+	mask := uint64(1)<<size - 1
+	ts := d.timestamp&^mask | v
+	if v < d.timestamp&mask {
+		ts += mask + 1
+	}
+	d.timestamp = ts
+}
+
+// readOptional reads a structure which may be missing in the metadata.
+func (d *decoder) readOptional(t *typ, sc *scope) (map[string]any, error) {
+	if t == nil {
+		return map[string]any{}, nil
+	}
+	return d.readStruct(t, sc)
+}
+
+// number returns an integer field of a structure.
+func number(fields map[string]any, name string) (uint64, bool) {
+	switch v := fields[name].(type) {
+	case uint64:
+		return v, true
+	case int64:
+		return uint64(v), true
+	}
+	return 0, false
+}
+
+// ReadStream reads the events of a stream file.
+//
+// Parameters:
+//   - m: The metadata of the trace.
+//   - data: The content of the stream file.
+//
+// Returns:
+//   - The events in the order of the stream.
+//   - An error if the stream does not match the metadata.
+func ReadStream(m *Metadata, data []byte) ([]Event, error) {
+	d := &decoder{data: data, end: 8 * len(data), bigEndian: m.bigEndian, clocks: m.clocks}
+	var events []Event
+	for d.pos < 8*len(data) {
+		start := d.pos
+		d.end = 8 * len(data)
+		header, err := d.readOptional(m.packetHeader, nil)
+		if err != nil {
+			return nil, err
+		}
+		if magic, ok := number(header, "magic"); ok && magic != packetMagic {
+			return nil, fmt.Errorf("%w: magic 0x%X at byte %d", errStream, magic, start/8)
+		}
+		id, _ := number(header, "stream_id")
+		s, ok := m.streams[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown stream %d", errStream, id)
+		}
+		pctx, err := d.readOptional(s.packetContext, nil)
+		if err != nil {
+			return nil, err
+		}
+		if ts, ok := number(pctx, "timestamp_begin"); ok {
+			d.timestamp = ts
+		}
+		packetEnd := 8 * len(data)
+		if size, ok := number(pctx, "packet_size"); ok && size > 0 && start+int(size) <= packetEnd {
+			packetEnd = start + int(size)
+		}
+		d.end = packetEnd
+		if size, ok := number(pctx, "content_size"); ok && start+int(size) <= packetEnd {
+			d.end = start + int(size)
+		}
+		for d.pos < d.end {
+			pos := d.pos
+			ev, err := d.readEvent(s)
+			if err != nil {
+				return nil, err
+			}
+			if d.pos == pos {
+				return nil, fmt.Errorf("%w: empty event at byte %d", errStream, pos/8)
+			}
+			events = append(events, ev)
+		}
+		d.pos = packetEnd
+	}
+	return events, nil
+}
+
+// readEvent reads the header, the context and the payload of an event.
+func (d *decoder) readEvent(s *stream) (Event, error) {
+	var ev Event
+	header, err := d.readOptional(s.eventHeader, nil)
+	if err != nil {
+		return ev, err
+	}
+	id, _ := number(header, "id")
+	if v, ok := header["v"].(map[string]any); ok { // compact and large headers
+		if vid, ok := number(v, "id"); ok {
+			id = vid
+		}
+	}
+	class, ok := s.events[id]
+	if !ok {
+		return ev, fmt.Errorf("%w: unknown event id %d", errStream, id)
+	}
+	sc := &scope{fields: header}
+	if _, err = d.readOptional(s.eventContext, sc); err != nil {
+		return ev, err
+	}
+	if _, err = d.readOptional(class.context, sc); err != nil {
+		return ev, err
+	}
+	if ev.Fields, err = d.readOptional(class.fields, sc); err != nil {
+		return ev, err
+	}
+	ev.Name = class.name
+	ev.Timestamp = d.timestamp
+	ev.Clock = d.clock
+	return ev, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ctf
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testStreamMetadata = `
+typealias integer { size = 8; align = 8; signed = false; } := uint8_t;
+typealias integer { size = 16; align = 8; signed = false; } := uint16_t;
+typealias integer { size = 32; align = 8; signed = false; } := uint32_t;
+typealias integer { size = 64; align = 8; signed = false; map = clock.cycles.value; } := uint64_clock_t;
+typealias integer { size = 16; align = 8; signed = false; map = clock.cycles.value; } := uint16_clock_t;
+trace { major = 1; minor = 8; byte_order = be;
+	packet.header := struct { uint32_t magic; uint32_t stream_id; }; };
+clock { name = cycles; freq = 1000; };
+stream { id = 0;
+	packet.context := struct { uint64_clock_t timestamp_begin; uint32_t content_size; uint32_t packet_size; };
+	event.header := struct { uint8_t id; uint16_clock_t timestamp; };
+};
+event { name = "a"; id = 1; fields := struct {
+	uint8_t hi : 4;
+	uint8_t lo : 4;
+	integer { size = 8; signed = true; } s;
+	uint8_t n;
+	uint16_t seq[n];
+	string str;
+}; };
+`
+
+// testPacket returns a packet of the test stream with the events of the given
+// timestamps, the packet is padded to a multiple of 8 bytes.
+func testPacket(begin uint64, ts ...uint16) []byte {
+	var events []byte
+	for _, t := range ts {
+		events = append(events, 1)
+		events = binary.BigEndian.AppendUint16(events, t)
+		events = append(events, 0xA5, 0xFE, 2, 0, 1, 0, 2, 'h', 'i', 0)
+	}
+	content := 24 + len(events)
+	size := (content + 7) / 8 * 8
+	b := binary.BigEndian.AppendUint32(nil, packetMagic)
+	b = binary.BigEndian.AppendUint32(b, 0)
+	b = binary.BigEndian.AppendUint64(b, begin)
+	b = binary.BigEndian.AppendUint32(b, uint32(8*content))
+	b = binary.BigEndian.AppendUint32(b, uint32(8*size))
+	b = append(b, events...)
+	return append(b, make([]byte, size-content)...)
+}
+
+func TestReadStream(t *testing.T) {
+	t.Parallel()
+
+	m, err := ParseMetadata([]byte(testStreamMetadata))
+	if err != nil {
+		t.Fatalf("ParseMetadata() error = %v", err)
+	}
+	fields := map[string]any{
+		"hi": uint64(10), "lo": uint64(5), "s": int64(-2), "n": uint64(2),
+		"seq": []any{uint64(1), uint64(2)}, "str": "hi",
+	}
+	stream := append(testPacket(0x1FFF0, 0xFFF8, 0x0004), testPacket(0x30000, 0x0010)...)
+	badMagic := testPacket(0, 1)
+	badMagic[0] = 0
+	badID := testPacket(0, 1)
+	badID[24] = 7
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []uint64
+		wantErr error
+	}{
+		{"packets", stream, []uint64{0x1FFF8, 0x20004, 0x30010}, nil},
+		{"empty", nil, nil, nil},
+		{"magic", badMagic, nil, errStream},
+		{"event id", badID, nil, errStream},
+		{"truncated", stream[:30], nil, errStream},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ReadStream(m, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadStream() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ReadStream() %s = %d events, want %d", tt.name, len(got), len(tt.want))
+			}
+			for i, ev := range got {
+				if ev.Name != "a" || ev.Timestamp != tt.want[i] || ev.Clock != m.clocks["cycles"] {
+					t.Errorf("ReadStream() %s event %d = %s %d, want a %d", tt.name, i, ev.Name, ev.Timestamp, tt.want[i])
+				}
+				if !reflect.DeepEqual(ev.Fields, fields) {
+					t.Errorf("ReadStream() %s event %d fields = %v, want %v", tt.name, i, ev.Fields, fields)
+				}
+			}
+		})
+	}
+}
+
+func Test_decoder_bits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pos       int
+		size      int
+		bigEndian bool
+		want      uint64
+	}{
+		{"le byte", 0, 8, false, 0x12},
+		{"le word", 0, 16, false, 0x3412},
+		{"le low nibble", 0, 4, false, 0x2},
+		{"le high nibble", 4, 4, false, 0x1},
+		{"le across bytes", 4, 8, false, 0x41},
+		{"be word", 0, 16, true, 0x1234},
+		{"be high nibble", 0, 4, true, 0x1},
+		{"be across bytes", 4, 8, true, 0x23},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := decoder{data: []byte{0x12, 0x34}, pos: tt.pos, end: 16}
+			got, err := d.bits(tt.size, tt.bigEndian)
+			if err != nil || got != tt.want {
+				t.Errorf("decoder.bits() %s = 0x%X, %v, want 0x%X", tt.name, got, err, tt.want)
+			}
+			if d.pos != tt.pos+tt.size {
+				t.Errorf("decoder.bits() %s pos = %d, want %d", tt.name, d.pos, tt.pos+tt.size)
+			}
+		})
+	}
+}
@@ -19,8 +19,11 @@
 package elf
 
 import (
+	"debug/dwarf"
 	"debug/elf"
 	"errors"
+	"io"
+	"sort"
 	"strings"
 )
 
@@ -41,8 +44,27 @@ type symbol struct {
 	size uint64
 }
 
+type function struct {
+	name   string
+	addr   uint64
+	size   uint64
+	maxEnd uint64 // largest end address of this and all preceding functions
+}
+
+// line is a row of the DWARF line table, valid up to the address of the
+// next row. A line number of 0 marks the end of a sequence or code without
+// source line.
+type line struct {
+	addr uint64
+	file string
+	line int
+}
+
 type symbols struct {
-	symbols map[string]symbol
+	symbols   map[string]symbol
+	functions []function // sorted by address and name
+	lines     []line     // sorted by address
+	linesErr  error
 }
 
 var Symbols symbols
@@ -50,6 +72,8 @@ var Symbols symbols
 // Readelf reads the ELF file specified by the given filename and populates the sections and symbols.
 // It opens the ELF file, iterates through its sections, and appends relevant sections to the sections slice.
 // It also reads the symbols from the ELF file and stores them in the Symbols map.
+// The source lines are optional: if the DWARF line table cannot be read, it is
+// dropped and the error is kept for LineError.
 //
 // Parameters:
 //   - name: A pointer to the string containing the filename of the ELF file to be read.
@@ -83,7 +107,112 @@ func (s *sections) Readelf(name *string) error {
 	}
 	for _, s := range syms {
 		Symbols.symbols[s.Name] = symbol{s.Value, s.Size}
+		if elf.ST_TYPE(s.Info) == elf.STT_FUNC && s.Size > 0 {
+			// the address of a Thumb function has bit 0 set
+			Symbols.functions = append(Symbols.functions, function{name: s.Name, addr: s.Value &^ 1, size: s.Size})
+		}
+	}
+	Symbols.sortFunctions()
+	if err = Symbols.readLines(file); err != nil {
+		Symbols.lines = nil
 	}
+	Symbols.linesErr = err
+	return nil
+}
+
+// LineError returns the error that occurred while reading the DWARF line
+// table of the last ELF file, or nil if the source lines are available or
+// the file has no line table.
+func (s *symbols) LineError() error {
+	return s.linesErr
+}
+
+// sortFunctions sorts the functions by address and name for FindFunction.
+func (s *symbols) sortFunctions() {
+	sort.Slice(s.functions, func(i, j int) bool {
+		if s.functions[i].addr == s.functions[j].addr {
+			return s.functions[i].name < s.functions[j].name
+		}
+		return s.functions[i].addr < s.functions[j].addr
+	})
+	var maxEnd uint64
+	for i := range s.functions {
+		if end := s.functions[i].addr + s.functions[i].size; end > maxEnd {
+			maxEnd = end
+		}
+		s.functions[i].maxEnd = maxEnd
+	}
+}
+
+// baseName returns the file name without directory, which may be given
+// with Windows or POSIX separators.
+func baseName(name string) string {
+	if idx := strings.LastIndexAny(name, "/\\"); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+// readLines reads the line table of the DWARF debug information, if the
+// ELF file contains it.
+//
+// Parameters:
+//   - file: The opened ELF file.
+//
+// Returns:
+//   - error: An error if the debug information is corrupted, otherwise nil.
+func (s *symbols) readLines(file *elf.File) error {
+	if file.Section(".debug_line") == nil {
+		return nil
+	}
+	data, err := file.DWARF()
+	if err != nil {
+		return err
+	}
+	r := data.Reader()
+	for {
+		cu, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if cu == nil {
+			break
+		}
+		if cu.Tag != dwarf.TagCompileUnit {
+			r.SkipChildren()
+			continue
+		}
+		lr, err := data.LineReader(cu)
+		if err != nil {
+			return err
+		}
+		r.SkipChildren()
+		if lr == nil {
+			continue
+		}
+		var entry dwarf.LineEntry
+		for {
+			if err = lr.Next(&entry); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+			l := line{addr: entry.Address}
+			if !entry.EndSequence && entry.File != nil {
+				l.file = baseName(entry.File.Name)
+				l.line = entry.Line
+			}
+			s.lines = append(s.lines, l)
+		}
+	}
+	// the end of a sequence is sorted before a row starting at the same address
+	sort.SliceStable(s.lines, func(i, j int) bool {
+		if s.lines[i].addr == s.lines[j].addr {
+			return s.lines[i].file == "" && s.lines[j].file != ""
+		}
+		return s.lines[i].addr < s.lines[j].addr
+	})
 	return nil
 }
 
@@ -110,6 +239,24 @@ func (s *sections) GetString(addr uint64) string {
 	return ""
 }
 
+// Read returns size bytes of initialized data starting at addr.
+// It implements the memory.Reader interface for the loaded sections.
+//
+// Parameters:
+//   - addr: The address of the first byte.
+//   - size: The number of bytes to read.
+//
+// Returns:
+//   - The data, and true if the range is completely contained in one section.
+func (s *sections) Read(addr uint64, size uint64) ([]byte, bool) {
+	for _, es := range s.sections {
+		if addr >= es.addr && addr+size >= addr && addr+size <= es.addr+uint64(len(es.data)) {
+			return es.data[addr-es.addr : addr-es.addr+size], true
+		}
+	}
+	return nil, false
+}
+
 // Init initializes the symbols map and adds a new symbol with the given name, address, and size.
 // Parameters:
 //   - name: The name of the symbol to be added.
@@ -117,9 +264,35 @@ func (s *sections) GetString(addr uint64) string {
 //   - size: The size of the symbol.
 func (s *symbols) Init(name string, addr uint64, size uint64) {
 	s.symbols = make(map[string]symbol)
+	s.functions = nil
+	s.lines = nil
+	s.linesErr = nil
 	s.symbols[name] = symbol{addr, size}
 }
 
+// Add adds a symbol with the given name, address, and size to the symbols map.
+// Parameters:
+//   - name: The name of the symbol to be added.
+//   - addr: The address of the symbol.
+//   - size: The size of the symbol.
+func (s *symbols) Add(name string, addr uint64, size uint64) {
+	if s.symbols == nil {
+		s.symbols = make(map[string]symbol)
+	}
+	s.symbols[name] = symbol{addr, size}
+}
+
+// AddFunction adds a function with the given name, address, and size, it is found by FindFunction.
+// Parameters:
+//   - name: The name of the function to be added.
+//   - addr: The start address of the function.
+//   - size: The size of the function code.
+func (s *symbols) AddFunction(name string, addr uint64, size uint64) {
+	s.Add(name, addr, size)
+	s.functions = append(s.functions, function{name: name, addr: addr, size: size})
+	s.sortFunctions()
+}
+
 // GetAddrSize retrieves the address and size of a symbol by its name.
 // It returns the address, size, and a boolean indicating whether the symbol was found.
 //
@@ -137,3 +310,45 @@ func (s *symbols) GetAddrSize(name string) (addr uint64, size uint64, found bool
 	}
 	return sym.addr, sym.size, true
 }
+
+// FindFunction retrieves the function containing a code address, e.g. of a program counter.
+//
+// Parameters:
+//   - addr: The code address.
+//
+// Returns:
+//   - name: The name of the function if found, otherwise an empty string.
+//   - offset: The offset of the address from the start of the function.
+//   - found: A boolean indicating whether a function contains the address.
+func (s *symbols) FindFunction(addr uint64) (name string, offset uint64, found bool) {
+	// the innermost function is the one with the highest start address,
+	// functions starting before can only contain addr while maxEnd is above
+	i := sort.Search(len(s.functions), func(i int) bool { return s.functions[i].addr > addr })
+	for i--; i >= 0 && s.functions[i].maxEnd > addr; i-- {
+		f := s.functions[i]
+		if found && f.addr != addr-offset {
+			break
+		}
+		if addr < f.addr+f.size {
+			name, offset, found = f.name, addr-f.addr, true
+		}
+	}
+	return name, offset, found
+}
+
+// FindLine retrieves the source file and line of a code address from the DWARF line table.
+//
+// Parameters:
+//   - addr: The code address.
+//
+// Returns:
+//   - file: The source file name without directory if found, otherwise an empty string.
+//   - line: The line number if found, otherwise 0.
+//   - found: A boolean indicating whether a source line contains the address.
+func (s *symbols) FindLine(addr uint64) (file string, line int, found bool) {
+	i := sort.Search(len(s.lines), func(i int) bool { return s.lines[i].addr > addr })
+	if i == 0 || s.lines[i-1].line == 0 {
+		return "", 0, false
+	}
+	return s.lines[i-1].file, s.lines[i-1].line, true
+}
@@ -19,6 +19,11 @@
 package elf
 
 import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -98,6 +103,40 @@ func TestGetString(t *testing.T) {
 	}
 }
 
+func Test_sections_Read(t *testing.T) {
+	t.Parallel()
+
+	s := &sections{[]*elfSection{{"", 100, []uint8{0, 1, 2, 3}}, {"", 200, []uint8{4, 5}}}}
+
+	type args struct {
+		addr uint64
+		size uint64
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   []byte
+		wantOk bool
+	}{
+		{"first", args{101, 2}, []byte{1, 2}, true},
+		{"second", args{200, 2}, []byte{4, 5}, true},
+		{"empty", args{104, 0}, []byte{}, true},
+		{"beyond", args{102, 4}, nil, false},
+		{"gap", args{150, 1}, nil, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := s.Read(tt.args.addr, tt.args.size)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sections.Read() %s = %v,%v, want %v,%v", tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
 func Test_symbols_Init(t *testing.T) {
 	t.Parallel()
 
@@ -133,6 +172,31 @@ func Test_symbols_Init(t *testing.T) {
 	}
 }
 
+func Test_symbols_Add(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		symbols map[string]symbol
+		want    int
+	}{
+		{"empty", nil, 1},
+		{"add", map[string]symbol{"m": {1, 2}}, 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &symbols{symbols: tt.symbols}
+			s.Add("n", 123, 456)
+			if !reflect.DeepEqual(s.symbols["n"], symbol{123, 456}) || len(s.symbols) != tt.want {
+				t.Errorf("Test_symbols.Add() %s = %v, want %d symbols", tt.name, s, tt.want)
+			}
+		})
+	}
+}
+
 func Test_symbols_GetAddrSize(t *testing.T) {
 	t.Parallel()
 
@@ -178,3 +242,147 @@ func Test_symbols_GetAddrSize(t *testing.T) {
 		})
 	}
 }
+
+func Test_symbols_FindFunction(t *testing.T) { //nolint:golint,paralleltest
+	var s sections
+	name := "../../testdata/elfsym.elf"
+	if err := s.Readelf(&name); err != nil {
+		t.Fatalf("sections.Readelf() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		addr       uint64
+		wantName   string
+		wantOffset uint64
+		wantFound  bool
+	}{
+		{"start", 0x1000035c, "main", 0, true},
+		{"offset", 0x10000370, "main", 0x14, true},
+		{"thumb", 0x10001560, "Reset_Handler", 0, true},
+		{"end", 0x1000035c + 680, "", 0, false},
+		{"data", 0x38000010, "", 0, false},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotOffset, gotFound := Symbols.FindFunction(tt.addr)
+			if gotName != tt.wantName || gotOffset != tt.wantOffset || gotFound != tt.wantFound {
+				t.Errorf("symbols.FindFunction() %s = %s+0x%x %v, want %s+0x%x %v", tt.name,
+					gotName, gotOffset, gotFound, tt.wantName, tt.wantOffset, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_symbols_AddFunction(t *testing.T) {
+	t.Parallel()
+
+	s := &symbols{}
+	s.AddFunction("f", 0x100, 0x20)
+	if addr, size, ok := s.GetAddrSize("f"); !ok || addr != 0x100 || size != 0x20 {
+		t.Errorf("Test_symbols.AddFunction() symbol = %#x, %#x, %t", addr, size, ok)
+	}
+	if name, offset, ok := s.FindFunction(0x11F); !ok || name != "f" || offset != 0x1F {
+		t.Errorf("Test_symbols.AddFunction() function = %s, %#x, %t", name, offset, ok)
+	}
+
+	// nested and aliased functions, the innermost and then the first name is found
+	s.AddFunction("outer", 0x200, 0x100)
+	s.AddFunction("inner", 0x240, 0x10)
+	s.AddFunction("alias", 0x240, 0x10)
+	s.AddFunction("short", 0x280, 0x4)
+	tests := []struct {
+		addr       uint64
+		wantName   string
+		wantOffset uint64
+		wantFound  bool
+	}{
+		{0x200, "outer", 0, true},
+		{0x244, "alias", 4, true},
+		{0x250, "outer", 0x50, true},
+		{0x290, "outer", 0x90, true},
+		{0x300, "", 0, false},
+		{0x120, "", 0, false},
+	}
+	for _, tt := range tests {
+		if name, offset, ok := s.FindFunction(tt.addr); name != tt.wantName || offset != tt.wantOffset || ok != tt.wantFound {
+			t.Errorf("Test_symbols.AddFunction() %#x = %s+%#x %t, want %s+%#x %t", tt.addr,
+				name, offset, ok, tt.wantName, tt.wantOffset, tt.wantFound)
+		}
+	}
+}
+
+func Test_symbols_FindLine(t *testing.T) { //nolint:golint,paralleltest
+	fileSym := "../../testdata/elfsym.elf"
+	Symbols.Init("", 0, 0)
+	if err := Sections.Readelf(&fileSym); err != nil {
+		t.Fatalf("Test_symbols.FindLine() cannot open %s", fileSym)
+	}
+
+	tests := []struct {
+		name      string
+		addr      uint64
+		wantFile  string
+		wantLine  int
+		wantFound bool
+	}{
+		{"first", 0x1000035c, "Blinky.c", 88, true},
+		{"inside", 0x10000362, "Blinky.c", 89, true},
+		{"no line", 0x10000354, "", 0, false},
+		{"library", 0x10000232, "", 0, false},
+		{"before", 0, "", 0, false},
+		{"after", 0xFFFFFFFF, "", 0, false},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			file, line, found := Symbols.FindLine(tt.addr)
+			if file != tt.wantFile || line != tt.wantLine || found != tt.wantFound {
+				t.Errorf("Test_symbols.FindLine() %s = %s, %d, %t, want %s, %d, %t", tt.name, file, line, found, tt.wantFile, tt.wantLine, tt.wantFound)
+			}
+		})
+	}
+
+	if err := Symbols.LineError(); err != nil {
+		t.Errorf("Test_symbols.FindLine() line error = %v", err)
+	}
+
+	if got := baseName("RTE\\Device\\system.c"); got != "system.c" {
+		t.Errorf("baseName() = %s, want system.c", got)
+	}
+}
+
+func Test_sections_Readelf_badLines(t *testing.T) { //nolint:golint,paralleltest
+	data, err := os.ReadFile("../../testdata/elfsym.elf")
+	if err != nil {
+		t.Fatalf("Test_sections_Readelf_badLines() %v", err)
+	}
+	file, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Test_sections_Readelf_badLines() %v", err)
+	}
+	sect := file.Section(".debug_line")
+	if sect == nil {
+		t.Fatalf("Test_sections_Readelf_badLines() no .debug_line")
+	}
+	// unsupported version of the first line table, after the 32-bit unit length
+	binary.LittleEndian.PutUint16(data[sect.Offset+4:], 99)
+	name := filepath.Join(t.TempDir(), "badlines.elf")
+	if err = os.WriteFile(name, data, 0600); err != nil {
+		t.Fatalf("Test_sections_Readelf_badLines() %v", err)
+	}
+
+	var s sections
+	Symbols.Init("", 0, 0)
+	if err = s.Readelf(&name); err != nil {
+		t.Fatalf("sections.Readelf() error = %v", err)
+	}
+	if Symbols.LineError() == nil {
+		t.Errorf("symbols.LineError() = nil, want error")
+	}
+	if _, _, ok := Symbols.FindLine(0x1000035c); ok {
+		t.Errorf("symbols.FindLine() found a line")
+	}
+	if name, _, ok := Symbols.FindFunction(0x1000035c); !ok || name != "main" {
+		t.Errorf("symbols.FindFunction() = %s, %t, want main", name, ok)
+	}
+}
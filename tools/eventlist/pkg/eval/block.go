@@ -0,0 +1,388 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"math"
+	"strings"
+)
+
+var typeSizes = map[Type]uint32{
+	Uint8:  1,
+	Int8:   1,
+	Uint16: 2,
+	Int16:  2,
+	Uint32: 4,
+	Int32:  4,
+	Uint64: 8,
+	Int64:  8,
+	Float:  4,
+	Double: 8,
+}
+
+// Block returns a value holding a copy of target memory, e.g. a structure
+// read by a <read> element. The members are accessed with the . operator,
+// the pseudo members _addr and _count return the address and the number
+// of elements.
+//
+// Parameters:
+//   - data: The memory content.
+//   - addr: The target address of the memory.
+//   - typ: The name of the type or typedef of the memory.
+//
+// Returns:
+//
+//	The memory block value.
+func Block(data []byte, addr uint64, typ string) Value {
+	return Value{t: String, s: string(data), i: int64(addr), td: typ}
+}
+
+// Array returns a value holding the elements of an array or a linked list,
+// e.g. read by a <readlist> element. The elements are accessed with the
+// [] operator, the pseudo member _count returns the number of elements.
+func Array(elements []Value) Value {
+	return Value{t: List, l: append([]Value{}, elements...)}
+}
+
+// SizeOf returns the size in bytes of a scalar type or a typedef.
+//
+// Parameters:
+//   - typ: The name of the scalar type, e.g. uint32_t, or of the typedef.
+//   - typedefs: The known typedefs.
+//
+// Returns:
+//   - The size in bytes.
+//   - false if the type is unknown.
+func SizeOf(typ string, typedefs Typedefs) (uint32, bool) {
+	if sz, ok := typeSizes[ITypes[typ]]; ok {
+		return sz, true
+	}
+	td, ok := typedefs[typ]
+	return td.Size, ok
+}
+
+// Scalar converts target memory holding a scalar type
+// to an Integer or Floating value.
+//
+// Parameters:
+//   - data: The memory content, the size must match the type.
+//   - typ: The scalar type.
+//   - bigEndian: The byte order of the memory.
+//
+// Returns:
+//   - The value.
+//   - An error if the size of the memory does not match the type.
+func Scalar(data []byte, typ Type, bigEndian bool) (Value, error) {
+	sz, ok := typeSizes[typ]
+	if !ok || uint32(len(data)) != sz {
+		return Value{}, typeError("Scalar", "invalid size")
+	}
+	var u uint64
+	for i := range data {
+		if bigEndian {
+			u = u<<8 | uint64(data[i])
+		} else {
+			u |= uint64(data[i]) << (8 * i)
+		}
+	}
+	switch typ {
+	case Float:
+		return Value{t: Floating, f: float64(math.Float32frombits(uint32(u)))}, nil
+	case Double:
+		return Value{t: Floating, f: math.Float64frombits(u)}, nil
+	}
+	v := Value{t: Integer, i: int64(u)}
+	err := v.Cast(typ) // sign extension
+	return v, err
+}
+
+// IsBlock checks if the Value holds target memory.
+func (v *Value) IsBlock() bool {
+	return v.t == String && v.td != ""
+}
+
+// IsAddress checks if the Value is an integer given as target address, i.e.
+// the result of __FindSymbol or of the pseudo member _addr. Arithmetic on
+// the address keeps it, like pointer arithmetic.
+func (v *Value) IsAddress() bool {
+	return v.t == Integer && v.addr
+}
+
+// TypeName returns the type name of target memory, an empty string for other values.
+func (v *Value) TypeName() string {
+	if v.IsBlock() {
+		return v.td
+	}
+	return ""
+}
+
+// GetString returns the text of a string or of target memory
+// up to the first NUL character.
+func (v *Value) GetString() string {
+	if v.t != String {
+		return ""
+	}
+	if i := strings.IndexByte(v.s, 0); i >= 0 {
+		return v.s[:i]
+	}
+	return v.s
+}
+
+// GetBytes returns the content of target memory or of a string,
+// nil for other values.
+func (v *Value) GetBytes() []byte {
+	if v.t != String {
+		return nil
+	}
+	return []byte(v.s)
+}
+
+// memory returns the memory block or the array held by v itself or by the variable v refers to.
+func (v *Value) memory() (Value, bool) {
+	val := *v
+	if val.IsIdentifier() {
+		var err error
+		if val, err = val.getValue(); err != nil {
+			return val, false
+		}
+	}
+	return val, val.IsBlock() || (val.IsList() && v.IsIdentifier())
+}
+
+// element returns element i of an array or a memory block. Elements of a
+// scalar type are converted to their value.
+func (v *Value) element(i int64, typedefs Typedefs) (Value, error) {
+	if v.IsList() {
+		if i < 0 || i >= int64(len(v.l)) {
+			return Value{}, rangeError("element", "index")
+		}
+		el := v.l[i]
+		if ty, ok := ITypes[el.td]; ok && el.IsBlock() {
+			return Scalar([]byte(el.s), ty, false)
+		}
+		return el, nil
+	}
+	sz, ok := SizeOf(v.td, typedefs)
+	if !ok || sz == 0 {
+		return Value{}, typeError("element", v.td)
+	}
+	if i < 0 || uint64(i+1)*uint64(sz) > uint64(len(v.s)) {
+		return Value{}, rangeError("element", "index")
+	}
+	el := Value{t: String, s: v.s[uint32(i)*sz : uint32(i+1)*sz], i: v.i + i*int64(sz), td: v.td}
+	if ty, ok := ITypes[v.td]; ok {
+		return Scalar([]byte(el.s), ty, v.big)
+	}
+	return el, nil
+}
+
+// member returns a member of a memory block or one of the pseudo members
+// _addr and _count of a memory block or an array.
+func (v *Value) member(name string, typedefs Typedefs, tdUsed map[string]string) (Value, error) {
+	switch name {
+	case "_count":
+		if v.IsList() {
+			return Value{t: Integer, i: int64(len(v.l))}, nil
+		}
+		sz, ok := SizeOf(v.td, typedefs)
+		if !ok || sz == 0 {
+			return Value{t: Integer, i: 1}, nil
+		}
+		return Value{t: Integer, i: int64(uint32(len(v.s)) / sz)}, nil
+	case "_addr":
+		if v.IsList() {
+			if len(v.l) == 0 {
+				return Value{t: Integer}, nil
+			}
+			return Value{t: Integer, i: v.l[0].i, addr: true}, nil
+		}
+		return Value{t: Integer, i: v.i, addr: true}, nil
+	}
+	if v.IsList() {
+		return Value{}, typeError("member", name)
+	}
+	td, ok := typedefs[v.td]
+	if !ok {
+		return Value{}, typeError("member", v.td)
+	}
+	m, ok := td.Members[name]
+	if !ok {
+		return Value{}, syntaxError(name+" unknown in "+v.td, "")
+	}
+	return td.field(m, name, v, typedefs, tdUsed)
+}
+
+// typeName returns the name of the type of a member.
+func (m *Member) typeName() string {
+	if m.Type != "" {
+		return m.Type
+	}
+	for name, ty := range ITypes {
+		if ty == m.IType {
+			return name
+		}
+	}
+	return ""
+}
+
+// bigEndian returns the byte order of a member of a typedef.
+func (m *Member) bigEndian(td *ITypedef) bool {
+	switch strings.TrimSpace(m.Endian) {
+	case "B", "b":
+		return true
+	case "L", "l":
+		return false
+	}
+	return td.BigEndian
+}
+
+// field reads a member of a typedef from target memory. A member whose type
+// is a typedef, and an array, i.e. a member whose size is larger than its
+// type, return target memory. A scalar member returns its value, with only
+// the bits of a bit-field.
+//
+// Parameters:
+//   - m: The member.
+//   - name: The name of the member.
+//   - mem: The target memory holding the typedef.
+//   - typedefs: The known typedefs.
+//   - tdUsed: The typedefs of the values val1..val6, for the offset expression.
+//
+// Returns:
+//   - The value of the member.
+//   - An error if the member is not contained in the memory or its type is unknown.
+func (td *ITypedef) field(m Member, name string, mem *Value, typedefs Typedefs, tdUsed map[string]string) (Value, error) {
+	off, err := Eval(&m.Offset, typedefs, tdUsed)
+	if err != nil {
+		return Value{}, err
+	}
+	if !off.IsInteger() {
+		return off, syntaxError("integer offset expected", "")
+	}
+	typ := m.typeName()
+	sz, ok := SizeOf(typ, typedefs)
+	if !ok || sz == 0 {
+		return Value{}, typeError("member", name)
+	}
+	size := sz
+	if m.Size > sz {
+		size = m.Size
+	}
+	if off.i < 0 || uint64(off.i)+uint64(size) > uint64(len(mem.s)) {
+		return Value{}, rangeError("member", name)
+	}
+	data := mem.s[off.i : off.i+int64(size)]
+	big := m.bigEndian(td)
+	ty, scalar := ITypes[typ]
+	if !scalar || size > sz {
+		return Value{t: String, s: data, i: mem.i + off.i, td: typ, big: big}, nil
+	}
+	v, err := Scalar([]byte(data), ty, big)
+	if err != nil || m.BitWidth == 0 {
+		return v, err
+	}
+	return v, v.bits(m.BitLow, m.BitWidth, ty)
+}
+
+// bits keeps the bits of a bit-field of an integer value, the bit-field
+// of a signed type is sign extended.
+//
+// Parameters:
+//   - low: The first bit.
+//   - width: The number of bits.
+//   - ty: The type of the value.
+//
+// Returns:
+//   - An error if the value is not an integer or the bits exceed 64 bits.
+func (v *Value) bits(low, width uint32, ty Type) error {
+	if v.t != Integer || width == 0 || low+width > 64 {
+		return typeError("bits", "")
+	}
+	u := uint64(v.i) >> low
+	if width < 64 {
+		u &= 1<<width - 1
+	}
+	v.i = int64(u)
+	switch ty {
+	case Int8, Int16, Int32, Int64:
+		if width < 64 && u&(1<<(width-1)) != 0 {
+			v.i = int64(u | ^uint64(0)<<width)
+		}
+	}
+	return nil
+}
+
+// payload is the data of the current event with data, see SetPayload.
+var payload []byte
+
+// SetPayload sets the data of the current EventRecordData event. The
+// members of the values val1..val6 with a typedef are read from the data
+// at the position of the value, e.g. at byte 4 for val2, so typedefs larger
+// than a value describe the structure of the data. Without data, nil, the
+// members are read from the bytes of the value.
+//
+// Parameters:
+//   - data: The data of the event, nil for events with values.
+func SetPayload(data []byte) {
+	mu.Lock()
+	payload = data
+	mu.Unlock()
+}
+
+// valueMemory returns the memory holding a value with a typedef: the data
+// of the event at the position of the value, or the bytes of the value. The
+// bytes of a value in a big-endian typedef start with the most significant
+// byte of the typedef size.
+//
+// Parameters:
+//   - name: The name of the value, e.g. val1.
+//   - val: The value.
+//   - typ: The name of the typedef.
+//   - td: The typedef.
+//
+// Returns:
+//   - The memory.
+//   - An error if the value is not an integer.
+func valueMemory(name string, val Value, typ string, td *ITypedef) (Value, error) {
+	mu.Lock()
+	data := payload
+	mu.Unlock()
+	if data != nil && len(name) == 4 && strings.HasPrefix(name, "val") && name[3] >= '1' && name[3] <= '6' {
+		if off := 4 * int(name[3]-'1'); off < len(data) {
+			return Block(data[off:], 0, typ), nil
+		}
+	}
+	if val.t != Integer {
+		return Value{}, typeError("valueMemory", name)
+	}
+	n := td.Size
+	if n == 0 || n > 8 {
+		n = 8
+	}
+	var mem [8]byte
+	u := uint64(val.i)
+	for i := uint32(0); i < n; i++ {
+		if td.BigEndian {
+			mem[n-1-i] = byte(u >> (8 * i))
+		} else {
+			mem[i] = byte(u >> (8 * i))
+		}
+	}
+	return Block(mem[:], 0, typ), nil
+}
@@ -0,0 +1,384 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestScalar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      []byte
+		typ       Type
+		bigEndian bool
+		want      Value
+		wantErr   bool
+	}{
+		{"uint8", []byte{0xFF}, Uint8, false, Value{t: Integer, i: 0xFF}, false},
+		{"int8", []byte{0xFF}, Int8, false, Value{t: Integer, i: -1}, false},
+		{"uint16", []byte{0x34, 0x12}, Uint16, false, Value{t: Integer, i: 0x1234}, false},
+		{"uint16 big", []byte{0x12, 0x34}, Uint16, true, Value{t: Integer, i: 0x1234}, false},
+		{"int32", []byte{0xFE, 0xFF, 0xFF, 0xFF}, Int32, false, Value{t: Integer, i: -2}, false},
+		{"float", []byte{0x00, 0x00, 0xC0, 0x3F}, Float, false, Value{t: Floating, f: 1.5}, false},
+		{"double", []byte{0, 0, 0, 0, 0, 0, 0xF8, 0x3F}, Double, false, Value{t: Floating, f: 1.5}, false},
+		{"size", []byte{0x12}, Uint16, false, Value{}, true},
+		{"type", []byte{0x12}, NoType, false, Value{}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Scalar(tt.data, tt.typ, tt.bigEndian)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scalar() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scalar() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSizeOf(t *testing.T) {
+	t.Parallel()
+
+	typedefs := Typedefs{"T": {Size: 12}}
+	tests := []struct {
+		name   string
+		typ    string
+		want   uint32
+		wantOk bool
+	}{
+		{"uint8", "uint8_t", 1, true},
+		{"double", "double", 8, true},
+		{"typedef", "T", 12, true},
+		{"unknown", "X", 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := SizeOf(tt.typ, typedefs)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("SizeOf() %s = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestValue_GetString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    Value
+		want string
+	}{
+		{"string", Value{t: String, s: "abc"}, "abc"},
+		{"block", Block([]byte("1.2\x00\x00"), 0x100, "VersionString_t"), "1.2"},
+		{"integer", Value{t: Integer, i: 1}, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.v.GetString(); got != tt.want {
+				t.Errorf("Value.GetString() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValue_GetBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    Value
+		want []byte
+	}{
+		{"string", Value{t: String, s: "abc"}, []byte("abc")},
+		{"block", Block([]byte{1, 0, 2}, 0x100, "uint8_t"), []byte{1, 0, 2}},
+		{"integer", Value{t: Integer, i: 1}, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.v.GetBytes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Value.GetBytes() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlock_expressions(t *testing.T) { //nolint:golint,paralleltest
+	typedefs := Typedefs{
+		"T": {Size: 8, Members: map[string]Member{
+			"a": {Offset: "0", IType: Uint16},
+			"b": {Offset: "2", IType: Int16},
+			"c": {Offset: "4", IType: Uint32},
+		}},
+		"B": {Size: 4, BigEndian: true, Members: map[string]Member{
+			"a": {Offset: "0", IType: Uint32},
+		}},
+	}
+	data := []byte{0x01, 0x00, 0xFF, 0xFF, 0x78, 0x56, 0x34, 0x12}
+	SetVar("blk", Block(data, 0x20000000, "T"))
+	SetVar("big", Block([]byte{0x12, 0x34, 0x56, 0x78}, 0x20000100, "B"))
+	SetVar("arr", Array([]Value{
+		Block(data, 0x20000000, "T"),
+		Block([]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, 0x20000008, "T"),
+	}))
+	SetVar("u16", Array([]Value{Block([]byte{0x34, 0x12}, 0x20000010, "uint16_t")}))
+	SetVar("raw", Block([]byte{0x01, 0x00, 0x02, 0x00}, 0x20000020, "uint16_t"))
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    int64
+		wantErr bool
+	}{
+		{"member", "blk.a", 1, false},
+		{"signed member", "blk.b", -1, false},
+		{"member expression", "blk.c + 1", 0x12345679, false},
+		{"big endian", "big.a", 0x12345678, false},
+		{"addr", "blk._addr", 0x20000000, false},
+		{"count", "blk._count", 1, false},
+		{"array count", "arr._count", 2, false},
+		{"array addr", "arr._addr", 0x20000000, false},
+		{"element member", "arr[1].a", 2, false},
+		{"element addr", "arr[1]._addr", 0x20000008, false},
+		{"scalar element", "u16[0]", 0x1234, false},
+		{"block element", "raw[1]", 2, false},
+		{"block count", "raw._count", 2, false},
+		{"unknown member", "blk.x", 0, true},
+		{"index", "arr[2].a", 0, true},
+		{"block index", "raw[2]", 0, true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			expr := tt.expr
+			got, err := Eval(&expr, typedefs, nil)
+			if errors.Is(err, ErrEof) {
+				err = nil
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Eval() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.GetInt() != tt.want {
+				t.Errorf("Eval() %s = 0x%X, want 0x%X", tt.name, got.GetInt(), tt.want)
+			}
+		})
+	}
+
+	for expr, want := range map[string]bool{"blk._addr + 8": true, "arr._addr": true, "blk.a": false, "0x20000000": false} {
+		e := expr
+		if got, _ := Eval(&e, typedefs, nil); got.IsAddress() != want {
+			t.Errorf("Eval() %s IsAddress = %v, want %v", expr, got.IsAddress(), want)
+		}
+	}
+}
+
+func TestBlock_typedefMembers(t *testing.T) { //nolint:golint,paralleltest
+	typedefs := Typedefs{
+		"Hdr": {Size: 4, Members: map[string]Member{
+			"len":  {Offset: "0", IType: Uint16},
+			"kind": {Offset: "2", IType: Uint8, Type: "uint8_t"},
+		}},
+		"Msg": {Size: 20, Members: map[string]Member{
+			"hdr":   {Offset: "0", Type: "Hdr"},
+			"bytes": {Offset: "4", IType: Uint8, Type: "uint8_t", Size: 4},
+			"words": {Offset: "8", IType: Uint16, Type: "uint16_t", Size: 4, Endian: "B"},
+			"flag":  {Offset: "12", IType: Uint8, Type: "uint8_t", BitLow: 3, BitWidth: 3},
+			"delta": {Offset: "12", IType: Int8, Type: "int8_t", BitLow: 6, BitWidth: 2},
+			"be":    {Offset: "14", IType: Uint16, Type: "uint16_t", Endian: "b"},
+			"hdrs":  {Offset: "12", Type: "Hdr", Size: 8},
+			"nix":   {Offset: "0", Type: "Nix"},
+			"far":   {Offset: "18", IType: Uint32, Type: "uint32_t"},
+		}},
+		"Big": {Size: 4, BigEndian: true, Members: map[string]Member{
+			"w":  {Offset: "0", IType: Uint16},
+			"le": {Offset: "2", IType: Uint16, Endian: "L"},
+		}},
+	}
+	data := []byte{
+		0x10, 0x00, 0x07, 0x00, // hdr
+		0x01, 0x02, 0x03, 0x04, // bytes
+		0x12, 0x34, 0x56, 0x78, // words
+		0xA8, 0x00, 0xAB, 0xCD, // flag, delta, be
+		0x02, 0x00, 0x09, 0x00, // hdrs[1]
+	}
+	SetVar("msg", Block(data, 0x20000000, "Msg"))
+	SetVar("big", Block([]byte{0x12, 0x34, 0x56, 0x78}, 0x20000100, "Big"))
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    int64
+		wantErr bool
+	}{
+		{"nested", "msg.hdr.len", 0x10, false},
+		{"nested type", "msg.hdr.kind", 7, false},
+		{"nested addr", "msg.hdr._addr", 0x20000000, false},
+		{"array", "msg.bytes[2]", 3, false},
+		{"array count", "msg.bytes._count", 4, false},
+		{"array addr", "msg.bytes._addr", 0x20000004, false},
+		{"big endian array", "msg.words[1]", 0x5678, false},
+		{"bit-field", "msg.flag", 5, false},
+		{"signed bit-field", "msg.delta", -2, false},
+		{"member endian", "msg.be", 0xABCD, false},
+		{"typedef array", "msg.hdrs[1].kind", 9, false},
+		{"typedef array count", "msg.hdrs._count", 2, false},
+		{"typedef endian", "big.w", 0x1234, false},
+		{"little endian member", "big.le", 0x7856, false},
+		{"unknown type", "msg.nix", 0, true},
+		{"outside", "msg.far", 0, true},
+		{"array index", "msg.bytes[4]", 0, true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			expr := tt.expr
+			got, err := Eval(&expr, typedefs, nil)
+			if errors.Is(err, ErrEof) {
+				err = nil
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Eval() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.GetInt() != tt.want {
+				t.Errorf("Eval() %s = 0x%X, want 0x%X", tt.name, got.GetInt(), tt.want)
+			}
+		})
+	}
+}
+
+func TestValue_bits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		v       Value
+		low     uint32
+		width   uint32
+		ty      Type
+		want    int64
+		wantErr bool
+	}{
+		{"unsigned", Value{t: Integer, i: 0xA8}, 3, 3, Uint8, 5, false},
+		{"signed", Value{t: Integer, i: 0xA8}, 6, 2, Int8, -2, false},
+		{"signed positive", Value{t: Integer, i: 0x28}, 4, 3, Int8, 2, false},
+		{"all", Value{t: Integer, i: -1}, 0, 64, Uint64, -1, false},
+		{"too wide", Value{t: Integer, i: 1}, 60, 8, Uint64, 0, true},
+		{"floating", Value{t: Floating, f: 1}, 0, 1, Float, 0, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := tt.v
+			err := v.bits(tt.low, tt.width, tt.ty)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Value.bits() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr && v.i != tt.want {
+				t.Errorf("Value.bits() %s = %d, want %d", tt.name, v.i, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_valueMembers(t *testing.T) { //nolint:golint,paralleltest
+	defer SetPayload(nil)
+
+	typedefs := Typedefs{
+		"W": {Size: 4, Members: map[string]Member{
+			"lo": {Offset: "0", IType: Uint16},
+			"b2": {Offset: "2", IType: Uint8},
+		}},
+		"BW": {Size: 4, BigEndian: true, Members: map[string]Member{
+			"hi": {Offset: "0", IType: Uint16},
+			"b3": {Offset: "3", IType: Uint8},
+		}},
+		"Pkt": {Size: 12, Members: map[string]Member{
+			"id":   {Offset: "0", IType: Uint32},
+			"mac":  {Offset: "4", IType: Uint8, Type: "uint8_t", Size: 6},
+			"port": {Offset: "10", IType: Uint16},
+		}},
+	}
+	tdUsed := map[string]string{"val1": "W", "val2": "BW", "val3": "Pkt"}
+	payload := []byte{
+		0x78, 0x56, 0x34, 0x12, 0x78, 0x56, 0x34, 0x12, // val1, val2
+		0x11, 0x00, 0x00, 0x00, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x90, 0x1F, // val3
+	}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		expr    string
+		want    int64
+		wantErr bool
+	}{
+		{"value", nil, "val1.lo", 0x5678, false},
+		{"value byte", nil, "val1.b2", 0x34, false},
+		{"big endian value", nil, "val2.hi", 0x1234, false},
+		{"big endian byte", nil, "val2.b3", 0x78, false},
+		{"value outside", nil, "val3.port", 0, true},
+		{"value typedef", nil, "val3.id", 1, false},
+		{"payload", payload, "val1.lo", 0x5678, false},
+		{"payload big endian", payload, "val2.hi", 0x7856, false},
+		{"payload member", payload, "val3.id", 0x11, false},
+		{"payload array", payload, "val3.mac[2]", 0x0C, false},
+		{"payload typedef", payload, "val3.port", 0x1F90, false},
+		{"short payload", payload[:16], "val3.port", 0, true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			SetPayload(tt.payload)
+			SetVarI("val1", 0x12345678)
+			SetVarI("val2", 0x12345678)
+			SetVarI("val3", 1)
+			expr := tt.expr
+			got, err := Eval(&expr, typedefs, tdUsed)
+			if errors.Is(err, ErrEof) {
+				err = nil
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Eval() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.GetInt() != tt.want {
+				t.Errorf("Eval() %s = 0x%X, want 0x%X", tt.name, got.GetInt(), tt.want)
+			}
+		})
+	}
+}
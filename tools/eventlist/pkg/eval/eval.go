@@ -23,9 +23,14 @@ import (
 )
 
 type Member struct {
-	Offset string
-	IType  Type
-	Enums  map[int64]string
+	Offset   string
+	IType    Type
+	Enums    map[int64]string
+	Type     string // name of the type, e.g. the typedef of a nested structure
+	Size     uint32 // size in bytes, an array if larger than the type, 0 for the size of the type
+	Endian   string // B or L, empty for the byte order of the typedef
+	BitLow   uint32 // first bit of a bit-field
+	BitWidth uint32 // number of bits of a bit-field, 0 if the member is not a bit-field
 }
 type ITypedef struct {
 	Size      uint32
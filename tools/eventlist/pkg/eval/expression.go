@@ -608,7 +608,7 @@ func (ex *Expression) lex() (Value, error) {
 		if err != nil {
 			return v, err
 		}
-		if c != ' ' && c != '\t' && c != '\f' {
+		if c != ' ' && c != '\t' && c != '\f' && c != '\n' && c != '\r' && c != '\v' {
 			break
 		}
 	}
@@ -632,7 +632,7 @@ func (ex *Expression) lex() (Value, error) {
 		}
 		return Value{t: Integer, i: int64(ui)}, nil
 
-	} else if 'a' <= lower(c) && lower(c) <= 'z' {
+	} else if 'a' <= lower(c) && lower(c) <= 'z' || c == '_' {
 	loop:
 		for {
 			if c, err = ex.get(); err != nil {
@@ -1030,129 +1030,149 @@ func (ex *Expression) postfix() (Value, error) { // TODO: not finished yet
 	if left, err = ex.primary(); err != nil {
 		return left, err
 	}
-	switch ex.next.t {
-	case AddAdd:
-		if !left.IsIdentifier() {
-			return left, syntaxError("identifier expected", "")
-		}
-		if v, err = left.getValue(); err != nil {
-			return left, err
-		}
-		if err = v.Inc(); err != nil {
-			return v, err
-		}
-		if err = left.setValue(&v); err != nil { // do not change left, it is postincrement
-			return left, err // cannot happen because of working getValue
-		}
-		if ex.next, err = ex.lex(); err != nil {
-			return left, err
-		}
-	case SubSub:
-		if !left.IsIdentifier() {
-			return left, syntaxError("identifier expected", "")
-		}
-		if v, err = left.getValue(); err != nil {
-			return left, err
-		}
-		if err = v.Dec(); err != nil {
-			return v, err
-		}
-		if err = left.setValue(&v); err != nil { // do not change left, it is postdecrement
-			return left, err // cannot happen because of working getValue
-		}
-		if ex.next, err = ex.lex(); err != nil {
-			return left, err
-		}
-	case Dot:
-		if ex.next, err = ex.lex(); err != nil {
-			return left, err
-		}
-		if !left.IsIdentifier() {
-			return left, syntaxError("identifier expected", "")
-		}
-		if !ex.next.IsIdentifier() {
-			return ex.next, syntaxError("identifier expected", "")
-		}
-		members, ok := ex.typedefs[ex.tdUsed[left.s]]
-		if ok { // it is a val* typedef
-			member, ok := members.Members[ex.next.s]
-			if ok { // field found
-				if right, err = Eval(&member.Offset, ex.typedefs, ex.tdUsed); err != nil {
-					return ex.next, err
+	for {
+		switch ex.next.t {
+		case AddAdd:
+			if !left.IsIdentifier() {
+				return left, syntaxError("identifier expected", "")
+			}
+			if v, err = left.getValue(); err != nil {
+				return left, err
+			}
+			if err = v.Inc(); err != nil {
+				return v, err
+			}
+			if err = left.setValue(&v); err != nil { // do not change left, it is postincrement
+				return left, err // cannot happen because of working getValue
+			}
+			if ex.next, err = ex.lex(); err != nil {
+				return left, err
+			}
+		case SubSub:
+			if !left.IsIdentifier() {
+				return left, syntaxError("identifier expected", "")
+			}
+			if v, err = left.getValue(); err != nil {
+				return left, err
+			}
+			if err = v.Dec(); err != nil {
+				return v, err
+			}
+			if err = left.setValue(&v); err != nil { // do not change left, it is postdecrement
+				return left, err // cannot happen because of working getValue
+			}
+			if ex.next, err = ex.lex(); err != nil {
+				return left, err
+			}
+		case Dot:
+			if ex.next, err = ex.lex(); err != nil {
+				return left, err
+			}
+			mem, isMem := left.memory()
+			if !left.IsIdentifier() && !isMem {
+				return left, syntaxError("identifier expected", "")
+			}
+			if !ex.next.IsIdentifier() {
+				return ex.next, syntaxError("identifier expected", "")
+			}
+			if isMem { // member of target memory
+				if left, err = mem.member(ex.next.s, ex.typedefs, ex.tdUsed); err != nil {
+					return left, err
 				}
-				if v, err = left.getValue(); err != nil {
+				if ex.next, err = ex.lex(); err != nil {
 					return left, err
 				}
-				if !right.IsInteger() {
-					return right, syntaxError("integer offset expected", "")
+				continue
+			}
+			typ := ex.tdUsed[left.s]
+			members, ok := ex.typedefs[typ]
+			if ok { // it is a val* typedef
+				if member, ok := members.Members[ex.next.s]; ok { // field found
+					if _, err = Eval(&member.Offset, ex.typedefs, ex.tdUsed); err != nil {
+						return ex.next, err
+					}
+					if v, err = left.getValue(); err != nil {
+						return left, err
+					}
+					if v, err = valueMemory(left.s, v, typ, &members); err != nil {
+						return v, err
+					}
+					if v, err = v.member(ex.next.s, ex.typedefs, ex.tdUsed); err != nil {
+						return v, err
+					}
+					if ex.next, err = ex.lex(); err != nil {
+						return v, err
+					}
+					if ex.next.t < Assign || ex.next.t > ModAssign { // an assignment to a member assigns the variable
+						left = v
+					}
+					continue
 				}
-				if err = v.Extract(members.Size, members.BigEndian, uint32(right.i)); err != nil {
-					return v, err
+			}
+			if ex.next, err = ex.lex(); err != nil {
+				return ex.next, err
+			}
+		case Pointer:
+			if ex.next, err = ex.lex(); err != nil {
+				return left, err
+			}
+			if !left.IsIdentifier() {
+				return left, syntaxError("identifier expected", "")
+			}
+			if !ex.next.IsIdentifier() {
+				return ex.next, syntaxError("identifier expected", "")
+			} // TODO: noch nicht implementiert
+			if ex.next, err = ex.lex(); err != nil {
+				return ex.next, err
+			}
+		case ParenO:
+			if ex.next, err = ex.lex(); err != nil {
+				return left, syntaxError("expected \")\"", "")
+			}
+			if ex.next.t != ParenC {
+				if right, err = ex.arguments(); err != nil {
+					return left, err
 				}
-				if err = v.Cast(member.IType); err != nil {
-					return v, err
+				if ex.next.t != ParenC {
+					return left, syntaxError("expected \")\"", "")
+				}
+				if err = left.Function(&right); err != nil {
+					return left, err
 				}
-				left = v
-				ex.next, err = ex.lex()
-				return left, err
 			}
-		}
-		if ex.next, err = ex.lex(); err != nil {
-			return ex.next, err
-		}
-	case Pointer:
-		if ex.next, err = ex.lex(); err != nil {
-			return left, err
-		}
-		if !left.IsIdentifier() {
-			return left, syntaxError("identifier expected", "")
-		}
-		if !ex.next.IsIdentifier() {
-			return ex.next, syntaxError("identifier expected", "")
-		} // TODO: noch nicht implementiert
-		if ex.next, err = ex.lex(); err != nil {
-			return ex.next, err
-		}
-	case ParenO:
-		if ex.next, err = ex.lex(); err != nil {
-			return left, syntaxError("expected \")\"", "")
-		}
-		if ex.next.t != ParenC {
-			if right, err = ex.arguments(); err != nil {
+			if ex.next, err = ex.lex(); err != nil {
 				return left, err
 			}
-			if ex.next.t != ParenC {
-				return left, syntaxError("expected \")\"", "")
+		case BracketO:
+			if ex.next, err = ex.lex(); err != nil {
+				return left, syntaxError("expected expression", "")
 			}
-			if err = left.Function(&right); err != nil {
+			if right, err = ex.asnExpr(); err != nil {
 				return left, err
 			}
-		}
-		if ex.next, err = ex.lex(); err != nil {
-			return left, err
-		}
-	case BracketO:
-		if ex.next, err = ex.lex(); err != nil {
-			return left, syntaxError("expected expression", "")
-		}
-		if right, err = ex.asnExpr(); err != nil {
-			return left, err
-		}
-		if ex.next.t != BracketC {
-			return left, syntaxError("expected \"]\"", "")
-		}
-		v = right
-		if v.IsIdentifier() {
-			if v, err = v.getValue(); err != nil {
+			if ex.next.t != BracketC {
+				return left, syntaxError("expected \"]\"", "")
+			}
+			v = right
+			if v.IsIdentifier() {
+				if v, err = v.getValue(); err != nil {
+					return left, err
+				}
+			}
+			if mem, ok := left.memory(); ok { // element of target memory
+				if left, err = mem.element(v.GetInt(), ex.typedefs); err != nil {
+					return left, err
+				}
+			} else {
+				left.i = v.GetInt() // TODO: noch nicht implementiert
+			}
+			if ex.next, err = ex.lex(); err != nil {
 				return left, err
 			}
-		}
-		left.i = v.GetInt() // TODO: noch nicht implementiert
-		if ex.next, err = ex.lex(); err != nil {
-			return left, err
+		default:
+			return left, nil
 		}
 	}
-	return left, nil
 }
 
 // + postfix
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"encoding/binary"
+	"eventlist/pkg/memory"
+)
+
+// Target provides the target memory and the core registers to the
+// intrinsic functions __CalcMemUsed and __GetRegVal, e.g. the initialized
+// data of the application file, a memory image and a register file.
+type Target interface {
+	memory.Reader
+	// Register returns the value of a core register, false if it is unknown.
+	Register(name string) (uint64, bool)
+}
+
+var target Target
+
+// SetTarget sets the target used by the intrinsic functions. Without
+// target, or if the memory or register is not available, they return 0.
+//
+// Parameters:
+//   - t: The target, nil to remove it.
+func SetTarget(t Target) {
+	target = t
+}
+
+// Result bits of __CalcMemUsed.
+const (
+	memUsedMask     = 0xFFFFF    // bits 0..19: used memory in bytes
+	memPercentShift = 20         // bits 20..28: used memory in percent
+	memPercentMask  = 0x1FF      // 9 bits
+	memOverflow     = 1 << 31    // bit 31: magic value overwritten
+	memWord         = uint64(4)  // size of fill pattern and magic value
+	memMaxSize      = 0x10000000 // limit of the memory to be scanned
+)
+
+// calcMemUsed implements __CalcMemUsed like the Component Viewer: the
+// memory, e.g. a stack growing downwards, is filled with a pattern which
+// is overwritten when it is used. The first word holds the magic value,
+// if it is not 0.
+//
+// Parameters:
+//   - addr: The start address of the memory.
+//   - size: The size of the memory in bytes.
+//   - fill: The 32-bit fill pattern.
+//   - magic: The 32-bit magic value at the start address, 0 if not used.
+//
+// Returns:
+//
+//	The used memory in bytes (bits 0..19) and percent (bits 20..28),
+//	bit 31 is set if the magic value is overwritten.
+func calcMemUsed(addr, size, fill, magic uint64) int64 {
+	if target == nil || size == 0 || size > memMaxSize {
+		return 0
+	}
+	data, ok := target.Read(addr, size)
+	if !ok {
+		return 0
+	}
+	var result, unused uint64
+	i := uint64(0)
+	if magic != 0 {
+		if size < memWord || binary.LittleEndian.Uint32(data) != uint32(magic) {
+			result = memOverflow
+			i = size // the complete memory is used
+		} else {
+			unused = memWord
+			i = memWord
+		}
+	}
+	for ; i+memWord <= size && binary.LittleEndian.Uint32(data[i:]) == uint32(fill); i += memWord {
+		unused += memWord
+	}
+	used := size - unused
+	result |= used & memUsedMask
+	result |= (used * 100 / size & memPercentMask) << memPercentShift
+	return int64(result)
+}
+
+// getRegVal implements __GetRegVal, it returns the value of a core register.
+func getRegVal(name string) int64 {
+	if target == nil {
+		return 0
+	}
+	v, _ := target.Register(name)
+	return int64(v)
+}
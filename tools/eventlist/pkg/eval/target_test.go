@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"eventlist/pkg/memory"
+	"testing"
+)
+
+type testMemory map[uint64][]byte
+
+func (m testMemory) Read(addr uint64, size uint64) ([]byte, bool) {
+	for a, d := range m {
+		if addr >= a && addr+size <= a+uint64(len(d)) {
+			return d[addr-a : addr-a+size], true
+		}
+	}
+	return nil, false
+}
+
+// stack returns a stack of size bytes with the magic value at the bottom,
+// used from the top down to used bytes.
+func stack(size, used int, magic uint32) []byte {
+	data := bytes.Repeat([]byte{0xCC}, size)
+	binary.LittleEndian.PutUint32(data, magic)
+	for i := size - used; i < size; i++ {
+		data[i] = 0x11
+	}
+	return data
+}
+
+func Test_calcMemUsed(t *testing.T) { //nolint:golint,paralleltest
+	const magic = 0xE25A2EA5
+	const fill = 0xCCCCCCCC
+	defer SetTarget(nil)
+
+	tests := []struct {
+		name   string
+		data   []byte
+		addr   uint64
+		size   uint64
+		magic  uint64
+		target bool
+		want   int64
+	}{
+		{"no target", stack(256, 64, magic), 0x20000000, 256, magic, false, 0},
+		{"quarter", stack(256, 64, magic), 0x20000000, 256, magic, true, 64 | 25<<20},
+		{"unused", stack(256, 0, magic), 0x20000000, 256, magic, true, 0},
+		{"overflow", stack(256, 0, 0x12345678), 0x20000000, 256, magic, true, 256 | 100<<20 | 1<<31},
+		{"no magic", stack(256, 128, fill), 0x20000000, 256, 0, true, 128 | 50<<20},
+		{"no magic full", stack(256, 256, fill), 0x20000000, 256, 0, true, 256 | 100<<20},
+		{"not readable", stack(256, 64, magic), 0x20000100, 256, magic, true, 0},
+		{"size 0", stack(256, 64, magic), 0x20000000, 0, magic, true, 0},
+		{"small", stack(4, 0, magic), 0x20000000, 2, magic, true, 2 | 100<<20 | 1<<31},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			SetTarget(nil)
+			if tt.target {
+				SetTarget(memory.Target{Reader: testMemory{0x20000000: tt.data}})
+			}
+			if got := calcMemUsed(tt.addr, tt.size, fill, tt.magic); got != tt.want {
+				t.Errorf("calcMemUsed() %s = %#x, want %#x", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFunction_target(t *testing.T) { //nolint:golint,paralleltest
+	SetTarget(memory.Target{
+		Reader:    testMemory{0x20000000: stack(64, 16, 0xE25A2EA5)},
+		Registers: memory.Registers{"PSP": 0x20000030},
+	})
+	defer SetTarget(nil)
+
+	tests := []struct {
+		name string
+		expr string
+		want int64
+	}{
+		{"GetRegVal", "__GetRegVal(\"PSP\")", 0x20000030},
+		{"GetRegVal unknown", "__GetRegVal(\"R0\")", 0},
+		{"CalcMemUsed", "__CalcMemUsed(0x20000000, 64, 0xCCCCCCCC, 0xE25A2EA5) & 0xFFFFF", 16},
+		{"CalcMemUsed percent", "(__CalcMemUsed(0x20000000, 64, 0xCCCCCCCC, 0xE25A2EA5) >> 20) & 0x1FF", 25},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			expr := tt.expr
+			got, err := Eval(&expr, nil, nil)
+			if err != nil {
+				t.Fatalf("Eval() %s error = %v", tt.name, err)
+			}
+			if got.GetInt() != tt.want {
+				t.Errorf("Eval() %s = %#x, want %#x", tt.name, got.GetInt(), tt.want)
+			}
+		})
+	}
+}
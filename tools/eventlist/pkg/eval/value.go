@@ -25,12 +25,15 @@ import (
 )
 
 type Value struct {
-	t Token
-	i int64
-	f float64
-	s string
-	v *Variable
-	l []Value
+	t    Token
+	i    int64
+	f    float64
+	s    string
+	v    *Variable
+	l    []Value
+	td   string // type of target memory, see Block
+	big  bool   // byte order of the scalar elements of target memory
+	addr bool   // integer holding a target address, see IsAddress
 }
 
 // Compose sets the fields of the Value struct with the provided parameters.
@@ -41,7 +44,7 @@ type Value struct {
 //   - f: A float64 representing a floating-point value.
 //   - s: A string representing a string value.
 func (v *Value) Compose(t Token, i int64, f float64, s string) {
-	*v = Value{t, i, f, s, nil, nil}
+	*v = Value{t: t, i: i, f: f, s: s}
 }
 
 // getValue retrieves the value stored in the Value object.
@@ -219,7 +222,8 @@ var fctMap = map[string]Function{
 //
 // Depending on the function number (fno), it performs specific operations and sets the result in the receiver (v).
 // The possible operations include calculating memory usage, getting register values, checking symbol existence,
-// finding symbols, getting the offset of a symbol, and getting the size of a symbol.
+// finding the address of symbols, getting the offset of a symbol, and getting the size of a symbol.
+// Memory usage and register values are taken from the target set by SetTarget.
 //
 // Returns an error if any of the checks fail or if the function cannot be evaluated.
 func (v *Value) Function(v1 *Value) error {
@@ -247,9 +251,10 @@ func (v *Value) Function(v1 *Value) error {
 	}
 	switch f.fno {
 	case CALCMEMUSED:
-		*v = Value{t: f.ret, i: 0}
+		l := v1.GetList()
+		*v = Value{t: f.ret, i: calcMemUsed(l[0].GetUInt(), l[1].GetUInt(), l[2].GetUInt(), l[3].GetUInt())}
 	case GETREGVAL:
-		*v = Value{t: f.ret, i: 0}
+		*v = Value{t: f.ret, i: getRegVal(v1.GetList()[0].s)}
 	case SYMBOLEXIST:
 		_, _, flag := elf.Symbols.GetAddrSize(v1.GetList()[0].s)
 		if flag {
@@ -258,9 +263,9 @@ func (v *Value) Function(v1 *Value) error {
 			*v = Value{t: f.ret, i: 0}
 		}
 	case FINDSYMBOL:
-		_, _, flag := elf.Symbols.GetAddrSize(v1.GetList()[0].s)
+		a, _, flag := elf.Symbols.GetAddrSize(v1.GetList()[0].s)
 		if flag {
-			*v = Value{t: f.ret, i: 1}
+			*v = Value{t: f.ret, i: int64(a), addr: true}
 		} else {
 			*v = Value{t: f.ret, i: 0}
 		}
@@ -1018,7 +1023,7 @@ func (v *Value) GreaterEqual(v1 *Value) error {
 }
 
 // Equal compares the value of the current Value object with another Value object (v1).
-// It supports comparison between Integer and Floating types.
+// It supports comparison between Integer and Floating types, and between String types.
 // If the values are equal, it sets the current Value's integer field (v.i) to 1, otherwise to 0.
 //
 // The resulting type always is forced to be Integer.
@@ -1070,6 +1075,17 @@ func (v *Value) Equal(v1 *Value) error {
 		default:
 			return typeError("Equal", "")
 		}
+	case String:
+		if v1.t != String {
+			return typeError("Equal", "")
+		}
+		if v.s == v1.s {
+			v.i = 1
+		} else {
+			v.i = 0
+		}
+		v.t = Integer
+		v.s = ""
 	default:
 		return typeError("Equal", "")
 	}
@@ -1078,7 +1094,7 @@ func (v *Value) Equal(v1 *Value) error {
 
 // NotEqual compares the value of the current Value object with another Value object (v1).
 // It sets the current Value object to 1 if they are not equal, and 0 if they are equal.
-// The comparison is based on the type of the Value objects (Integer or Floating).
+// The comparison is based on the type of the Value objects (Integer, Floating or String).
 // If the types are incompatible, it returns a type error.
 //
 // The resulting type always is forced to be Integer.
@@ -1130,6 +1146,17 @@ func (v *Value) NotEqual(v1 *Value) error {
 		default:
 			return typeError("NotEqual", "")
 		}
+	case String:
+		if v1.t != String {
+			return typeError("NotEqual", "")
+		}
+		if v.s != v1.s {
+			v.i = 1
+		} else {
+			v.i = 0
+		}
+		v.t = Integer
+		v.s = ""
 	default:
 		return typeError("NotEqual", "")
 	}
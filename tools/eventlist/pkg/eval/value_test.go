@@ -587,7 +587,7 @@ func TestValue_Function(t *testing.T) { //nolint:golint,paralleltest
 		{"GetRegVal", fields{t: Identifier, s: "__GetRegVal"}, args{&getRegValArgs}, Value{t: Integer, i: 0}, false},
 		{"SymbolExist", fields{t: Identifier, s: "__Symbol_exists"}, args{&symbolExistsArgs}, Value{t: Integer, i: 1}, false},
 		{"SymbolExist1", fields{t: Identifier, s: "__Symbol_exists"}, args{&symbolExistsArgs1}, Value{t: Integer, i: 0}, false},
-		{"FindSymbol", fields{t: Identifier, s: "__FindSymbol"}, args{&symbolExistsArgs}, Value{t: Integer, i: 1}, false},
+		{"FindSymbol", fields{t: Identifier, s: "__FindSymbol"}, args{&symbolExistsArgs}, Value{t: Integer, i: 0x38000178, addr: true}, false},
 		{"FindSymbol1", fields{t: Identifier, s: "__FindSymbol"}, args{&symbolExistsArgs1}, Value{t: Integer, i: 0}, false},
 		{"offsetOf", fields{t: Identifier, s: "__Offset_of"}, args{&symbolExistsArgs}, Value{t: Integer, i: 0x38000178}, false},
 		{"offsetOf1", fields{t: Identifier, s: "__Offset_of"}, args{&symbolExistsArgs1}, Value{t: Integer, i: 0}, false},
@@ -1656,6 +1656,9 @@ func TestValue_Equal(t *testing.T) {
 		{"I==X", fields{t: Integer, I: 345}, args{&Value{t: Nix}}, Value{t: Integer, i: 345}, true},
 		{"F==X", fields{t: Floating, F: 3.4}, args{&Value{t: Nix}}, Value{t: Floating, f: 3.4}, true},
 		{"X==F", fields{t: Nix}, args{&Value{t: Floating, f: 3.4}}, Value{t: Nix}, true},
+		{"abc==abc", fields{t: String, s: "abc"}, args{&Value{t: String, s: "abc"}}, Value{t: Integer, i: 1}, false},
+		{"abc==abd", fields{t: String, s: "abc"}, args{&Value{t: String, s: "abd"}}, Value{t: Integer, i: 0}, false},
+		{"S==I", fields{t: String, s: "abc"}, args{&Value{t: Integer, i: 1}}, Value{t: String, s: "abc"}, true},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -1716,6 +1719,9 @@ func TestValue_NotEqual(t *testing.T) {
 		{"I!=X", fields{t: Integer, I: 345}, args{&Value{t: Nix}}, Value{t: Integer, i: 345}, true},
 		{"F!=X", fields{t: Floating, F: 3.4}, args{&Value{t: Nix}}, Value{t: Floating, f: 3.4}, true},
 		{"X!=F", fields{t: Nix}, args{&Value{t: Floating, f: 3.4}}, Value{t: Nix}, true},
+		{"abc!=abc", fields{t: String, s: "abc"}, args{&Value{t: String, s: "abc"}}, Value{t: Integer, i: 0}, false},
+		{"abc!=abd", fields{t: String, s: "abc"}, args{&Value{t: String, s: "abd"}}, Value{t: Integer, i: 1}, false},
+		{"S!=I", fields{t: String, s: "abc"}, args{&Value{t: Integer, i: 1}}, Value{t: String, s: "abc"}, true},
 	}
 	for _, tt := range tests {
 		tt := tt
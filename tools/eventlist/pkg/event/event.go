@@ -27,14 +27,17 @@ import (
 	"eventlist/pkg/xml/scvd"
 	"fmt"
 	"io"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
 )
 
-var errEnum = errors.New("invalid enum")
+var ErrEnum = errors.New("invalid enum")
 
-var errFormat = errors.New("invalid format expression")
+var ErrFormat = errors.New("invalid format expression")
+
+var ErrTruncated = errors.New("truncated event record")
 
 // enumError creates and returns a pointer to an eval.NumError struct.
 // The function takes two string parameters: fn and str, which represent
@@ -42,7 +45,7 @@ var errFormat = errors.New("invalid format expression")
 // It returns a pointer to an eval.NumError containing the provided
 // function name, the erroneous string, and a predefined error value.
 func enumError(fn, str string) *eval.NumError {
-	return &eval.NumError{Func: fn, Num: str, Err: errEnum}
+	return &eval.NumError{Func: fn, Num: str, Err: ErrEnum}
 }
 
 // formatError creates and returns a pointer to an eval.NumError struct.
@@ -51,7 +54,7 @@ func enumError(fn, str string) *eval.NumError {
 // The returned eval.NumError contains the provided function name, string value,
 // and a predefined error indicating a formatting issue.
 func formatError(fn, str string) *eval.NumError {
-	return &eval.NumError{Func: fn, Num: str, Err: errFormat}
+	return &eval.NumError{Func: fn, Num: str, Err: ErrFormat}
 }
 
 // getEnum retrieves the enumeration name corresponding to a given integer value from a set of typedefs.
@@ -122,6 +125,11 @@ func (info *Info) getInfoFromBytes(data []byte) {
 	info.length &= 0x7FFF
 }
 
+// IRQ reports whether the event was recorded in an interrupt service routine.
+func (info *Info) IRQ() bool {
+	return info.irq
+}
+
 // SplitID splits the ID field of the Info struct into its constituent parts:
 // class, group, idx, and start. The ID is expected to be a 16-bit value with
 // the following structure:
@@ -173,18 +181,17 @@ type Data struct {
 // - 't': Text
 // - 'x': Hexadecimal
 // - 'F': File
-// - 'C': Address with file (currently returns syntax error)
+// - 'C': Address with file and line
 // - 'I': IPV4 address
-// - 'J': IPV6 address (partial support)
+// - 'J': IPV6 address
 // - 'N': String address
 // - 'M': MAC address
-// - 'S': Address
+// - 'S': Symbolic address
 // - 'T': Type dependent (floating point or integer)
-// - 'U': USB descriptor (currently not implemented)
+// - 'U': USB descriptor
 // - Default: Returns the character itself as a string
 func (e *Data) calculateExpression(typedefs eval.Typedefs, tdUsed map[string]string, value string, i *int) (string, error) {
 	var val eval.Value
-	var out string
 	var err error
 
 	if *i >= len(value) {
@@ -193,6 +200,7 @@ func (e *Data) calculateExpression(typedefs eval.Typedefs, tdUsed map[string]str
 	c := value[*i]
 	if *i+1 < len(value) && value[*i+1] == '[' {
 		*i++
+		start := *i + 1
 		val, err = e.GetValue(value, i, typedefs, tdUsed)
 		if err != nil {
 			return "", err
@@ -200,15 +208,147 @@ func (e *Data) calculateExpression(typedefs eval.Typedefs, tdUsed map[string]str
 		if value[*i] != ']' {
 			return "", eval.ErrSyntax
 		}
+		if c == 'J' || c == 'U' {
+			val = e.payload(strings.TrimSpace(value[start:*i]), val)
+		}
 		*i++
 	}
+	return FormatValue(c, val)
+}
+
+// payload returns the data of an EventRecordData event starting at the
+// position of a value, e.g. at byte 4 for val2, for formats which read
+// more than 4 bytes.
+//
+// Parameters:
+//   - name: The expression of the value.
+//   - val: The evaluated value.
+//
+// Returns:
+//   - The data as memory block if the expression is a value of an
+//     EventRecordData event, otherwise val.
+func (e *Data) payload(name string, val eval.Value) eval.Value {
+	if e.Data == nil || len(name) != 4 || !strings.HasPrefix(name, "val") || name[3] < '1' || name[3] > '4' {
+		return val
+	}
+	off := 4 * int(name[3]-'1')
+	if off > len(*e.Data) {
+		return val
+	}
+	return eval.Block((*e.Data)[off:], 0, "uint8_t")
+}
+
+// targetData returns the data of a memory block, or of the initialized
+// data of the application file at an explicit address, see
+// eval.Value.IsAddress. Other integers are not read as address.
+//
+// Parameters:
+//   - val: The memory block or the address.
+//   - size: The number of bytes to read at the address.
+//
+// Returns:
+//   - The data, nil if val is neither a memory block nor an address.
+//   - An error if there is no data at the address.
+func targetData(val eval.Value, size uint64) ([]byte, error) {
+	switch {
+	case val.IsBlock():
+		return val.GetBytes(), nil
+	case !val.IsAddress():
+		return nil, nil
+	}
+	data, ok := elf.Sections.Read(val.GetUInt(), size)
+	if !ok {
+		return nil, fmt.Errorf("%w: no %d bytes of data at 0x%08x", ErrFormat, size, val.GetUInt())
+	}
+	return data, nil
+}
+
+// formatIPv6 formats an IPV6 address as recommended by RFC 5952. The
+// address is read from a memory block or from the application file at
+// an explicit address, otherwise val itself is taken as the low 64 bits
+// of the address.
+func formatIPv6(val eval.Value) (string, error) {
+	var addr [16]byte
+	data, err := targetData(val, 16)
+	switch {
+	case err != nil:
+		return "", err
+	case data == nil:
+		binary.BigEndian.PutUint64(addr[8:], val.GetUInt())
+	case len(data) < len(addr):
+		return "", fmt.Errorf("%w: %%J needs 16 bytes, got %d", ErrFormat, len(data))
+	default:
+		copy(addr[:], data)
+	}
+	return netip.AddrFrom16(addr).String(), nil
+}
+
+// formatUSBValue formats the USB descriptors of a memory block, or the
+// descriptor in the application file at the explicit address val.
+func formatUSBValue(val eval.Value) (string, error) {
+	if val.IsBlock() {
+		return formatUSB(val.GetBytes()), nil
+	}
+	if !val.IsAddress() {
+		return "", fmt.Errorf("%w: %%U needs a memory block or an address", ErrFormat)
+	}
+	data, err := targetData(val, 1)
+	if err == nil {
+		data, err = targetData(val, uint64(data[0]))
+	}
+	if err != nil {
+		return "", err
+	}
+	return formatUSB(data), nil
+}
+
+// symbolAddress formats a code address as function+offset, the address
+// in hexadecimal if it is not contained in a function of the application
+// file. Bit 0 of the address is ignored, it is set in function pointers
+// and return addresses of Thumb code.
+//
+// Parameters:
+//   - addr: The code address.
+//
+// Returns:
+//   - The formatted address.
+func symbolAddress(addr uint64) string {
+	name, offset, ok := elf.Symbols.FindFunction(addr &^ 1)
+	switch {
+	case !ok:
+		return fmt.Sprintf("%08x", addr)
+	case offset == 0:
+		return name
+	}
+	return fmt.Sprintf("%s+0x%x", name, offset)
+}
+
+// FormatValue formats a value according to a format specifier of the
+// SCVD value attribute, e.g. x for %x[...]. It is used for event values
+// and for the items of component views.
+//
+// Parameters:
+//   - c: The format specifier character.
+//   - val: The value to be formatted.
+//
+// Returns:
+//   - The formatted value, the specifier itself if it is unknown.
+//   - An error if the value does not provide the data of the specifier,
+//     e.g. less than 16 bytes for %J.
+func FormatValue(c byte, val eval.Value) (string, error) {
+	var out string
+	var err error
 	switch c {
 	case 'd': // signed decimal
 		out = fmt.Sprintf("%d", val.GetInt())
 	case 'u': // unsigned decimal
 		out = fmt.Sprintf("%d", val.GetUInt())
 	case 't': // text
-		out = elf.Sections.GetString(val.GetUInt())
+		if val.IsString() {
+			out = val.GetString() // text read from target memory
+		} else {
+			out = elf.Sections.GetString(val.GetUInt())
+		}
 	case 'x': // hexadecimal
 		out = fmt.Sprintf("0x%02x", val.GetUInt())
 	case 'F': // File
@@ -217,13 +357,15 @@ func (e *Data) calculateExpression(typedefs eval.Typedefs, tdUsed map[string]str
 			out = fmt.Sprintf("0x%08x", val.GetUInt())
 		}
 	case 'C': // address with file
-		return "", eval.ErrSyntax
+		out = symbolAddress(val.GetUInt())
+		if file, line, ok := elf.Symbols.FindLine(val.GetUInt() &^ 1); ok {
+			out += fmt.Sprintf(" (%s:%d)", file, line)
+		}
 	case 'I': // IPV4
 		out = fmt.Sprintf("%d.%d.%d.%d", val.GetUInt()>>24&0xFF, val.GetUInt()>>16&0xFF,
 			val.GetUInt()>>8&0xFF, val.GetUInt()&0xFF)
-	case 'J': // IPV6			TODO: only part of IPV6
-		out = fmt.Sprintf("%x:%x:%x:%x:", val.GetUInt()>>48&0xFFFF, val.GetUInt()>>32&0xFFFF,
-			val.GetUInt()>>16&0xFFFF, val.GetUInt()&0xFFFF)
+	case 'J': // IPV6
+		out, err = formatIPv6(val)
 	case 'N': // string address
 		out = elf.Sections.GetString(val.GetUInt())
 		if len(out) == 0 {
@@ -233,7 +375,7 @@ func (e *Data) calculateExpression(typedefs eval.Typedefs, tdUsed map[string]str
 		out = fmt.Sprintf("%02x-%02x-%02x-%02x-%02x-%02x", val.GetUInt()>>40&0xFF, val.GetUInt()>>32&0xFF,
 			val.GetUInt()>>24&0xFF, val.GetUInt()>>16&0xFF, val.GetUInt()>>8&0xFF, val.GetUInt()&0xFF)
 	case 'S': // address
-		out = fmt.Sprintf("%08x", val.GetUInt())
+		out = symbolAddress(val.GetUInt())
 	case 'T': // type dependant
 		switch {
 		case val.IsFloating():
@@ -242,10 +384,11 @@ func (e *Data) calculateExpression(typedefs eval.Typedefs, tdUsed map[string]str
 			out = fmt.Sprintf("%d", val.GetInt())
 		}
 	case 'U': // USB descriptor
+		out, err = formatUSBValue(val)
 	default:
 		out = string(c)
 	}
-	return out, nil
+	return out, err
 }
 
 // calculateEnumExpression evaluates an enum expression from the given string value starting at the position indicated by i.
@@ -296,6 +439,15 @@ func (e *Data) calculateEnumExpression(typedefs eval.Typedefs, value string, i *
 	return out, nil
 }
 
+// Line is the text of an event, selected by the <print> alternatives
+// of the event definition, together with its highlighting.
+type Line struct {
+	Text     string
+	Property string // property of the selected <print>, empty for the event property
+	Alert    bool
+	Bold     bool
+}
+
 // EvalLine evaluates a line of event data based on the provided event type and typedefs.
 // It processes the event's value string, replacing placeholders with corresponding values
 // from the event or calculated expressions.
@@ -308,32 +460,118 @@ func (e *Data) calculateEnumExpression(typedefs eval.Typedefs, value string, i *
 //   - A string with the evaluated event data.
 //   - An error if any issues occur during the evaluation process.
 func (e *Data) EvalLine(scvdevent scvd.EventType, typedefs eval.Typedefs) (string, error) {
-	var tdUsed = make(map[string]string)
-	if scvdevent.Val1 != "" {
-		tdUsed["val1"] = scvdevent.Val1
+	line, err := e.EvalPrint(scvdevent, typedefs)
+	return line.Text, err
+}
+
+// EvalPrint evaluates the text of an event like EvalLine. The first <print>
+// element of the event definition with a true cond expression replaces the
+// value, property, alert and bold attributes of the event; if none is true,
+// the attributes of the event are used. As in component views, a condition
+// which cannot be evaluated is false.
+//
+// Parameters:
+//   - scvdevent: The event type containing the value string and the print alternatives.
+//   - typedefs: A collection of type definitions used for evaluating expressions.
+//
+// Returns:
+//   - The evaluated event text and its highlighting.
+//   - An error if the selected value string cannot be evaluated.
+func (e *Data) EvalPrint(scvdevent scvd.EventType, typedefs eval.Typedefs) (Line, error) {
+	tdUsed := usedTypedefs(scvdevent)
+	value, alert, bold := string(scvdevent.Value), scvdevent.Alert, scvdevent.Bold
+	var line Line
+	for _, p := range scvdevent.Prints {
+		if e.cond(p.Cond, typedefs, tdUsed) {
+			value, line.Property = string(p.Value), p.Property
+			if p.Alert != "" {
+				alert = p.Alert
+			}
+			if p.Bold != "" {
+				bold = p.Bold
+			}
+			break
+		}
 	}
-	if scvdevent.Val2 != "" {
-		tdUsed["val2"] = scvdevent.Val2
+	line.Alert = strings.TrimSpace(alert) != "" && e.cond(alert, typedefs, tdUsed)
+	line.Bold = strings.TrimSpace(bold) != "" && e.cond(bold, typedefs, tdUsed)
+
+	var err error
+	line.Text, err = e.evalValue(value, typedefs, tdUsed)
+	return line, err
+}
+
+// usedTypedefs returns the typedefs of the values val1..val6 of an event definition.
+func usedTypedefs(scvdevent scvd.EventType) map[string]string {
+	tdUsed := make(map[string]string)
+	for name, td := range map[string]string{
+		"val1": scvdevent.Val1, "val2": scvdevent.Val2, "val3": scvdevent.Val3,
+		"val4": scvdevent.Val4, "val5": scvdevent.Val5, "val6": scvdevent.Val6,
+	} {
+		if td != "" {
+			tdUsed[name] = td
+		}
 	}
-	if scvdevent.Val3 != "" {
-		tdUsed["val3"] = scvdevent.Val3
+	return tdUsed
+}
+
+// EvalHandle evaluates the handle of an event with state tracking, e.g. the
+// thread ID of an RTOS event, and the name of the handle.
+//
+// Parameters:
+//   - scvdevent: The event type containing the handle and hname expressions.
+//   - typedefs: A collection of type definitions used for evaluating expressions.
+//
+// Returns:
+//   - The value of the handle.
+//   - The name of the handle, empty if the event does not name it or the
+//     name cannot be evaluated, e.g. without target memory.
+//   - An error if the handle expression cannot be evaluated.
+func (e *Data) EvalHandle(scvdevent scvd.EventType, typedefs eval.Typedefs) (int64, string, error) {
+	tdUsed := usedTypedefs(scvdevent)
+	expr := scvdevent.Handle
+	e.setValues()
+	v, err := eval.Eval(&expr, typedefs, tdUsed)
+	if err != nil {
+		return 0, "", err
 	}
-	if scvdevent.Val4 != "" {
-		tdUsed["val4"] = scvdevent.Val4
+	if v.IsFloating() {
+		return 0, "", eval.ErrType
+	}
+	var name string
+	if strings.TrimSpace(scvdevent.HName) != "" {
+		name, _ = e.evalValue(scvdevent.HName, typedefs, tdUsed)
+	}
+	return v.GetInt(), name, nil
+}
+
+// cond evaluates a condition with the values of the event, an empty
+// condition is true.
+func (e *Data) cond(expr string, typedefs eval.Typedefs, tdUsed map[string]string) bool {
+	if strings.TrimSpace(expr) == "" {
+		return true
 	}
-	if scvdevent.Val5 != "" {
-		tdUsed["val5"] = scvdevent.Val5
+	e.setValues()
+	v, err := eval.Eval(&expr, typedefs, tdUsed)
+	if err != nil {
+		return false
 	}
-	if scvdevent.Val6 != "" {
-		tdUsed["val6"] = scvdevent.Val6
+	if v.IsFloating() {
+		return v.GetFloat() != 0
 	}
+	return v.GetInt() != 0
+}
+
+// evalValue replaces the format specifiers of a value string by the
+// formatted values of the event.
+func (e *Data) evalValue(value string, typedefs eval.Typedefs, tdUsed map[string]string) (string, error) {
 	var s string
-	for i := 0; i < len(scvdevent.Value); i++ {
-		c := scvdevent.Value[i]
+	for i := 0; i < len(value); i++ {
+		c := value[i]
 		if c == '%' {
-			if i+1 < len(scvdevent.Value) {
+			if i+1 < len(value) {
 				i++
-				c := scvdevent.Value[i]
+				c := value[i]
 				switch c {
 				case '%':
 					s += string(c)
@@ -363,14 +601,14 @@ func (e *Data) EvalLine(scvdevent scvd.EventType, typedefs eval.Typedefs) (strin
 				case 'T': // type dependant
 					fallthrough
 				case 'U': // USB descriptor
-					out, err := e.calculateExpression(typedefs, tdUsed, string(scvdevent.Value), &i)
+					out, err := e.calculateExpression(typedefs, tdUsed, value, &i)
 					if err != nil {
 						return "", err
 					}
 					s += out
 					i--
 				case 'E': // enum
-					out, err := e.calculateEnumExpression(typedefs, string(scvdevent.Value), &i)
+					out, err := e.calculateEnumExpression(typedefs, value, &i)
 					if err != nil {
 						return "", err
 					}
@@ -470,6 +708,11 @@ func convert64(data []byte) uint64 {
 	return binary.LittleEndian.Uint64([]byte{data[0], data[1], data[2], data[3], data[4], data[5], data[6], data[7]})
 }
 
+// truncated returns the error of a record which ends before its data is complete.
+func truncated(err error) error {
+	return fmt.Errorf("%w: %w", ErrTruncated, err)
+}
+
 // Read reads data from the provided bufio.Reader and populates the Data struct.
 // It expects the input data to be in a specific binary format and processes it accordingly.
 //
@@ -491,7 +734,8 @@ func convert64(data []byte) uint64 {
 //   - Type 2 (Eventrecord2): Expects at least 20 bytes of data.
 //   - Type 3 (Eventrecord4): Expects at least 28 bytes of data.
 //
-// If the data is successfully read and processed, the function returns nil. Otherwise, it returns an error.
+// If the data is successfully read and processed, the function returns nil. At the end of the input it
+// returns eval.ErrEof, for a record which is incomplete an error wrapping ErrTruncated.
 func (e *Data) Read(in *bufio.Reader) error {
 	if in == nil {
 		return eval.ErrEof
@@ -504,16 +748,16 @@ func (e *Data) Read(in *bufio.Reader) error {
 	typ := convert16(a2)
 	_, err = io.ReadFull(in, a2)
 	if err != nil {
-		return err
+		return truncated(err)
 	}
 	length := int(convert16(a2))
 	data := make([]byte, length)
 	_, err = io.ReadFull(in, data)
 	if err != nil {
-		return err
+		return truncated(err)
 	}
 	if len(data) < 12 {
-		return eval.ErrEof
+		return truncated(eval.ErrEof)
 	}
 	e.Time = convert64(data[:8])
 	e.Info.getInfoFromBytes(data[8:12])
@@ -521,19 +765,19 @@ func (e *Data) Read(in *bufio.Reader) error {
 	switch typ {
 	case 1: // EventrecordData
 		if len(data) < 12+int(e.Info.length) {
-			return eval.ErrEof
+			return truncated(eval.ErrEof)
 		}
 		e.Data = new([]uint8)
 		*e.Data = data[12 : 12+int(e.Info.length)]
 	case 2: // Eventrecord2
 		if len(data) < 20 {
-			return eval.ErrEof
+			return truncated(eval.ErrEof)
 		}
 		e.Value1 = int32(convert32(data[12:16]))
 		e.Value2 = int32(convert32(data[16:20]))
 	case 3: // Eventrecord4
 		if len(data) < 28 {
-			return eval.ErrEof
+			return truncated(eval.ErrEof)
 		}
 		e.Value1 = int32(convert32(data[12:16]))
 		e.Value2 = int32(convert32(data[16:20]))
@@ -543,6 +787,35 @@ func (e *Data) Read(in *bufio.Reader) error {
 	return nil
 }
 
+// expressionEnd returns the index of the "," or "]" ending an expression
+// of a value string. Brackets of the expression, e.g. of an array member,
+// are skipped.
+//
+// Parameters:
+//   - s: The value string following the "[" of the expression.
+//
+// Returns:
+//   - The index of the ending character, -1 if the expression is not ended.
+func expressionEnd(s string) int {
+	depth := 0
+	for j := 0; j < len(s); j++ {
+		switch s[j] {
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				return j
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
 // GetValue evaluates a value expression within a given context and returns the result.
 // It supports evaluating expressions that are enclosed in square brackets and uses
 // predefined variables (val1, val2, val3, val4) for evaluation.
@@ -558,24 +831,9 @@ func (e *Data) Read(in *bufio.Reader) error {
 //   - error: An error if the evaluation fails or if there is a syntax error in the expression.
 func (e *Data) GetValue(value string, i *int, typedefs eval.Typedefs, tdUsed map[string]string) (eval.Value, error) {
 	if *i < len(value) && value[*i] == '[' {
-		if e.Data == nil {
-			eval.SetVarI("val1", int64(e.Value1))
-			eval.SetVarI("val2", int64(e.Value2))
-			eval.SetVarI("val3", int64(e.Value3))
-			eval.SetVarI("val4", int64(e.Value4))
-		} else {
-			ed := *e.Data
-			var ed8 [8]uint8
-			copy(ed8[:8], ed)
-			v := binary.LittleEndian.Uint32(ed8[:4]) // load a byte string from a little-endian source
-			eval.SetVarI("val1", int64(v))
-			v = binary.LittleEndian.Uint32(ed8[4:]) // load a byte string from a little-endian source
-			eval.SetVarI("val2", int64(v))
-			eval.SetVarI("val3", 0)
-			eval.SetVarI("val4", 0)
-		}
+		e.setValues()
 		*i++ // skip [
-		j := strings.IndexAny(value[*i:], ",]")
+		j := expressionEnd(value[*i:])
 		var n eval.Value
 		var err error
 		if j == -1 {
@@ -592,6 +850,48 @@ func (e *Data) GetValue(value string, i *int, typedefs eval.Typedefs, tdUsed map
 	return eval.Value{}, eval.ErrSyntax
 }
 
+// setValues sets the variables val1 to val4 of the expression evaluator
+// to the values of the event. For EventRecordData events, val1 and val2
+// hold the first 8 bytes of the data, and the members of values with a
+// typedef are read from the data.
+func (e *Data) setValues() {
+	if e.Data == nil {
+		eval.SetPayload(nil)
+		eval.SetVarI("val1", int64(e.Value1))
+		eval.SetVarI("val2", int64(e.Value2))
+		eval.SetVarI("val3", int64(e.Value3))
+		eval.SetVarI("val4", int64(e.Value4))
+	} else {
+		ed := *e.Data
+		eval.SetPayload(ed)
+		var ed8 [8]uint8
+		copy(ed8[:8], ed)
+		v := binary.LittleEndian.Uint32(ed8[:4]) // load a byte string from a little-endian source
+		eval.SetVarI("val1", int64(v))
+		v = binary.LittleEndian.Uint32(ed8[4:]) // load a byte string from a little-endian source
+		eval.SetVarI("val2", int64(v))
+		eval.SetVarI("val3", 0)
+		eval.SetVarI("val4", 0)
+	}
+}
+
+// Values returns the values val1..val6 of the event without type
+// conversion. For events with data they are the 32-bit words of the
+// data, missing words are 0.
+func (e *Data) Values() [6]int64 {
+	var vals [6]int64
+	if e.Data == nil {
+		vals[0], vals[1], vals[2], vals[3] = int64(e.Value1), int64(e.Value2), int64(e.Value3), int64(e.Value4)
+		return vals
+	}
+	var ed [4 * len(vals)]uint8
+	copy(ed[:], *e.Data)
+	for i := range vals {
+		vals[i] = int64(binary.LittleEndian.Uint32(ed[4*i:]))
+	}
+	return vals
+}
+
 // Open opens a file specified by the filename and returns a bufio.Reader to read from it.
 // If there is an error opening the file, it returns nil.
 //
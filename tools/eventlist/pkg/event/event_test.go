@@ -207,9 +207,9 @@ func TestEventData_calculateExpression(t *testing.T) { //nolint:golint,parallelt
 		{"expr x", ed1, args{tds, "x[val1]", &i}, "0x101", 7, false},
 		{"expr F", ed1, args{tds, "F[val4]", &i}, "def", 7, false},
 		{"expr F", ed1, args{tds, "F[val1]", &i}, "0x00000101", 7, false},
-		{"expr C", ed1, args{tds, "C[val2]", &i}, "", 7, true},
+		{"expr C", ed1, args{tds, "C[val3]", &i}, "25480a75", 7, false},
 		{"expr I", ed1, args{tds, "I[val3]", &i}, "37.72.10.117", 7, false},
-		{"expr J", ed1, args{tds, "J[val3]", &i}, "0:0:2548:a75:", 7, false},
+		{"expr J", ed1, args{tds, "J[val3]", &i}, "::2548:a75", 7, false},
 		{"expr N", ed1, args{tds, "N[val4]", &i}, "def", 7, false},
 		{"expr N", ed1, args{tds, "N[val1]", &i}, "0x00000101", 7, false},
 		{"expr M", ed1, args{tds, "M[val3]", &i}, "00-00-25-48-0a-75", 7, false},
@@ -250,6 +250,77 @@ func TestEventData_calculateExpression(t *testing.T) { //nolint:golint,parallelt
 	}
 }
 
+func TestFormatValue_address(t *testing.T) { //nolint:golint,paralleltest
+	fileSym := "../../testdata/elfsym.elf"
+	if err := elf.Sections.Readelf(&fileSym); err != nil {
+		t.Fatalf("FormatValue() cannot open %s", fileSym)
+	}
+
+	tests := []struct {
+		name string
+		c    byte
+		addr uint64
+		want string
+	}{
+		{"S function", 'S', 0x1000035d, "main"},
+		{"S offset", 'S', 0x10000360, "main+0x4"},
+		{"S unknown", 'S', 0x38000178, "38000178"},
+		{"C line", 'C', 0x10000360, "main+0x4 (Blinky.c:89)"},
+		{"C return address", 'C', 0x10000365, "main+0x8 (Blinky.c:91)"},
+		{"C no line", 'C', 0x10000232, "__main+0x2"},
+		{"C unknown", 'C', 0, "00000000"},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var val eval.Value
+			val.Compose(eval.Integer, int64(tt.addr), 0.0, "")
+			got, err := FormatValue(tt.c, val)
+			if err != nil {
+				t.Fatalf("FormatValue() %s error = %v", tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatValue() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventData_calculateExpression_address(t *testing.T) { //nolint:golint,paralleltest
+	fileSym := "../../testdata/elfsym.elf"
+	if err := elf.Sections.Readelf(&fileSym); err != nil {
+		t.Fatalf("Data.calculateExpression() cannot open %s", fileSym)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"J address", `J[__FindSymbol("__aeabi_stdin")]`, "::4078:7d01", false},
+		{"J address offset", `J[__FindSymbol("__aeabi_stdin") + 4]`, "::4078:7d01:ffff:0", false},
+		{"J short", `J[__FindSymbol("SystemCoreClock")]`, "", true},
+		{"J integer", "J[0x38000000]", "::3800:0", false},
+		{"U integer", "U[0x38000000]", "", true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var e Data
+			i := 0
+			got, err := e.calculateExpression(nil, nil, tt.value, &i)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Data.calculateExpression() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrFormat) {
+				t.Errorf("Data.calculateExpression() %s error = %v, want %v", tt.name, err, ErrFormat)
+			}
+			if got != tt.want {
+				t.Errorf("Data.calculateExpression() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestEventData_calculateEnumExpression(t *testing.T) { //nolint:golint,paralleltest
 	var vals eval.Member
 	vals.Enums = make(map[int64]string)
@@ -335,6 +406,9 @@ func TestEventData_EvalLine(t *testing.T) {
 	var ev2 scvd.EventType = scvd.EventType{ID: "id2", Value: "x%T[val1]y%x[val2]z"}
 	var ev3 scvd.EventType = scvd.EventType{ID: "id3", Value: "x%I[val3]y%J[val3]z"}
 	var ev4 scvd.EventType = scvd.EventType{ID: "id4", Value: "x%M[val3]y%S[val3]z"}
+	var evJ1 scvd.EventType = scvd.EventType{ID: "idJ1", Value: "ip=%J[val1]"}
+	var evJ2 scvd.EventType = scvd.EventType{ID: "idJ2", Value: "ip=%J[ val2 ]"}
+	var evU scvd.EventType = scvd.EventType{ID: "idU", Value: "%U[val1]"}
 	var evE1 scvd.EventType = scvd.EventType{ID: "idE1", Value: "x%E[val2, typName]y"}
 	var evTD scvd.EventType = scvd.EventType{ID: "idTD", Val1: "v1", Val2: "v2", Val3: "4BY", Val4: "v4", Val5: "v5", Val6: "v6", Value: "x%x[val3.B2]y"}
 	var everr1 scvd.EventType = scvd.EventType{ID: "iderr1", Value: "x%d[;]y"}
@@ -367,6 +441,9 @@ func TestEventData_EvalLine(t *testing.T) {
 	}
 
 	var ed1 = fields{Time: 306, Value1: 257, Value2: 4711, Value3: 625478261, Value4: 0, Data: nil, Info: Info{}}
+	var ip6 = fields{Data: &[]uint8{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}}
+	var ip6Mapped = fields{Data: &[]uint8{1, 2, 3, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 192, 0, 2, 1}}
+	var usb = fields{Data: &[]uint8{7, 5, 0x81, 0x02, 0x40, 0x00, 0x00}}
 
 	type args struct {
 		scvdevent scvd.EventType
@@ -381,8 +458,12 @@ func TestEventData_EvalLine(t *testing.T) {
 	}{
 		{"EvalLine ev1", ed1, args{ev1, tds}, "x%257y4711z", false},
 		{"EvalLine ev2", ed1, args{ev2, tds}, "x257y0x1267z", false},
-		{"EvalLine ev3", ed1, args{ev3, tds}, "x37.72.10.117y0:0:2548:a75:z", false},
+		{"EvalLine ev3", ed1, args{ev3, tds}, "x37.72.10.117y::2548:a75z", false},
 		{"EvalLine ev4", ed1, args{ev4, tds}, "x00-00-25-48-0a-75y25480a75z", false},
+		{"EvalLine evJ1", ip6, args{evJ1, tds}, "ip=2001:db8::1", false},
+		{"EvalLine evJ2", ip6Mapped, args{evJ2, tds}, "ip=::ffff:192.0.2.1", false},
+		{"EvalLine evJ short", usb, args{evJ1, tds}, "", true},
+		{"EvalLine evU", usb, args{evU, tds}, "Endpoint: bEndpointAddress=0x81 (IN 1), bmAttributes=0x02 (Bulk), wMaxPacketSize=64, bInterval=0", false},
 		{"EvalLine evE1", ed1, args{evE1, tds}, "xenumy", false},
 		{"EvalLine evTD", ed1, args{evTD, tds}, "x0x48y", false},
 		{"EvalLine err1", ed1, args{everr1, tds}, "", true},
@@ -414,6 +495,80 @@ func TestEventData_EvalLine(t *testing.T) {
 	}
 }
 
+func TestEventData_EvalPrint(t *testing.T) { //nolint:golint,paralleltest
+	ev := scvd.EventType{ID: "id", Value: "v=%d[val1]", Alert: "val1 > 100", Bold: "",
+		Prints: []scvd.PrintType{
+			{Cond: "val1 == 0", Value: "zero", Property: "Zero"},
+			{Cond: "val1 == 1", Value: "one", Bold: "1"},
+			{Cond: "val1 == 2", Value: "two", Alert: "val2"},
+			{Cond: "val1 ==", Value: "error"},
+		}}
+	evNoValue := scvd.EventType{ID: "id", Prints: []scvd.PrintType{{Value: "always %d[val2]", Bold: "val2 & 1"}}}
+	evErr := scvd.EventType{ID: "id", Value: "x%d[;]y"}
+
+	tests := []struct {
+		name    string
+		ev      scvd.EventType
+		e       Data
+		want    Line
+		wantErr bool
+	}{
+		{"zero", ev, Data{Value1: 0}, Line{Text: "zero", Property: "Zero"}, false},
+		{"one", ev, Data{Value1: 1}, Line{Text: "one", Bold: true}, false},
+		{"two", ev, Data{Value1: 2, Value2: 1}, Line{Text: "two", Alert: true}, false},
+		{"two no alert", ev, Data{Value1: 2}, Line{Text: "two"}, false},
+		{"value", ev, Data{Value1: 5}, Line{Text: "v=5"}, false},
+		{"value alert", ev, Data{Value1: 101}, Line{Text: "v=101", Alert: true}, false},
+		{"data", ev, Data{Data: &[]uint8{1, 0, 0, 0}}, Line{Text: "one", Bold: true}, false},
+		{"no cond", evNoValue, Data{Value2: 3}, Line{Text: "always 3", Bold: true}, false},
+		{"error", evErr, Data{}, Line{}, true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.e.EvalPrint(tt.ev, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Data.EvalPrint() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Data.EvalPrint() %s = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventData_EvalHandle(t *testing.T) { //nolint:golint,paralleltest
+	ev := scvd.EventType{ID: "id", Handle: "val1", HName: "thread%d[val2]"}
+	evNoName := scvd.EventType{ID: "id", Handle: "val2 & 0xFF"}
+	evBadName := scvd.EventType{ID: "id", Handle: "val1", HName: "x%d[;]"}
+	evErr := scvd.EventType{ID: "id", Handle: "val1 +"}
+
+	tests := []struct {
+		name     string
+		ev       scvd.EventType
+		e        Data
+		want     int64
+		wantName string
+		wantErr  bool
+	}{
+		{"named", ev, Data{Value1: 0x2000, Value2: 3}, 0x2000, "thread3", false},
+		{"expression", evNoName, Data{Value2: 0x1234}, 0x34, "", false},
+		{"data", ev, Data{Data: &[]uint8{4, 0, 0, 0, 7}}, 4, "thread7", false},
+		{"bad name", evBadName, Data{Value1: 1}, 1, "", false},
+		{"error", evErr, Data{}, 0, "", true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			got, name, err := tt.e.EvalHandle(tt.ev, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Data.EvalHandle() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want || name != tt.wantName {
+				t.Errorf("Data.EvalHandle() %s = %v, %q, want %v, %q", tt.name, got, name, tt.want, tt.wantName)
+			}
+		})
+	}
+}
+
 func TestData_GetValuesAsString(t *testing.T) {
 	t.Parallel()
 
@@ -459,6 +614,31 @@ func TestData_GetValuesAsString(t *testing.T) {
 	}
 }
 
+func TestData_Values(t *testing.T) {
+	t.Parallel()
+
+	data := []uint8{1, 2, 3, 4, 5, 6, 7, 8, 0xFF, 0xFF, 0xFF, 0xFF, 9}
+	tests := []struct {
+		name string
+		e    Data
+		want [6]int64
+	}{
+		{"values", Data{Value1: -1, Value2: 2, Value3: 3, Value4: 4}, [6]int64{-1, 2, 3, 4, 0, 0}},
+		{"data", Data{Data: &data}, [6]int64{0x04030201, 0x08070605, 0xFFFFFFFF, 9, 0, 0}},
+		{"empty data", Data{Data: &[]uint8{}}, [6]int64{}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.e.Values(); got != tt.want {
+				t.Errorf("Data.Values() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_convert16(t *testing.T) {
 	t.Parallel()
 
@@ -718,3 +898,39 @@ func TestData_GetValue(t *testing.T) { //nolint:golint,paralleltest
 		})
 	}
 }
+
+func TestEventData_EvalLine_typedefMembers(t *testing.T) { //nolint:golint,paralleltest
+	events := make(scvd.Events)
+	typedefs := make(eval.Typedefs)
+	if err := scvd.Load("../../testdata/typedefs.scvd", events, typedefs); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	frame := []uint8{
+		0x01, 0x5E, 0x00, 0x80, // flags, ver and prio, ack
+		0x02, 0x00, 0x5E, 0x10, 0x20, 0x30, // dst.mac
+		0x1F, 0x90, // port, big-endian
+		0xEF, 0xBE, 0xAD, 0xDE, // crc
+	}
+
+	tests := []struct {
+		name    string
+		e       Data
+		want    string
+		wantErr bool
+	}{
+		{"data", Data{Data: &frame}, "ver=5 prio=-2 ack=1 mac=0x30 port=8080 crc=0xdeadbeef", false},
+		{"short data", Data{Data: &[]uint8{0x01, 0x5E, 0x00, 0x80}}, "", true},
+		{"values", Data{Value1: 0x5E01}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.e.EvalLine(events[0xD00], typedefs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Data.EvalLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Data.EvalLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,390 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"encoding/xml"
+	"errors"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var ErrReference = errors.New("unknown reference")
+var ErrUnsupported = errors.New("unsupported")
+
+// ValueError is a problem of a value string or an expression.
+type ValueError struct {
+	Offset int // offset of the problem in the string
+	Err    error
+}
+
+func (e *ValueError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// formats are the format specifiers taking a value.
+const formats = "dutxFCIJNMSTU"
+
+var (
+	memberRef  = regexp.MustCompile(`\b(val[1-6])\.([A-Za-z_]\w*)`)                                  // val1.member
+	typedefRef = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*:\s*([A-Za-z_]\w*)(\s*:\s*([A-Za-z_]\w*))?`) // typedef:member:enum
+)
+
+// checkReferences checks the members of the values and the typedef
+// members and enums used in an expression.
+//
+// Parameters:
+//   - expr: The expression.
+//   - typedefs: The typedefs of the SCVD files.
+//   - tdUsed: The typedefs of the values val1..val6.
+//
+// Returns:
+//   - The unknown references.
+func checkReferences(expr string, typedefs eval.Typedefs, tdUsed map[string]string) []*ValueError {
+	var errs []*ValueError
+	for _, m := range memberRef.FindAllStringSubmatchIndex(expr, -1) {
+		val, member := expr[m[2]:m[3]], expr[m[4]:m[5]]
+		td, ok := tdUsed[val]
+		if !ok {
+			errs = append(errs, &ValueError{m[0], fmt.Errorf("%w: %s.%s, %s has no typedef", ErrReference, val, member, val)})
+			continue
+		}
+		if members := typedefs[td].Members; members != nil { // an unknown typedef is reported at the value
+			if _, ok := members[member]; !ok {
+				errs = append(errs, &ValueError{m[4], fmt.Errorf("%w: member %s of typedef %s", ErrReference, member, td)})
+			}
+		}
+	}
+	for _, m := range typedefRef.FindAllStringSubmatchIndex(expr, -1) {
+		td, name := expr[m[2]:m[3]], expr[m[4]:m[5]]
+		itypedef, ok := typedefs[td]
+		if !ok {
+			continue // not a typedef, e.g. the alternative of a conditional expression
+		}
+		member, ok := itypedef.Members[name]
+		if !ok {
+			errs = append(errs, &ValueError{m[4], fmt.Errorf("%w: member %s of typedef %s", ErrReference, name, td)})
+			continue
+		}
+		if m[8] == -1 {
+			continue
+		}
+		enum := expr[m[8]:m[9]]
+		found := false
+		for _, s := range member.Enums {
+			found = found || s == enum
+		}
+		if !found {
+			errs = append(errs, &ValueError{m[8], fmt.Errorf("%w: %s of %s:%s", ErrEnum, enum, td, name)})
+		}
+	}
+	return errs
+}
+
+// checkExpression checks an expression, e.g. a cond attribute or the value
+// of a format specifier. Only syntax errors are reported, the values of the
+// expression are not known.
+//
+// Parameters:
+//   - expr: The expression, empty is allowed.
+//   - offset: The offset of the expression in the checked string.
+//   - typedefs: The typedefs of the SCVD files.
+//   - tdUsed: The typedefs of the values val1..val6.
+//
+// Returns:
+//   - The problems of the expression.
+func checkExpression(expr string, offset int, typedefs eval.Typedefs, tdUsed map[string]string) []*ValueError {
+	trimmed := strings.TrimSpace(expr)
+	if trimmed == "" {
+		return nil
+	}
+	errs := checkReferences(expr, typedefs, tdUsed)
+	for _, e := range errs {
+		e.Offset += offset
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	s := expr
+	if _, err := eval.Eval(&s, typedefs, tdUsed); errors.Is(err, eval.ErrSyntax) || errors.Is(err, eval.ErrEof) {
+		lead := len(expr) - len(strings.TrimLeft(expr, " \t\r\n"))
+		return []*ValueError{{offset + lead, fmt.Errorf("%w: %s", eval.ErrSyntax, trimmed)}}
+	}
+	return nil
+}
+
+// checkEnum checks the enum reference of %E[value, typedef:member] or
+// %E[value, typedef].
+//
+// Parameters:
+//   - ref: The reference after the comma.
+//   - offset: The offset of the reference in the value string.
+//   - typedefs: The typedefs of the SCVD files.
+//
+// Returns:
+//   - The problem of the reference, nil if the enums exist.
+func checkEnum(ref string, offset int, typedefs eval.Typedefs) *ValueError {
+	offset += len(ref) - len(strings.TrimLeft(ref, " \t\r\n"))
+	td, name, hasMember := strings.Cut(ref, ":")
+	td, name = strings.TrimSpace(td), strings.TrimSpace(name)
+	itypedef, ok := typedefs[td]
+	switch {
+	case !ok:
+		return &ValueError{offset, fmt.Errorf("%w: unknown typedef %s", ErrEnum, td)}
+	case hasMember:
+		member, ok := itypedef.Members[name]
+		if !ok {
+			return &ValueError{offset, fmt.Errorf("%w: unknown member %s of typedef %s", ErrEnum, name, td)}
+		}
+		if len(member.Enums) == 0 {
+			return &ValueError{offset, fmt.Errorf("%w: member %s of typedef %s has no enums", ErrEnum, name, td)}
+		}
+		return nil
+	}
+	for _, member := range itypedef.Members {
+		if len(member.Enums) != 0 {
+			return nil
+		}
+	}
+	return &ValueError{offset, fmt.Errorf("%w: typedef %s has no enums", ErrEnum, td)}
+}
+
+// checkValue checks a value string like evalValue does without the values
+// of an event: the format specifiers, the expressions of their values and
+// the typedef members and enums they use.
+//
+// Parameters:
+//   - value: The value string.
+//   - typedefs: The typedefs of the SCVD files.
+//   - tdUsed: The typedefs of the values val1..val6.
+//
+// Returns:
+//   - The problems in the order of the value string.
+func checkValue(value string, typedefs eval.Typedefs, tdUsed map[string]string) []*ValueError {
+	var errs []*ValueError
+	for i := 0; i < len(value); i++ {
+		if value[i] != '%' {
+			continue
+		}
+		start := i
+		if i+1 == len(value) {
+			errs = append(errs, &ValueError{start, fmt.Errorf("%w: %% at the end", ErrFormat)})
+			break
+		}
+		i++
+		c := value[i]
+		switch {
+		case c == '%':
+			continue
+		case c != 'E' && strings.IndexByte(formats, c) == -1:
+			errs = append(errs, &ValueError{start, fmt.Errorf("%w: unknown specifier %%%c", ErrFormat, c)})
+			continue
+		case i+1 == len(value) || value[i+1] != '[':
+			errs = append(errs, &ValueError{start, fmt.Errorf("%w: missing [ after %%%c", ErrFormat, c)})
+			continue
+		}
+		i += 2
+		j := expressionEnd(value[i:])
+		if j == -1 {
+			errs = append(errs, &ValueError{start, fmt.Errorf("%w: missing ] after %%%c[", ErrFormat, c)})
+			break
+		}
+		errs = append(errs, checkExpression(value[i:i+j], i, typedefs, tdUsed)...)
+		i += j
+		if c != 'E' {
+			if value[i] == ',' {
+				errs = append(errs, &ValueError{i, fmt.Errorf("%w: %%%c takes one value", ErrFormat, c)})
+				if j = strings.IndexByte(value[i:], ']'); j == -1 {
+					break
+				}
+				i += j
+			}
+			continue
+		}
+		if value[i] != ',' {
+			errs = append(errs, &ValueError{i, fmt.Errorf("%w: missing enum in %%E", ErrFormat)})
+			continue
+		}
+		i++
+		if j = strings.IndexByte(value[i:], ']'); j == -1 {
+			errs = append(errs, &ValueError{start, fmt.Errorf("%w: missing ] after %%E[", ErrFormat)})
+			break
+		}
+		if err := checkEnum(value[i:i+j], i, typedefs); err != nil {
+			errs = append(errs, err)
+		}
+		i += j
+	}
+	return errs
+}
+
+// lintEvent checks an <event> element or one of its <print> elements.
+//
+// Parameters:
+//   - el: The element.
+//   - event: The <event> element.
+//   - typedefs: The typedefs of the SCVD files.
+//
+// Returns:
+//   - The problems of the element.
+func lintEvent(el *scvd.Element, event *scvd.Element, typedefs eval.Typedefs) []scvd.Problem {
+	var problems []scvd.Problem
+	tdUsed := make(map[string]string)
+	for n := 1; n <= 6; n++ {
+		name := fmt.Sprintf("val%d", n)
+		a, ok := event.Attr(name)
+		if !ok || a.Value == "" {
+			continue
+		}
+		tdUsed[name] = a.Value
+		if _, ok := typedefs[a.Value]; !ok && el == event {
+			problems = append(problems, scvd.Problem{Pos: a.ValuePos,
+				Message: fmt.Sprintf("%s: %v: typedef %s", name, ErrReference, a.Value)})
+		}
+	}
+	if a, ok := el.Attr("id"); ok && el == event {
+		s := a.Value
+		if id, err := eval.Eval(&s, typedefs, nil); (err != nil && !errors.Is(err, eval.ErrEof)) || !id.IsInteger() ||
+			id.GetInt() < 0 || id.GetInt() > 0xFFFF {
+			problems = append(problems, scvd.Problem{Pos: a.ValuePos, Message: fmt.Sprintf("id: invalid event ID %s", a.Value)})
+		}
+	}
+	for _, a := range el.Attrs {
+		var errs []*ValueError
+		switch a.Name {
+		case "value", "hname":
+			errs = checkValue(a.Value, typedefs, tdUsed)
+		case "cond", "alert", "bold", "handle":
+			errs = checkExpression(a.Value, 0, typedefs, tdUsed)
+		}
+		for _, err := range errs {
+			problems = append(problems, scvd.Problem{Pos: a.At(err.Offset), Message: a.Name + ": " + err.Error()})
+		}
+	}
+	return problems
+}
+
+// lintMember checks that the type of a typedef member is a scalar type or
+// a typedef.
+func lintMember(el *scvd.Element, typedefs eval.Typedefs) []scvd.Problem {
+	a, ok := el.Attr("type")
+	if !ok {
+		return nil
+	}
+	typ := strings.TrimSpace(a.Value)
+	if _, ok := eval.SizeOf(typ, typedefs); ok {
+		return nil
+	}
+	return []scvd.Problem{{Pos: a.ValuePos, Message: fmt.Sprintf("type: %v: type %s", ErrReference, typ)}}
+}
+
+// lintTypedef checks a <typedef> element or one of its <var> elements. The
+// variables and the members imported from the debug information of a symbol
+// are not known when decoding events. The const attribute has no effect,
+// the events are read only once.
+//
+// Parameters:
+//   - el: The element.
+//   - typedef: The <typedef> element.
+//
+// Returns:
+//   - The problems of the element.
+func lintTypedef(el *scvd.Element, typedef *scvd.Element) []scvd.Problem {
+	name, _ := typedef.Attr("name")
+	if el != typedef {
+		v, _ := el.Attr("name")
+		return []scvd.Problem{{Pos: el.Pos,
+			Message: fmt.Sprintf("var: %v: variable %s of typedef %s", ErrUnsupported, v.Value, name.Value)}}
+	}
+	if a, ok := el.Attr("import"); ok {
+		return []scvd.Problem{{Pos: a.ValuePos,
+			Message: fmt.Sprintf("import: %v: members of typedef %s imported from %s", ErrUnsupported, name.Value, a.Value)}}
+	}
+	return nil
+}
+
+// Lint checks SCVD files for the problems which the decoding of events
+// would meet: the structure of the files against Component_Viewer.xsd, the
+// format specifiers of the value strings, the expressions of the events
+// and their <print> elements, the typedefs, members and enums they use, the
+// types of the typedef members, and the typedef variables and imports,
+// which are not supported.
+// The typedefs of all files are known in each file.
+//
+// Parameters:
+//   - scvdFiles: The paths of the SCVD files.
+//
+// Returns:
+//   - The problems, sorted by file and position.
+//   - An error if an SCVD file cannot be read.
+func Lint(scvdFiles []string) ([]scvd.Problem, error) {
+	data := make([][]byte, len(scvdFiles))
+	loadErrs := make([]error, len(scvdFiles))
+	events := make(scvd.Events)
+	typedefs := make(eval.Typedefs)
+	for i, file := range scvdFiles {
+		var err error
+		if data[i], err = os.ReadFile(file); err != nil {
+			return nil, err
+		}
+		loadErrs[i] = scvd.Load(file, events, typedefs)
+	}
+
+	for n := 1; n <= 6; n++ { // any value, for the syntax of the expressions
+		eval.SetVarI(fmt.Sprintf("val%d", n), 1)
+	}
+	var problems []scvd.Problem
+	for i, file := range scvdFiles {
+		fileProblems := scvd.Validate(data[i], file)
+		_ = scvd.Walk(data[i], file, func(path []*scvd.Element) {
+			if len(path) < 3 {
+				return
+			}
+			el := path[len(path)-1]
+			switch {
+			case path[1].Name == "typedefs" && len(path) == 4 && el.Name == "member":
+				fileProblems = append(fileProblems, lintMember(el, typedefs)...)
+			case path[1].Name == "typedefs" && path[2].Name == "typedef" && (len(path) == 3 || (len(path) == 4 && el.Name == "var")):
+				fileProblems = append(fileProblems, lintTypedef(el, path[2])...)
+			case path[1].Name != "events" || path[2].Name != "event": // not an event
+			case len(path) == 3 || (len(path) == 4 && el.Name == "print"):
+				fileProblems = append(fileProblems, lintEvent(el, path[2], typedefs)...)
+			}
+		})
+		var syntaxErr *xml.SyntaxError
+		if len(fileProblems) == 0 && loadErrs[i] != nil && !errors.As(loadErrs[i], &syntaxErr) {
+			fileProblems = append(fileProblems, scvd.Problem{Pos: scvd.Position{File: file}, Message: loadErrs[i].Error()})
+		}
+		sort.SliceStable(fileProblems, func(a, b int) bool {
+			pa, pb := fileProblems[a].Pos, fileProblems[b].Pos
+			return pa.Line < pb.Line || (pa.Line == pb.Line && pa.Column < pb.Column)
+		})
+		problems = append(problems, fileProblems...)
+	}
+	return problems, nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"errors"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/xml/scvd"
+	"reflect"
+	"testing"
+)
+
+func Test_checkValue(t *testing.T) { //nolint:golint,paralleltest
+	typedefs := eval.Typedefs{"T": {Size: 4, Members: map[string]eval.Member{
+		"m": {Offset: "0", IType: eval.Uint8, Enums: map[int64]string{1: "one"}},
+		"n": {Offset: "1", IType: eval.Uint8},
+	}}}
+	tdUsed := map[string]string{"val1": "T"}
+	for _, name := range []string{"val1", "val2", "val3"} {
+		eval.SetVarI(name, 1)
+	}
+
+	type result struct {
+		offset int
+		err    error
+		msg    string
+	}
+	tests := []struct {
+		name  string
+		value string
+		want  []result
+	}{
+		{"text", "text 100%%", nil},
+		{"formats", "%d[val1] %x[val2 + 1] %t[val1.m] %E[val1.m, T:m] %E[val2, T]", nil},
+		{"end", "text %", []result{{5, ErrFormat, "invalid format expression: % at the end"}}},
+		{"unknown", "a=%q[val1]", []result{{2, ErrFormat, "invalid format expression: unknown specifier %q"}}},
+		{"no bracket", "%d", []result{{0, ErrFormat, "invalid format expression: missing [ after %d"}}},
+		{"no end", "x %d[val1", []result{{2, ErrFormat, "invalid format expression: missing ] after %d["}}},
+		{"two values", "%x[val1, val2] %d[val1]", []result{{7, ErrFormat, "invalid format expression: %x takes one value"}}},
+		{"syntax", "%x[ val1 +* 2]", []result{{4, eval.ErrSyntax, "syntax error: val1 +* 2"}}},
+		{"incomplete", "%x[val1 +]", []result{{3, eval.ErrSyntax, "syntax error: val1 +"}}},
+		{"member", "%d[val1.size]", []result{{8, ErrReference, "unknown reference: member size of typedef T"}}},
+		{"no typedef", "%d[val2.m]", []result{{3, ErrReference, "unknown reference: val2.m, val2 has no typedef"}}},
+		{"typedef member", "%d[T:x]", []result{{5, ErrReference, "unknown reference: member x of typedef T"}}},
+		{"typedef enum", "%d[T:m:two]", []result{{7, ErrEnum, "invalid enum: two of T:m"}}},
+		{"enum no comma", "%E[val1]", []result{{7, ErrFormat, "invalid format expression: missing enum in %E"}}},
+		{"enum typedef", "%E[val1, X:m]", []result{{9, ErrEnum, "invalid enum: unknown typedef X"}}},
+		{"enum member", "%E[val1, T:x]", []result{{9, ErrEnum, "invalid enum: unknown member x of typedef T"}}},
+		{"enum values", "%E[val1, T:n]", []result{{9, ErrEnum, "invalid enum: member n of typedef T has no enums"}}},
+		{"enum no end", "%E[val1, T:m", []result{{0, ErrFormat, "invalid format expression: missing ] after %E["}}},
+		{"several", "%q %d[val1.x]", []result{
+			{0, ErrFormat, "invalid format expression: unknown specifier %q"},
+			{11, ErrReference, "unknown reference: member x of typedef T"},
+		}},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var got []result
+			for _, err := range checkValue(tt.value, typedefs, tdUsed) {
+				got = append(got, result{err.Offset, errors.Unwrap(err.Err), err.Error()})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("checkValue() %s = %v, want %v", tt.name, got, tt.want)
+			}
+			for i := range got {
+				if got[i].offset != tt.want[i].offset || !errors.Is(got[i].err, tt.want[i].err) || got[i].msg != tt.want[i].msg {
+					t.Errorf("checkValue() %s = %v, want %v", tt.name, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLint(t *testing.T) { //nolint:golint,paralleltest
+	file := "../../testdata/lint.scvd"
+	pos := func(line, column int) scvd.Position {
+		return scvd.Position{File: file, Line: line, Column: column}
+	}
+	want := []scvd.Problem{
+		{Pos: pos(10, 33), Message: "type: unknown reference: type node"},
+		{Pos: pos(15, 60), Message: "unknown attribute colour of <component>"},
+		{Pos: pos(18, 24), Message: "invalid level=\"Info\" of <event>, want Error, API, Op or Detail"},
+		{Pos: pos(18, 64), Message: "value: invalid format expression: unknown specifier %q"},
+		{Pos: pos(18, 82), Message: "value: invalid format expression: %x takes one value"},
+		{Pos: pos(18, 92), Message: "value: invalid format expression: missing [ after %d"},
+		{Pos: pos(19, 70), Message: "val2: unknown reference: typedef nix"},
+		{Pos: pos(19, 90), Message: "value: unknown reference: member size of typedef state"},
+		{Pos: pos(19, 99), Message: "value: unknown reference: val3.id, val3 has no typedef"},
+		{Pos: pos(19, 117), Message: "value: invalid enum: member prio of typedef state has no enums"},
+		{Pos: pos(20, 61), Message: "value: syntax error: val1 +* 2"},
+		{Pos: pos(21, 29), Message: "cond: invalid enum: busy of state:id"},
+		{Pos: pos(21, 68), Message: "value: invalid enum: unknown typedef nix"},
+		{Pos: pos(23, 5), Message: "missing attribute level of <event>"},
+		{Pos: pos(23, 16), Message: "id: invalid event ID 0x10000"},
+	}
+	got, err := Lint([]string{file})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %v, want %v", got, want)
+	}
+
+	for _, file := range []string{"../../testdata/test.xml", "../../testdata/states.scvd", "../../testdata/groups.scvd", "../../testdata/typedefs.scvd"} {
+		if got, err := Lint([]string{file}); err != nil || len(got) != 0 {
+			t.Errorf("Lint() %s = %v, %v, want no problems", file, got, err)
+		}
+	}
+	vars := "../../testdata/typedef_vars.scvd"
+	want = []scvd.Problem{
+		{Pos: scvd.Position{File: vars, Line: 8, Column: 7}, Message: "var: unsupported: variable count of typedef Msg"},
+		{Pos: scvd.Position{File: vars, Line: 10, Column: 43}, Message: "import: unsupported: members of typedef Tcb imported from osRtxThread_t"},
+	}
+	if got, err = Lint([]string{vars}); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() typedef_vars.scvd = %v, %v, want %v", got, err, want)
+	}
+	if got, err := Lint([]string{"../../testdata/test_err3.xml"}); err != nil || len(got) != 1 || got[0].Pos.Line != 0 {
+		t.Errorf("Lint() test_err3.xml = %v, %v, want the error of the file", got, err)
+	}
+	if _, err := Lint([]string{"../../testdata/nix.scvd"}); err == nil {
+		t.Errorf("Lint() nix.scvd error = nil")
+	}
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/elf"
+	"eventlist/pkg/memory"
+)
+
+var errRecorder = errors.New("event recorder not found in memory image")
+
+var errRecorderInfo = errors.New("invalid EventRecorderInfo")
+
+// Record information bits of EventRecord_t.info (see EventRecorder.c)
+const (
+	recordIDMask   = 0x0000FFFF
+	recordCtxPos   = 16
+	recordCtxMask  = 0x00070000
+	recordIRQ      = 0x00080000
+	recordSeqPos   = 20
+	recordSeqMask  = 0x00F00000
+	recordFirst    = 0x01000000
+	recordLast     = 0x02000000
+	recordLocked   = 0x04000000
+	recordValid    = 0x08000000
+	recordMsbTs    = 0x10000000
+	recordMsbVal1  = 0x20000000
+	recordMsbVal2  = 0x40000000
+	recordTbit     = 0x80000000
+	recordSize     = 16 // sizeof(EventRecord_t)
+	recorderInfoSz = 24 // sizeof(EventRecorderInfo_t)
+	statusSize     = 36 // sizeof(EventStatus_t)
+)
+
+// Recorder is the state of the Event Recorder read from target memory.
+type Recorder struct {
+	RecordCount    uint32 // number of records in the event buffer
+	RecordIndex    uint32 // index of the next record to be written
+	RecordsWritten uint32
+	RecordsDumped  uint32
+	TsOverflow     uint32 // timestamp overflow counter
+	TsFreq         uint32 // timestamp frequency
+	Events         int    // number of reconstructed events
+	bufferAddr     uint64
+	statusAddr     uint64
+}
+
+type pendingEvent struct {
+	id   uint16
+	ts   uint64
+	irq  bool
+	vals []uint32
+	data []byte
+}
+
+// locate finds the event buffer and the status of the Event Recorder,
+// either via EventRecorderInfo or via the EventBuffer and EventStatus symbols.
+func (r *Recorder) locate(mem memory.Reader) error {
+	if addr, _, ok := elf.Symbols.GetAddrSize("EventRecorderInfo"); ok {
+		info, ok := mem.Read(addr, recorderInfoSz)
+		if !ok {
+			return errRecorder
+		}
+		if info[0] != 1 { // protocol type: DAP
+			return errRecorderInfo
+		}
+		r.RecordCount = binary.LittleEndian.Uint32(info[4:8])
+		r.bufferAddr = uint64(binary.LittleEndian.Uint32(info[8:12]))
+		r.statusAddr = uint64(binary.LittleEndian.Uint32(info[16:20]))
+	} else {
+		bufAddr, bufSize, ok1 := elf.Symbols.GetAddrSize("EventBuffer")
+		statAddr, _, ok2 := elf.Symbols.GetAddrSize("EventStatus")
+		if !ok1 || !ok2 {
+			return errRecorder
+		}
+		r.RecordCount = uint32(bufSize / recordSize)
+		r.bufferAddr = bufAddr
+		r.statusAddr = statAddr
+	}
+	if r.RecordCount == 0 || r.RecordCount&(r.RecordCount-1) != 0 {
+		return errRecorderInfo
+	}
+	return nil
+}
+
+// appendRecord appends an event in log file format (see eventlist.tsdl) to out.
+func appendRecord(out *bytes.Buffer, typ uint16, ev *pendingEvent) {
+	var length uint16
+	switch typ {
+	case 1: // EventRecordData
+		length = uint16(len(ev.data))
+	case 2: // EventRecord2
+		ev.vals = ev.vals[:2]
+	}
+	head := make([]byte, 16, 16+len(ev.data)+4*len(ev.vals))
+	binary.LittleEndian.PutUint16(head[0:], typ)
+	binary.LittleEndian.PutUint64(head[4:], ev.ts)
+	binary.LittleEndian.PutUint16(head[12:], ev.id)
+	if ev.irq {
+		length |= 0x8000
+	}
+	binary.LittleEndian.PutUint16(head[14:], length)
+	if typ == 1 {
+		head = append(head, ev.data...)
+	} else {
+		for _, v := range ev.vals {
+			head = binary.LittleEndian.AppendUint32(head, v)
+		}
+	}
+	binary.LittleEndian.PutUint16(head[2:], uint16(len(head)-4))
+	out.Write(head)
+}
+
+// AppendRecord appends an event in log file format to out, e.g. an event
+// converted from another trace format.
+//
+// Parameters:
+//   - out: The buffer receiving the event.
+//   - typ: The record type: 1 EventRecordData, 2 EventRecord2, 3 EventRecord4.
+//   - id: The event ID.
+//   - ts: The timestamp.
+//   - irq: The event was recorded in an interrupt service routine.
+//   - vals: The values of EventRecord2 and EventRecord4.
+//   - data: The data of EventRecordData.
+func AppendRecord(out *bytes.Buffer, typ uint16, id uint16, ts uint64, irq bool, vals []uint32, data []byte) {
+	v := make([]uint32, 4)
+	copy(v, vals)
+	appendRecord(out, typ, &pendingEvent{id: id, ts: ts, irq: irq, vals: v, data: data})
+}
+
+// ReadRecorder reads the Event Recorder buffer from target memory and
+// reconstructs the events in the order of their record index. Records
+// which are invalid, locked or torn are skipped, events which are split
+// into several records are reassembled. Like in EventRecorder.c, a record
+// without the FIRST and LAST flags continues an EventRecordData if its
+// component is 0xFF, otherwise it is an EventRecordData without data. The events are returned in the
+// log file format, so they can be decoded like a semihosting log.
+//
+// The addresses of the Event Recorder are taken from the symbols of the
+// application file, therefore elf.Sections.Readelf must be called before.
+//
+// Parameters:
+//   - mem: The target memory, e.g. a RAM dump combined with the application file.
+//
+// Returns:
+//   - A bufio.Reader providing the events in log file format.
+//   - The Event Recorder state.
+//   - An error if the Event Recorder cannot be found in memory.
+func ReadRecorder(mem memory.Reader) (*bufio.Reader, *Recorder, error) {
+	var r Recorder
+	if err := r.locate(mem); err != nil {
+		return nil, nil, err
+	}
+	status, ok := mem.Read(r.statusAddr, statusSize)
+	if !ok {
+		return nil, nil, errRecorder
+	}
+	r.RecordIndex = binary.LittleEndian.Uint32(status[4:8])
+	r.RecordsWritten = binary.LittleEndian.Uint32(status[8:12])
+	r.RecordsDumped = binary.LittleEndian.Uint32(status[12:16])
+	r.TsOverflow = binary.LittleEndian.Uint32(status[16:20])
+	r.TsFreq = binary.LittleEndian.Uint32(status[20:24])
+	buffer, ok := mem.Read(r.bufferAddr, uint64(r.RecordCount)*recordSize)
+	if !ok {
+		return nil, nil, errRecorder
+	}
+
+	first := uint32(0)
+	if r.RecordIndex > r.RecordCount {
+		first = r.RecordIndex - r.RecordCount
+	}
+	var out bytes.Buffer
+	var upper uint64
+	var lastTs uint32
+	var tsValid bool
+	pending := make(map[uint32]*pendingEvent)
+	for i := first; i != r.RecordIndex; i++ {
+		rec := buffer[(i&(r.RecordCount-1))*recordSize:]
+		ts := binary.LittleEndian.Uint32(rec[0:4])
+		val1 := binary.LittleEndian.Uint32(rec[4:8])
+		val2 := binary.LittleEndian.Uint32(rec[8:12])
+		info := binary.LittleEndian.Uint32(rec[12:16])
+		if info&recordValid == 0 || info&recordLocked != 0 {
+			continue
+		}
+		if (info&recordSeqMask)>>recordSeqPos != (i/r.RecordCount)&0xF {
+			continue // record of an older buffer cycle
+		}
+		tbit := info & recordTbit
+		if ts&recordTbit != tbit || val1&recordTbit != tbit || val2&recordTbit != tbit {
+			continue // record was not completely written
+		}
+		ts = ts&^recordTbit | (info&recordMsbTs)<<3
+		val1 = val1&^recordTbit | (info&recordMsbVal1)<<2
+		val2 = val2&^recordTbit | (info&recordMsbVal2)<<1
+		id := uint16(info & recordIDMask)
+		ctx := (info & recordCtxMask) >> recordCtxPos
+		comp := id >> 8
+		// continuation records of EventRecordData have the component 0xFF,
+		// a record without FIRST and LAST of another component is an
+		// EventRecordData without data
+		empty := info&(recordFirst|recordLast) == 0 && comp != 0xFF
+		if info&recordFirst != 0 || empty {
+			// records of interrupted events may be slightly older, an overflow
+			// is assumed only for a step back of more than half the range
+			if tsValid && ts < lastTs && lastTs-ts > 1<<31 {
+				upper += 1 << 32
+			}
+			lastTs = ts
+			tsValid = true
+		}
+
+		switch {
+		case empty:
+			ev := pendingEvent{id: id, ts: upper | uint64(ts), irq: info&recordIRQ != 0, data: []byte{}}
+			appendRecord(&out, 1, &ev)
+			r.Events++
+		case info&recordFirst != 0 && info&recordLast != 0:
+			ev := pendingEvent{id: id, ts: upper | uint64(ts), irq: info&recordIRQ != 0, vals: []uint32{val1, val2}}
+			if ctx == 0 { // EventRecord2
+				appendRecord(&out, 2, &ev)
+			} else { // EventRecordData with 1..7 bytes
+				ev.data = binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, val1), val2)[:ctx]
+				appendRecord(&out, 1, &ev)
+			}
+			r.Events++
+		case info&recordFirst != 0:
+			pending[ctx] = &pendingEvent{id: id, ts: upper | uint64(ts), irq: info&recordIRQ != 0,
+				vals: []uint32{val1, val2},
+				data: binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, val1), val2)}
+		default:
+			ev := pending[ctx]
+			if ev == nil {
+				continue // first record already overwritten
+			}
+			switch {
+			case info&recordLast == 0: // EventRecordData continuation
+				ev.data = binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(ev.data, val1), val2)
+				continue
+			case comp == 0 && id&0xFF == 1: // second record of EventRecord4
+				ev.vals = append(ev.vals, val1, val2)
+				appendRecord(&out, 3, ev)
+			case comp <= 8: // last record of EventRecordData, component is the remaining length
+				ev.data = binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(ev.data, val1), val2)
+				ev.data = ev.data[:len(ev.data)-8+int(comp)]
+				appendRecord(&out, 1, ev)
+			default:
+				delete(pending, ctx)
+				continue
+			}
+			delete(pending, ctx)
+			r.Events++
+		}
+	}
+	return bufio.NewReader(&out), &r, nil
+}
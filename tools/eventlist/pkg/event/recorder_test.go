@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/elf"
+	"eventlist/pkg/eval"
+	"reflect"
+	"testing"
+)
+
+type testMemory map[uint64][]byte
+
+func (m testMemory) Read(addr uint64, size uint64) ([]byte, bool) {
+	for a, d := range m {
+		if addr >= a && addr+size <= a+uint64(len(d)) {
+			return d[addr-a : addr-a+size], true
+		}
+	}
+	return nil, false
+}
+
+// putRecord stores a record into the event buffer like EventRecordItem does.
+func putRecord(buf []byte, index uint32, count uint32, id uint32, ts, val1, val2 uint32, tbit uint32) {
+	info := id | ((index/count)<<recordSeqPos)&recordSeqMask |
+		(ts>>3)&recordMsbTs | (val1>>2)&recordMsbVal1 | (val2>>1)&recordMsbVal2 |
+		recordValid | tbit
+	rec := buf[(index&(count-1))*recordSize:]
+	binary.LittleEndian.PutUint32(rec[0:], ts&^recordTbit|tbit)
+	binary.LittleEndian.PutUint32(rec[4:], val1&^recordTbit|tbit)
+	binary.LittleEndian.PutUint32(rec[8:], val2&^recordTbit|tbit)
+	binary.LittleEndian.PutUint32(rec[12:], info)
+}
+
+func TestReadRecorder(t *testing.T) { //nolint:golint,paralleltest
+	const count = 8
+	info := make([]byte, recorderInfoSz)
+	info[0] = 1
+	binary.LittleEndian.PutUint32(info[4:], count)
+	binary.LittleEndian.PutUint32(info[8:], 0x20000000)
+	binary.LittleEndian.PutUint32(info[16:], 0x20000100)
+
+	status := make([]byte, statusSize)
+	binary.LittleEndian.PutUint32(status[4:], 11)    // record index
+	binary.LittleEndian.PutUint32(status[20:], 1000) // ts_freq
+
+	buf := make([]byte, count*recordSize)
+	putRecord(buf, 2, count, 0xEF00|recordFirst|recordLast, 1, 1, 2, 0)                    // overwritten
+	putRecord(buf, 3, count, 0xFF03|recordFirst|recordLast, 10, 0x80000004, 2, recordTbit) // EventRecord2
+	putRecord(buf, 4, count, 0xEF01|recordIRQ|1<<recordCtxPos|recordFirst, 20, 1, 2, 0)    // EventRecord4
+	putRecord(buf, 5, count, 0x3301|2<<recordCtxPos|recordFirst, 30, 0x64636261, 0x68676665, 0)
+	putRecord(buf, 6, count, 0x0001|1<<recordCtxPos|recordLast, 20, 3, 4, 0)
+	putRecord(buf, 7, count, 0x0301|2<<recordCtxPos|recordLast, 30, 0x6B6A69, 0, 0) // EventRecordData 11 bytes
+	putRecord(buf, 8, count, 0x4400|3<<recordCtxPos|recordFirst|recordLast, 0xFFFFFF00, 0x434241, 0, 0)
+	putRecord(buf, 9, count, 0xEF02|recordFirst|recordLast, 6, 5, 6, 0)
+	binary.LittleEndian.PutUint32(buf[(9&(count-1))*recordSize+4:], 5|recordTbit) // torn record
+	putRecord(buf, 10, count, 0xEF03|recordFirst|recordLast, 7, 7, 8, 0)
+
+	mem := testMemory{0x08000000: info, 0x20000100: status, 0x20000000: buf}
+
+	data11 := []uint8("abcdefghijk")
+	data3 := []uint8("ABC")
+	want := []Data{
+		{Time: 10, Value1: -0x7FFFFFFC, Value2: 2, Typ: 2, Info: Info{ID: 0xFF03}},
+		{Time: 20, Value1: 1, Value2: 2, Value3: 3, Value4: 4, Typ: 3, Info: Info{ID: 0xEF01, irq: true}},
+		{Time: 30, Data: &data11, Typ: 1, Info: Info{ID: 0x3301, length: 11}},
+		{Time: 0xFFFFFF00, Data: &data3, Typ: 1, Info: Info{ID: 0x4400, length: 3}},
+		{Time: 1<<32 | 7, Value1: 7, Value2: 8, Typ: 2, Info: Info{ID: 0xEF03}},
+	}
+
+	elf.Symbols.Init("EventRecorderInfo", 0x08000000, recorderInfoSz)
+	in, r, err := ReadRecorder(mem)
+	if err != nil {
+		t.Fatalf("ReadRecorder() error = %v", err)
+	}
+	if r.RecordCount != count || r.RecordIndex != 11 || r.TsFreq != 1000 || r.Events != len(want) {
+		t.Errorf("ReadRecorder() = %+v", *r)
+	}
+	for i := range want {
+		var ev Data
+		if err := ev.Read(in); err != nil {
+			t.Fatalf("ReadRecorder() event %d error = %v", i, err)
+		}
+		if !reflect.DeepEqual(ev, want[i]) {
+			t.Errorf("ReadRecorder() event %d = %+v, want %+v", i, ev, want[i])
+		}
+	}
+	var ev Data
+	if err := ev.Read(in); !errors.Is(err, eval.ErrEof) {
+		t.Errorf("ReadRecorder() end error = %v, want %v", err, eval.ErrEof)
+	}
+
+	tests := []struct {
+		name    string
+		symbol  string
+		mem     testMemory
+		wantErr error
+	}{
+		{"no symbol", "nix", mem, errRecorder},
+		{"no info", "EventRecorderInfo", testMemory{}, errRecorder},
+		{"no status", "EventRecorderInfo", testMemory{0x08000000: info}, errRecorder},
+		{"bad info", "EventRecorderInfo", testMemory{0x08000000: make([]byte, recorderInfoSz)}, errRecorderInfo},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			elf.Symbols.Init(tt.symbol, 0x08000000, recorderInfoSz)
+			if _, _, err := ReadRecorder(tt.mem); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadRecorder() %s error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadRecorder_dataRecords(t *testing.T) { //nolint:golint,paralleltest
+	const count = 8
+	info := make([]byte, recorderInfoSz)
+	info[0] = 1
+	binary.LittleEndian.PutUint32(info[4:], count)
+	binary.LittleEndian.PutUint32(info[8:], 0x20000000)
+	binary.LittleEndian.PutUint32(info[16:], 0x20000100)
+
+	status := make([]byte, statusSize)
+	binary.LittleEndian.PutUint32(status[4:], 6) // record index
+
+	// EventRecordData with 20 bytes in context 0, interrupted by an
+	// EventRecordData without data, and another one without data
+	buf := make([]byte, count*recordSize)
+	putRecord(buf, 0, count, 0x3302|recordFirst, 40, 0x64636261, 0x68676665, 0)
+	putRecord(buf, 1, count, 0xFF01, 40, 0x6C6B6A69, 0x706F6E6D, 0)
+	putRecord(buf, 2, count, 0x3400|recordIRQ, 41, 0, 0, 0)
+	putRecord(buf, 3, count, 0x0402|recordLast, 40, 0x74737271, 0, 0)
+	putRecord(buf, 4, count, 0x3401, 42, 0, 0, 0)
+	putRecord(buf, 5, count, 0xFF01, 43, 1, 2, 0) // continuation without first record
+
+	mem := testMemory{0x08000000: info, 0x20000100: status, 0x20000000: buf}
+
+	data20 := []uint8("abcdefghijklmnopqrst")
+	empty := []uint8{}
+	want := []Data{
+		{Time: 41, Data: &empty, Typ: 1, Info: Info{ID: 0x3400, irq: true}},
+		{Time: 40, Data: &data20, Typ: 1, Info: Info{ID: 0x3302, length: 20}},
+		{Time: 42, Data: &empty, Typ: 1, Info: Info{ID: 0x3401}},
+	}
+
+	elf.Symbols.Init("EventRecorderInfo", 0x08000000, recorderInfoSz)
+	in, r, err := ReadRecorder(mem)
+	if err != nil {
+		t.Fatalf("ReadRecorder() error = %v", err)
+	}
+	if r.Events != len(want) {
+		t.Errorf("ReadRecorder() events = %d, want %d", r.Events, len(want))
+	}
+	for i := range want {
+		var ev Data
+		if err := ev.Read(in); err != nil {
+			t.Fatalf("ReadRecorder() event %d error = %v", i, err)
+		}
+		if !reflect.DeepEqual(ev, want[i]) {
+			t.Errorf("ReadRecorder() event %d = %+v, want %+v", i, ev, want[i])
+		}
+	}
+	var ev Data
+	if err := ev.Read(in); !errors.Is(err, eval.ErrEof) {
+		t.Errorf("ReadRecorder() end error = %v, want %v", err, eval.ErrEof)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"bufio"
+	"encoding/binary"
+	"eventlist/pkg/eval"
+	"io"
+)
+
+// maxRecordSize is the size of the largest record: header and 16-bit length.
+const maxRecordSize = 4 + 0xFFFF
+
+// minRecordSize is the size of a record without values: header, timestamp and info.
+const minRecordSize = 16
+
+// syncWindow is the number of bytes searched for the sync points after damaged data.
+const syncWindow = 4096
+
+// idInitialize is the ID of EventRecorderInitialize, which restarts the timestamps.
+const idInitialize = 0xFF00
+
+// SkippedRange is a range of bytes of a log file which does not contain
+// plausible records.
+type SkippedRange struct {
+	Offset int64 // offset of the first byte
+	Length int64
+}
+
+// Resync reads the records of a log file which may be corrupted or
+// truncated. A record is plausible if its type is 1..3 and its length
+// matches the type and the data length of its info. Data which is not a
+// plausible record is skipped up to the next sync point: a plausible record
+// followed by another plausible record or the end of the file. Of the sync
+// points found within syncWindow bytes, the first one whose timestamps
+// continue the records before is taken, otherwise the first one. The
+// timestamps continue if they do not decrease, except for the wraparound of
+// a 32-bit timestamp and for EventRecorderInitialize, which restarts them.
+type Resync struct {
+	in      *bufio.Reader
+	offset  int64  // offset of the next byte
+	last    uint64 // timestamp of the last record
+	started bool   // a record was read
+	skipped []SkippedRange
+}
+
+// NewResync creates the reader of a log file.
+//
+// Parameters:
+//   - in: The log file.
+//
+// Returns:
+//   - The reader, which buffers the sync window and two of the largest records.
+func NewResync(in io.Reader) *Resync {
+	return &Resync{in: bufio.NewReaderSize(in, syncWindow+2*maxRecordSize+minRecordSize)}
+}
+
+// Skipped returns the byte ranges skipped since the last call.
+func (r *Resync) Skipped() []SkippedRange {
+	skipped := r.skipped
+	r.skipped = nil
+	return skipped
+}
+
+// monotonic checks that a timestamp follows the previous one. A decrease
+// is accepted if it is the wraparound of a 32-bit timestamp.
+func monotonic(prev, ts uint64) bool {
+	if ts >= prev {
+		return true
+	}
+	return prev <= 0xFFFFFFFF && prev-ts > 0x80000000
+}
+
+// continues checks that a record continues the timestamps of the record before.
+//
+// Parameters:
+//   - prev: The timestamp of the record before.
+//   - ts: The timestamp of the record.
+//   - id: The ID of the record.
+//
+// Returns:
+//   - true if the timestamp does not decrease or the record restarts the timestamps.
+func continues(prev, ts uint64, id uint16) bool {
+	return id == idInitialize || monotonic(prev, ts)
+}
+
+// record checks the record at an offset of the buffered data.
+//
+// Parameters:
+//   - at: The offset of the record in the buffered data.
+//
+// Returns:
+//   - The size of the record.
+//   - The timestamp of the record.
+//   - The ID of the record.
+//   - true if the record is plausible and complete.
+func (r *Resync) record(at int) (int, uint64, uint16, bool) {
+	head, err := r.in.Peek(at + minRecordSize)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	head = head[at:]
+	typ := binary.LittleEndian.Uint16(head[0:])
+	length := int(binary.LittleEndian.Uint16(head[2:]))
+	ts := binary.LittleEndian.Uint64(head[4:])
+	id := binary.LittleEndian.Uint16(head[12:])
+	dataLength := int(binary.LittleEndian.Uint16(head[14:]) & 0x7FFF)
+	switch typ {
+	case 1: // EventRecordData, the data may be padded
+		if length < 12+dataLength || length > 12+dataLength+3 {
+			return 0, 0, 0, false
+		}
+	case 2: // EventRecord2
+		if length != 20 {
+			return 0, 0, 0, false
+		}
+	case 3: // EventRecord4
+		if length != 28 {
+			return 0, 0, 0, false
+		}
+	default:
+		return 0, 0, 0, false
+	}
+	size := 4 + length
+	if _, err = r.in.Peek(at + size); err != nil {
+		return 0, 0, 0, false // truncated
+	}
+	return size, ts, id, true
+}
+
+// atEnd checks if the buffered data ends at an offset.
+func (r *Resync) atEnd(at int) bool {
+	_, err := r.in.Peek(at + 1)
+	return err != nil
+}
+
+// sync searches the sync point after damaged data at the start of the
+// buffered data.
+//
+// Returns:
+//   - The offset of the sync point, of the end of the data or of the last searched byte.
+//   - true if a sync point was found.
+func (r *Resync) sync() (int, bool) {
+	first := -1
+	end := syncWindow // the bytes up to the window end are no sync points
+	for at := 1; at <= syncWindow; at++ {
+		if r.atEnd(at) {
+			end = at
+			break
+		}
+		size, ts, id, ok := r.record(at)
+		if !ok {
+			continue
+		}
+		_, nextTs, nextID, next := r.record(at + size)
+		if !next && !r.atEnd(at+size) {
+			continue
+		}
+		if (!r.started || continues(r.last, ts, id)) && (!next || continues(ts, nextTs, nextID)) {
+			return at, true
+		}
+		if first < 0 {
+			first = at
+		}
+	}
+	if first >= 0 {
+		return first, true
+	}
+	return end, false
+}
+
+// skip records the bytes skipped since an offset.
+func (r *Resync) skip(begin int64) {
+	if r.offset > begin {
+		r.skipped = append(r.skipped, SkippedRange{Offset: begin, Length: r.offset - begin})
+	}
+}
+
+// Read reads the next plausible record. The timestamps are only used to
+// choose the sync point after damaged data, a plausible record following
+// the record before is never skipped.
+//
+// Parameters:
+//   - e: The event receiving the record.
+//
+// Returns:
+//   - eval.ErrEof at the end of the log file.
+func (r *Resync) Read(e *Data) error {
+	begin := r.offset
+	size, _, _, ok := r.record(0)
+	for !ok {
+		if r.atEnd(0) {
+			r.skip(begin)
+			return eval.ErrEof
+		}
+		var at int
+		at, ok = r.sync()
+		_, _ = r.in.Discard(at)
+		r.offset += int64(at)
+		if ok {
+			size, _, _, _ = r.record(0)
+		}
+	}
+	r.skip(begin)
+	if err := e.Read(r.in); err != nil {
+		return err
+	}
+	r.offset += int64(size)
+	r.last = e.Time
+	r.started = true
+	return nil
+}
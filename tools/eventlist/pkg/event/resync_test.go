@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"eventlist/pkg/eval"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_monotonic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		prev uint64
+		ts   uint64
+		want bool
+	}{
+		{"equal", 5, 5, true},
+		{"increase", 5, 6, true},
+		{"decrease", 6, 5, false},
+		{"wrap", 0xFFFFFF00, 0x10, true},
+		{"small decrease", 0xFFFFFF00, 0xFFFFF000, false},
+		{"64-bit decrease", 0x100000010, 0x10, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := monotonic(tt.prev, tt.ts); got != tt.want {
+				t.Errorf("monotonic() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResync_Read(t *testing.T) {
+	t.Parallel()
+
+	record := func(typ uint16, id uint16, ts uint64, data []byte) []byte {
+		var b bytes.Buffer
+		AppendRecord(&b, typ, id, ts, false, []uint32{1, 2, 3, 4}, data)
+		return b.Bytes()
+	}
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+	r1 := record(2, 0x0100, 10, nil)
+	r2 := record(3, 0x0101, 20, nil)
+	r3 := record(1, 0xFE00, 30, []byte("hello"))
+	r4 := record(2, 0x0102, 40, nil)
+	r5 := record(2, 0x0106, 50, nil)
+	r6 := record(2, 0x0107, 60, nil)
+	old := record(2, 0x0103, 5, nil)
+	wrap := record(2, 0x0104, 0x10, nil)
+	last := record(2, 0x0105, 0xFFFFFFF0, nil)
+	garbage := []byte{0x02, 0x00, 0x13, 0x00, 0xAA, 0x55, 0x01}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		ids     []uint16
+		skipped []SkippedRange
+	}{
+		{"intact", join(r1, r2, r3, r4), []uint16{0x0100, 0x0101, 0xFE00, 0x0102}, nil},
+		{"garbage before", join(garbage, r1, r2), []uint16{0x0100, 0x0101}, []SkippedRange{{0, 7}}},
+		{"garbage between", join(r1, garbage, r2, r3), []uint16{0x0100, 0x0101, 0xFE00}, []SkippedRange{{24, 7}}},
+		{"corrupted length", join(r1, r2[:2], []byte{0x30, 0x00}, r2[4:], r3), []uint16{0x0100, 0xFE00}, []SkippedRange{{24, 32}}},
+		{"decreasing time", join(r1, r2, old, r3), []uint16{0x0100, 0x0101, 0x0103, 0xFE00}, nil},
+		{"reset", join(record(2, 0x0100, 1000, nil), record(2, 0x0101, 2000, nil), record(2, 0xFF00, 10, nil), r4, r5, r6),
+			[]uint16{0x0100, 0x0101, 0xFF00, 0x0102, 0x0106, 0x0107}, nil},
+		{"stale candidate", join(r1, garbage, old, r2, r3), []uint16{0x0100, 0x0101, 0xFE00}, []SkippedRange{{24, 31}}},
+		{"stale only", join(r1, garbage, old), []uint16{0x0100, 0x0103}, []SkippedRange{{24, 7}}},
+		{"wraparound", join(last, wrap), []uint16{0x0105, 0x0104}, nil},
+		{"truncated", join(r1, r2, r3[:10]), []uint16{0x0100, 0x0101}, []SkippedRange{{56, 10}}},
+		{"garbage at end", join(r1, garbage), []uint16{0x0100}, []SkippedRange{{24, 7}}},
+		{"only garbage", garbage, nil, []SkippedRange{{0, 7}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewResync(bytes.NewReader(tt.data))
+			var ids []uint16
+			var skipped []SkippedRange
+			for {
+				var e Data
+				err := r.Read(&e)
+				skipped = append(skipped, r.Skipped()...)
+				if errors.Is(err, eval.ErrEof) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Resync.Read() %s error = %v", tt.name, err)
+				}
+				ids = append(ids, uint16(e.Info.ID))
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("Resync.Read() %s IDs = %04X, want %04X", tt.name, ids, tt.ids)
+			}
+			if !reflect.DeepEqual(skipped, tt.skipped) {
+				t.Errorf("Resync.Read() %s skipped = %v, want %v", tt.name, skipped, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestResync_logFiles(t *testing.T) {
+	t.Parallel()
+
+	// intact log files are read like without resync, including the
+	// EventRecorderInitialize records restarting the timestamps
+	for _, name := range []string{"test.binary", "test10.binary"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(filepath.Join("../../testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want []Data
+			in := bufio.NewReader(bytes.NewReader(data))
+			for {
+				var e Data
+				if err := e.Read(in); err != nil {
+					break
+				}
+				want = append(want, e)
+			}
+			var got []Data
+			r := NewResync(bytes.NewReader(data))
+			for {
+				var e Data
+				if err := r.Read(&e); err != nil {
+					break
+				}
+				got = append(got, e)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Resync.Read() %s = %d events, want %d", name, len(got), len(want))
+			}
+			if skipped := r.Skipped(); skipped != nil {
+				t.Errorf("Resync.Read() %s skipped = %v, want none", name, skipped)
+			}
+		})
+	}
+}
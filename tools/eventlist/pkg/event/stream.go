@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const tcpPrefix = "tcp://"
+
+// pollInterval is the time to wait for new data at the end of a followed file.
+var pollInterval = 100 * time.Millisecond
+
+// Stream is a live source of events in log file format: a growing log
+// file, a named pipe or a TCP connection, e.g. of a debug probe bridge.
+type Stream struct {
+	src       io.ReadCloser
+	follow    bool
+	stop      chan struct{}
+	once      sync.Once
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// IsStream reports whether name is a live event source,
+// which is a tcp://host:port address or a named pipe.
+func IsStream(name string) bool {
+	if strings.HasPrefix(name, tcpPrefix) {
+		return true
+	}
+	fi, err := os.Stat(name)
+	return err == nil && fi.Mode()&os.ModeNamedPipe != 0
+}
+
+// Open opens a live event source. A name of the form tcp://host:port
+// connects to a TCP server, any other name is opened as file. With follow
+// set, the end of a file does not end the stream, further data appended
+// to the file is read until Stop is called.
+//
+// Parameters:
+//   - name: The file name, named pipe or tcp://host:port address.
+//   - follow: Wait for more data at the end of the file.
+//
+// Returns:
+//   - A bufio.Reader providing the events as they arrive.
+//   - An error if the source cannot be opened.
+func (s *Stream) Open(name string, follow bool) (*bufio.Reader, error) {
+	var err error
+	if addr, ok := strings.CutPrefix(name, tcpPrefix); ok {
+		s.src, err = net.Dial("tcp", addr)
+	} else {
+		s.src, err = os.Open(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.follow = follow
+	s.stop = make(chan struct{})
+	return bufio.NewReader(s), nil
+}
+
+// Read reads from the source. At the end of a followed file it waits for
+// more data. After Stop has been called the end of the stream is reported.
+func (s *Stream) Read(p []byte) (int, error) {
+	for {
+		n, err := s.src.Read(p)
+		if n > 0 {
+			return n, nil
+		}
+		select {
+		case <-s.stop:
+			return 0, io.EOF
+		default:
+		}
+		if !errors.Is(err, io.EOF) || !s.follow {
+			return n, err
+		}
+		select {
+		case <-s.stop:
+			return 0, io.EOF
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Stop ends the stream, e.g. on user interrupt. A pending read of a
+// connection or a named pipe is aborted by an expired read deadline, a
+// source without read deadlines is closed.
+func (s *Stream) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+		if d, ok := s.src.(interface{ SetReadDeadline(time.Time) error }); ok && d.SetReadDeadline(time.Now()) == nil {
+			return
+		}
+		_ = s.Close()
+	})
+}
+
+// Close closes the source, it may already be closed by Stop.
+func (s *Stream) Close() error {
+	s.closeOnce.Do(func() {
+		s.closeErr = s.src.Close()
+	})
+	return s.closeErr
+}
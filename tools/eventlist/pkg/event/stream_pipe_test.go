@@ -0,0 +1,105 @@
+//go:build !windows
+
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"errors"
+	"eventlist/pkg/eval"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestStream_pipeStop(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "events")
+	if err := syscall.Mkfifo(name, 0o600); err != nil {
+		t.Skipf("named pipe: %v", err)
+	}
+	writer := make(chan *os.File, 1)
+	go func() {
+		// the writer stays open without sending, so the read blocks
+		f, err := os.OpenFile(name, os.O_WRONLY, 0)
+		if err != nil {
+			close(writer)
+			return
+		}
+		writer <- f
+	}()
+
+	var s Stream
+	in, err := s.Open(name, false)
+	if err != nil {
+		t.Fatalf("Stream.Open() error = %v", err)
+	}
+	defer s.Close()
+	if f, ok := <-writer; ok {
+		defer f.Close()
+	}
+	time.AfterFunc(10*time.Millisecond, s.Stop)
+	done := make(chan error)
+	go func() {
+		var ev Data
+		done <- ev.Read(in)
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, eval.ErrEof) {
+			t.Errorf("Stream.Read() stop error = %v, want %v", err, eval.ErrEof)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stream.Read() not stopped")
+	}
+}
+
+func TestStream_closeStop(t *testing.T) {
+	t.Parallel()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// a source without read deadlines is closed by Stop
+	s := Stream{src: struct{ io.ReadCloser }{r}, stop: make(chan struct{})}
+	time.AfterFunc(10*time.Millisecond, s.Stop)
+	done := make(chan error)
+	go func() {
+		_, err := s.Read(make([]byte, 16))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("Stream.Read() stop error = %v, want %v", err, io.EOF)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stream.Read() not stopped")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Stream.Close() error = %v", err)
+	}
+}
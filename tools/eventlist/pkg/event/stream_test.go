@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"errors"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/xml/scvd"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		arg  string
+		want bool
+	}{
+		{"tcp", "tcp://localhost:1234", true},
+		{"file", "../../testdata/test10.binary", false},
+		{"nix", "../../testdata/nix", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsStream(tt.arg); got != tt.want {
+				t.Errorf("IsStream() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStream_tcp(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("../../testdata/test10.binary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// send the records in pieces like a probe bridge
+		for len(data) > 0 {
+			n := 5
+			if n > len(data) {
+				n = len(data)
+			}
+			if _, err := conn.Write(data[:n]); err != nil {
+				return
+			}
+			data = data[n:]
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	var s Stream
+	in, err := s.Open("tcp://"+ln.Addr().String(), false)
+	if err != nil {
+		t.Fatalf("Stream.Open() error = %v", err)
+	}
+	defer s.Close()
+	ids := []scvd.IDType{0xFF03, 0xFE00}
+	for i, id := range ids {
+		var ev Data
+		if err := ev.Read(in); err != nil {
+			t.Fatalf("Stream.Read() event %d error = %v", i, err)
+		}
+		if ev.Info.ID != id {
+			t.Errorf("Stream.Read() event %d = 0x%04X, want 0x%04X", i, ev.Info.ID, id)
+		}
+	}
+	var ev Data
+	if err := ev.Read(in); !errors.Is(err, eval.ErrEof) {
+		t.Errorf("Stream.Read() end error = %v, want %v", err, eval.ErrEof)
+	}
+}
+
+func TestStream_tcpStop(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			time.Sleep(time.Second)
+			conn.Close()
+		}
+	}()
+
+	var s Stream
+	in, err := s.Open("tcp://"+ln.Addr().String(), false)
+	if err != nil {
+		t.Fatalf("Stream.Open() error = %v", err)
+	}
+	defer s.Close()
+	time.AfterFunc(10*time.Millisecond, s.Stop)
+	var ev Data
+	if err := ev.Read(in); !errors.Is(err, eval.ErrEof) {
+		t.Errorf("Stream.Read() stop error = %v, want %v", err, eval.ErrEof)
+	}
+}
+
+func TestStream_follow(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("../../testdata/test10.binary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "log.binary")
+	if err := os.WriteFile(name, data[:10], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var s Stream
+	in, err := s.Open(name, true)
+	if err != nil {
+		t.Fatalf("Stream.Open() error = %v", err)
+	}
+	defer s.Close()
+
+	events := make(chan scvd.IDType)
+	go func() {
+		defer close(events)
+		for {
+			var ev Data
+			if err := ev.Read(in); err != nil {
+				return
+			}
+			events <- ev.Info.ID
+		}
+	}()
+
+	// the log file grows while it is read
+	time.Sleep(2 * pollInterval)
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.Write(data[10:])
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []scvd.IDType{0xFF03, 0xFE00} {
+		if got := <-events; got != want {
+			t.Errorf("Stream.Read() follow = 0x%04X, want 0x%04X", got, want)
+		}
+	}
+	s.Stop()
+	if id, ok := <-events; ok {
+		t.Errorf("Stream.Read() after stop = 0x%04X", id)
+	}
+}
+
+func TestStream_Open(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		arg  string
+	}{
+		{"nix", "../../testdata/nix"},
+		{"tcp", "tcp://127.0.0.1:0"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var s Stream
+			if in, err := s.Open(tt.arg, false); in != nil || err == nil {
+				t.Errorf("Stream.Open() %s = %v, want error", tt.name, err)
+			}
+		})
+	}
+}
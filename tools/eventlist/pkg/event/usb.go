@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Standard USB descriptor types (USB 2.0 specification, table 9-5).
+const (
+	usbDevice        = 1
+	usbConfiguration = 2
+	usbString        = 3
+	usbInterface     = 4
+	usbEndpoint      = 5
+)
+
+// usbTransferTypes are the transfer types of bmAttributes of an endpoint.
+var usbTransferTypes = [4]string{"Control", "Isochronous", "Bulk", "Interrupt"}
+
+// bcd formats a binary coded decimal version number, e.g. 2.00 for 0x0200.
+func bcd(v uint16) string {
+	return fmt.Sprintf("%x.%02x", v>>8, v&0xFF)
+}
+
+// formatUSBDescriptor formats one standard USB descriptor.
+//
+// Parameters:
+//   - d: The descriptor, the length is given by bLength.
+//
+// Returns:
+//   - The descriptor fields.
+func formatUSBDescriptor(d []byte) string {
+	le := binary.LittleEndian
+	switch d[1] {
+	case usbDevice:
+		if len(d) < 18 {
+			break
+		}
+		return fmt.Sprintf("Device: bcdUSB=%s, bDeviceClass=0x%02x, bDeviceSubClass=0x%02x, bDeviceProtocol=0x%02x, "+
+			"bMaxPacketSize0=%d, idVendor=0x%04x, idProduct=0x%04x, bcdDevice=%s, "+
+			"iManufacturer=%d, iProduct=%d, iSerialNumber=%d, bNumConfigurations=%d",
+			bcd(le.Uint16(d[2:])), d[4], d[5], d[6], d[7], le.Uint16(d[8:]), le.Uint16(d[10:]), bcd(le.Uint16(d[12:])),
+			d[14], d[15], d[16], d[17])
+	case usbConfiguration:
+		if len(d) < 9 {
+			break
+		}
+		return fmt.Sprintf("Configuration: wTotalLength=%d, bNumInterfaces=%d, bConfigurationValue=%d, "+
+			"iConfiguration=%d, bmAttributes=0x%02x, bMaxPower=%dmA",
+			le.Uint16(d[2:]), d[4], d[5], d[6], d[7], 2*int(d[8]))
+	case usbString:
+		text := make([]uint16, (len(d)-2)/2)
+		for i := range text {
+			text[i] = le.Uint16(d[2+2*i:])
+		}
+		return fmt.Sprintf("String: %q", string(utf16.Decode(text)))
+	case usbInterface:
+		if len(d) < 9 {
+			break
+		}
+		return fmt.Sprintf("Interface: bInterfaceNumber=%d, bAlternateSetting=%d, bNumEndpoints=%d, "+
+			"bInterfaceClass=0x%02x, bInterfaceSubClass=0x%02x, bInterfaceProtocol=0x%02x, iInterface=%d",
+			d[2], d[3], d[4], d[5], d[6], d[7], d[8])
+	case usbEndpoint:
+		if len(d) < 7 {
+			break
+		}
+		dir := "OUT"
+		if d[2]&0x80 != 0 {
+			dir = "IN"
+		}
+		return fmt.Sprintf("Endpoint: bEndpointAddress=0x%02x (%s %d), bmAttributes=0x%02x (%s), wMaxPacketSize=%d, bInterval=%d",
+			d[2], dir, d[2]&0x0F, d[3], usbTransferTypes[d[3]&0x03], le.Uint16(d[4:])&0x7FF, d[6])
+	default:
+		return fmt.Sprintf("Descriptor 0x%02x: bLength=%d", d[1], d[0])
+	}
+	return fmt.Sprintf("Descriptor 0x%02x: invalid bLength=%d", d[1], d[0])
+}
+
+// formatUSB formats standard USB descriptors, e.g. a configuration
+// descriptor followed by its interface and endpoint descriptors.
+//
+// Parameters:
+//   - data: The descriptors.
+//
+// Returns:
+//   - The fields of the descriptors, separated by semicolons.
+func formatUSB(data []byte) string {
+	var out []string
+	for len(data) >= 2 {
+		n := int(data[0])
+		if n == 0 { // padding
+			break
+		}
+		if n < 2 || n > len(data) {
+			out = append(out, fmt.Sprintf("Descriptor 0x%02x: invalid bLength=%d", data[1], data[0]))
+			break
+		}
+		out = append(out, formatUSBDescriptor(data[:n]))
+		data = data[n:]
+	}
+	return strings.Join(out, "; ")
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"eventlist/pkg/eval"
+	"testing"
+)
+
+func Test_formatUSB(t *testing.T) {
+	t.Parallel()
+
+	device := []byte{18, 1, 0x00, 0x02, 0xEF, 0x02, 0x01, 64, 0x51, 0xC2, 0x0A, 0xF0, 0x00, 0x01, 1, 2, 3, 1}
+	config := []byte{9, 2, 32, 0, 1, 1, 0, 0xC0, 50,
+		9, 4, 0, 0, 2, 0x08, 0x06, 0x50, 4,
+		7, 5, 0x81, 0x02, 0x40, 0x00, 0x00,
+		7, 5, 0x01, 0x02, 0x40, 0x00, 0x00}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"device", device, "Device: bcdUSB=2.00, bDeviceClass=0xef, bDeviceSubClass=0x02, bDeviceProtocol=0x01, " +
+			"bMaxPacketSize0=64, idVendor=0xc251, idProduct=0xf00a, bcdDevice=1.00, " +
+			"iManufacturer=1, iProduct=2, iSerialNumber=3, bNumConfigurations=1"},
+		{"configuration", config, "Configuration: wTotalLength=32, bNumInterfaces=1, bConfigurationValue=1, " +
+			"iConfiguration=0, bmAttributes=0xc0, bMaxPower=100mA; " +
+			"Interface: bInterfaceNumber=0, bAlternateSetting=0, bNumEndpoints=2, " +
+			"bInterfaceClass=0x08, bInterfaceSubClass=0x06, bInterfaceProtocol=0x50, iInterface=4; " +
+			"Endpoint: bEndpointAddress=0x81 (IN 1), bmAttributes=0x02 (Bulk), wMaxPacketSize=64, bInterval=0; " +
+			"Endpoint: bEndpointAddress=0x01 (OUT 1), bmAttributes=0x02 (Bulk), wMaxPacketSize=64, bInterval=0"},
+		{"string", []byte{8, 3, 'K', 0, 'e', 0, 'i', 0}, "String: \"Kei\""},
+		{"other", []byte{5, 0x0F, 5, 0, 0}, "Descriptor 0x0f: bLength=5"},
+		{"padding", []byte{4, 3, 'a', 0, 0, 0}, "String: \"a\""},
+		{"short device", device[:9], "Descriptor 0x01: invalid bLength=18"},
+		{"short length", []byte{9, 1, 0, 2, 0, 0, 0, 64, 0}, "Descriptor 0x01: invalid bLength=9"},
+		{"invalid length", []byte{1, 1}, "Descriptor 0x01: invalid bLength=1"},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := formatUSB(tt.data); got != tt.want {
+				t.Errorf("formatUSB() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatValue_block(t *testing.T) {
+	t.Parallel()
+
+	ip := []byte{0xfe, 0x80, 0, 0, 0, 0, 0, 0, 0x02, 0x11, 0x22, 0xff, 0xfe, 0x33, 0x44, 0x55}
+	var integer eval.Value
+	integer.Compose(eval.Integer, 0x20010db8, 0.0, "")
+
+	tests := []struct {
+		name    string
+		c       byte
+		val     eval.Value
+		want    string
+		wantErr bool
+	}{
+		{"J block", 'J', eval.Block(ip, 0x20000000, "uint8_t"), "fe80::211:22ff:fe33:4455", false},
+		{"J short block", 'J', eval.Block(ip[:15], 0x20000000, "uint8_t"), "", true},
+		{"J value", 'J', integer, "::2001:db8", false},
+		{"U block", 'U', eval.Block([]byte{4, 3, 'a', 0}, 0x20000000, "uint8_t"), "String: \"a\"", false},
+		{"U value", 'U', integer, "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FormatValue(tt.c, tt.val)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatValue() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatValue() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
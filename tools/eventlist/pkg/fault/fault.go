@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fault decodes the fault information saved by the ARM_FaultSave
+// function of the Fault component (ARM_FaultInfo_t, see ARM_Fault.h).
+package fault
+
+import (
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/elf"
+	"eventlist/pkg/memory"
+	"fmt"
+	"strings"
+)
+
+var errSymbol = errors.New("fault information not found: symbol ARM_FaultInfo missing")
+
+var errRead = errors.New("fault information not contained in memory image")
+
+var errSize = errors.New("fault information too short")
+
+var errMagic = errors.New("no fault saved yet: invalid magic number")
+
+var errCRC = errors.New("fault information is invalid: CRC mismatch")
+
+var errVersion = errors.New("unsupported fault information version")
+
+const (
+	magicNumber = 0x52746C46 // ASCII "FltR"
+	crc32Init   = 0xFFFFFFFF // CRC-32 initial value
+	crc32Poly   = 0x04C11DB7 // CRC-32 polynom
+
+	infoSize     = 104 // size of ARM_FaultInfo_t without fault registers
+	infoRegsSize = 140 // size of ARM_FaultInfo_t with fault registers
+	versionMajor = 1   // supported ARM_FaultInfo_t version
+
+	infoSymbol    = "ARM_FaultInfo"
+	versionSymbol = "ARM_FaultVersion"
+	versionSize   = 16 // maximum length of the component version string
+)
+
+// Bits of ARM_FaultInfo_t.Content.
+const (
+	FaultRegsExist = 1 << iota
+	Armv8xMMain
+	TZEnabled
+	TZSaveMode
+	TZFaultMode
+	StateContext
+	AdditionalContext
+	LimitRegs
+	FaultRegs
+	SecureFaultRegs
+	RASFaultReg
+)
+
+// Indexes of Info.Registers.
+const (
+	R0 = iota
+	R1
+	R2
+	R3
+	R4
+	R5
+	R6
+	R7
+	R8
+	R9
+	R10
+	R11
+	R12
+	LR
+	ReturnAddress
+	XPSR
+	MSP
+	PSP
+	MSPLIM
+	PSPLIM
+)
+
+// Indexes of Info.FaultRegisters.
+const (
+	CFSR = iota
+	HFSR
+	DFSR
+	MMFAR
+	BFAR
+	AFSR
+	SFSR
+	SFAR
+	RFSR
+)
+
+// Info is the decoded content of ARM_FaultInfo_t.
+type Info struct {
+	Version        string     // component version, empty if unknown
+	Count          uint32     // saved faults counter
+	VersionMajor   uint8      // structure version
+	VersionMinor   uint8      // structure version
+	Content        uint16     // content bits, see FaultRegsExist
+	Registers      [20]uint32 // R0 .. PSPLIM
+	ExcXPSR        uint32     // xPSR in exception handler
+	ExcReturn      uint32     // EXC_RETURN in exception handler
+	FaultRegisters [9]uint32  // CFSR .. RFSR, only if FaultRegsExist
+}
+
+// crc32 calculates the CRC-32 of the fault information like ARM_FaultSave:
+// MSB first without final XOR.
+func crc32(data []byte) uint32 {
+	crc := uint32(crc32Init)
+	for _, b := range data {
+		crc ^= uint32(b) << 24
+		for i := 0; i < 8; i++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ crc32Poly
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// size returns the size of ARM_FaultInfo_t given by its content bits.
+func size(content uint16) int {
+	if content&FaultRegsExist != 0 {
+		return infoRegsSize
+	}
+	return infoSize
+}
+
+// Decode decodes and validates the raw content of ARM_FaultInfo_t,
+// e.g. saved by the application to a file.
+//
+// Parameters:
+//   - data: The content of ARM_FaultInfo_t, further data is ignored.
+//
+// Returns:
+//   - The decoded fault information.
+//   - An error if the magic number, the CRC or the version is invalid.
+func Decode(data []byte) (*Info, error) {
+	if len(data) < infoSize {
+		return nil, errSize
+	}
+	le := binary.LittleEndian
+	if le.Uint32(data[0:]) != magicNumber {
+		return nil, errMagic
+	}
+	info := Info{
+		Count:        le.Uint32(data[8:]),
+		VersionMinor: data[12],
+		VersionMajor: data[13],
+		Content:      le.Uint16(data[14:]),
+	}
+	if info.VersionMajor != versionMajor {
+		return nil, fmt.Errorf("%w %d.%d", errVersion, info.VersionMajor, info.VersionMinor)
+	}
+	sz := size(info.Content)
+	if len(data) < sz {
+		return nil, errSize
+	}
+	if le.Uint32(data[4:]) != crc32(data[8:sz]) {
+		return nil, errCRC
+	}
+	for i := range info.Registers {
+		info.Registers[i] = le.Uint32(data[16+4*i:])
+	}
+	info.ExcXPSR = le.Uint32(data[96:])
+	info.ExcReturn = le.Uint32(data[100:])
+	if sz == infoRegsSize {
+		for i := range info.FaultRegisters {
+			info.FaultRegisters[i] = le.Uint32(data[infoSize+4*i:])
+		}
+	}
+	return &info, nil
+}
+
+// Read reads and validates the fault information from target memory.
+// It is located via the ARM_FaultInfo symbol of the application file,
+// the component version via the ARM_FaultVersion symbol.
+//
+// Parameters:
+//   - mem: The target memory, e.g. a memory image combined with the application file.
+//
+// Returns:
+//   - The decoded fault information.
+//   - An error if the fault information cannot be read or is invalid.
+func Read(mem memory.Reader) (*Info, error) {
+	addr, _, ok := elf.Symbols.GetAddrSize(infoSymbol)
+	if !ok {
+		return nil, errSymbol
+	}
+	data, ok := mem.Read(addr, infoSize)
+	if !ok {
+		return nil, errRead
+	}
+	if sz := size(binary.LittleEndian.Uint16(data[14:])); sz > infoSize {
+		if data, ok = mem.Read(addr, uint64(sz)); !ok {
+			return nil, errRead
+		}
+	}
+	info, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if addr, sz, ok := elf.Symbols.GetAddrSize(versionSymbol); ok {
+		if sz == 0 || sz > versionSize {
+			sz = versionSize
+		}
+		if data, ok = mem.Read(addr, sz); ok {
+			info.Version, _, _ = strings.Cut(string(data), "\x00")
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fault
+
+import (
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/elf"
+	"reflect"
+	"testing"
+)
+
+type testMemory map[uint64][]byte
+
+func (m testMemory) Read(addr uint64, size uint64) ([]byte, bool) {
+	for a, d := range m {
+		if addr >= a && addr+size <= a+uint64(len(d)) {
+			return d[addr-a : addr-a+size], true
+		}
+	}
+	return nil, false
+}
+
+// faultInfo returns the content of ARM_FaultInfo_t with a valid CRC.
+func faultInfo(content uint16, regs map[int]uint32, faultRegs map[int]uint32) []byte {
+	data := make([]byte, size(content))
+	le := binary.LittleEndian
+	le.PutUint32(data[0:], magicNumber)
+	le.PutUint32(data[8:], 2) // Count
+	data[12], data[13] = 1, versionMajor
+	le.PutUint16(data[14:], content)
+	for i, v := range regs {
+		le.PutUint32(data[16+4*i:], v)
+	}
+	le.PutUint32(data[96:], 0x01000003)  // EXC xPSR: HardFault
+	le.PutUint32(data[100:], 0xFFFFFFFD) // EXC_RETURN: Thread mode
+	for i, v := range faultRegs {
+		le.PutUint32(data[infoSize+4*i:], v)
+	}
+	le.PutUint32(data[4:], crc32(data[8:]))
+	return data
+}
+
+func Test_crc32(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		want uint32
+	}{
+		{"empty", nil, 0xFFFFFFFF},
+		{"check", []byte("123456789"), 0x0376E6E7},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := crc32(tt.data); got != tt.want {
+				t.Errorf("crc32() = %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	regs := map[int]uint32{R0: 1, ReturnAddress: 0x08000124, PSPLIM: 0x20000000}
+	faultRegs := map[int]uint32{CFSR: 0x8200, BFAR: 0x40000000, RFSR: 7}
+	info := faultInfo(StateContext|FaultRegsExist, regs, faultRegs)
+	short := faultInfo(StateContext, regs, nil)
+	badMagic := faultInfo(0, nil, nil)
+	badMagic[0] = 0
+	badCRC := faultInfo(0, nil, nil)
+	badCRC[8]++
+	badVersion := faultInfo(0, nil, nil)
+	badVersion[13] = 2
+
+	want := Info{Count: 2, VersionMajor: 1, VersionMinor: 1, Content: StateContext | FaultRegsExist, ExcXPSR: 0x01000003, ExcReturn: 0xFFFFFFFD}
+	want.Registers[R0], want.Registers[ReturnAddress], want.Registers[PSPLIM] = 1, 0x08000124, 0x20000000
+	want.FaultRegisters[CFSR], want.FaultRegisters[BFAR], want.FaultRegisters[RFSR] = 0x8200, 0x40000000, 7
+	wantShort := Info{Count: 2, VersionMajor: 1, VersionMinor: 1, Content: StateContext, ExcXPSR: 0x01000003, ExcReturn: 0xFFFFFFFD}
+	wantShort.Registers = want.Registers
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *Info
+		wantErr error
+	}{
+		{"fault registers", info, &want, nil},
+		{"no fault registers", short, &wantShort, nil},
+		{"trailing data", append(short, 1, 2, 3), &wantShort, nil},
+		{"too short", info[:infoSize-1], nil, errSize},
+		{"fault registers missing", info[:infoSize], nil, errSize},
+		{"magic", badMagic, nil, errMagic},
+		{"crc", badCRC, nil, errCRC},
+		{"version", badVersion, nil, errVersion},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Decode(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() %s = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) { //nolint:golint,paralleltest
+	info := faultInfo(FaultRegsExist, nil, nil)
+	version := []byte("1.0.0\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")
+
+	tests := []struct {
+		name    string
+		symbols map[string]uint64
+		mem     testMemory
+		want    string
+		wantErr error
+	}{
+		{"version", map[string]uint64{"ARM_FaultInfo": 0x20000000, "ARM_FaultVersion": 0x08000000},
+			testMemory{0x20000000: info, 0x08000000: version}, "1.0.0", nil},
+		{"no version", map[string]uint64{"ARM_FaultInfo": 0x20000000},
+			testMemory{0x20000000: info}, "", nil},
+		{"version not readable", map[string]uint64{"ARM_FaultInfo": 0x20000000, "ARM_FaultVersion": 0x08000000},
+			testMemory{0x20000000: info}, "", nil},
+		{"no symbol", map[string]uint64{"ARM_FaultVersion": 0x08000000},
+			testMemory{0x20000000: info}, "", errSymbol},
+		{"not readable", map[string]uint64{"ARM_FaultInfo": 0x20000000},
+			testMemory{0x20000000: info[:infoSize-1]}, "", errRead},
+		{"fault registers not readable", map[string]uint64{"ARM_FaultInfo": 0x20000000},
+			testMemory{0x20000000: info[:infoSize]}, "", errRead},
+		{"invalid", map[string]uint64{"ARM_FaultInfo": 0x20000000},
+			testMemory{0x20000000: make([]byte, infoRegsSize)}, "", errMagic},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			elf.Symbols.Init("", 0, 0)
+			for name, addr := range tt.symbols {
+				elf.Symbols.Add(name, addr, 0)
+			}
+			got, err := Read(tt.mem)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Read() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err == nil && (got.Version != tt.want || got.Count != 2) {
+				t.Errorf("Read() %s = %+v, want version %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfo_Report(t *testing.T) { //nolint:golint,paralleltest
+	elf.Symbols.Init("", 0, 0)
+
+	regs := map[int]uint32{R0: 1, ReturnAddress: 0x08000124, XPSR: 0x21000000, MSPLIM: 0x20000100}
+	faultRegs := map[int]uint32{CFSR: 0x2008200, HFSR: 1 << 30, BFAR: 0x40000000, SFSR: 1 << 3, RFSR: 7}
+
+	data := faultInfo(FaultRegsExist, nil, nil)
+	info, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	got := info.Report()
+	want := &Report{
+		Count:     2,
+		Exception: "HardFault",
+		Mode:      "Thread",
+		Faults:    []string{},
+		PC:        notStacked,
+		Registers: []Register{
+			{"R0", notStacked}, {"R1", notStacked}, {"R2", notStacked}, {"R3", notStacked},
+			{"R4", "0x00000000"}, {"R5", "0x00000000"}, {"R6", "0x00000000"}, {"R7", "0x00000000"},
+			{"R8", "0x00000000"}, {"R9", "0x00000000"}, {"R10", "0x00000000"}, {"R11", "0x00000000"},
+			{"R12", notStacked}, {"LR", notStacked}, {"Return Address", notStacked}, {"xPSR", notStacked},
+			{"MSP", "0x00000000"}, {"PSP", "0x00000000"},
+		},
+		ExceptionState: []Register{{"xPSR", "0x01000003"}, {"Exception Return", "0xFFFFFFFD"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Report() minimal = %+v, want %+v", got, want)
+	}
+
+	data = faultInfo(FaultRegsExist|TZEnabled|TZSaveMode|StateContext|LimitRegs|FaultRegs|SecureFaultRegs|RASFaultReg, regs, faultRegs)
+	binary.LittleEndian.PutUint32(data[96:], 0x01000005)  // EXC xPSR: BusFault
+	binary.LittleEndian.PutUint32(data[100:], 0xFFFFFFF1) // EXC_RETURN: Handler mode
+	binary.LittleEndian.PutUint32(data[4:], crc32(data[8:]))
+	if info, err = Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	elf.Symbols.AddFunction("main", 0x08000100, 0x100)
+	got = info.Report()
+	if got.Exception != "BusFault" || got.SaveMode != "Secure" || got.FaultMode != "Non-Secure" || got.Mode != "Handler" {
+		t.Errorf("Report() exception = %+v", got)
+	}
+	if got.PC != "0x08000124" || got.Symbol != "main+0x24" {
+		t.Errorf("Report() PC = %s (%s)", got.PC, got.Symbol)
+	}
+	faults := []string{
+		"HardFault - Escalated fault (original fault was disabled or it caused another lower priority fault)",
+		"BusFault - Data access failure due to bus fault (precise), fault address 0x40000000",
+		"UsageFault - Divide by 0",
+		"SecureFault - Attribution unit violation due to Non-secure access to Secure address space",
+	}
+	if !reflect.DeepEqual(got.Faults, faults) {
+		t.Errorf("Report() faults = %q, want %q", got.Faults, faults)
+	}
+	if len(got.Registers) != 20 || got.Registers[R0].Value != "0x00000001" || got.Registers[MSPLIM] != (Register{"MSPLIM", "0x20000100"}) {
+		t.Errorf("Report() registers = %+v", got.Registers)
+	}
+	if len(got.FaultRegisters) != 9 || got.FaultRegisters[RFSR] != (Register{"RFSR", "0x00000007"}) {
+		t.Errorf("Report() fault registers = %+v", got.FaultRegisters)
+	}
+
+	info.Content = FaultRegs
+	info.ExcXPSR = 0x10
+	info.Registers[ReturnAddress] = 0x08000100
+	got = info.Report()
+	if got.Exception != "unknown, exception number = 16" || got.SaveMode != "" || got.FaultMode != "" {
+		t.Errorf("Report() unknown exception = %+v", got)
+	}
+	if len(got.FaultRegisters) != 6 {
+		t.Errorf("Report() fault registers = %+v", got.FaultRegisters)
+	}
+	info.Content = StateContext
+	if got = info.Report(); got.Symbol != "main" || len(got.Faults) != 0 || got.FaultRegisters != nil {
+		t.Errorf("Report() symbol = %+v", got)
+	}
+
+	modes := []struct {
+		excReturn uint32
+		want      string
+	}{
+		{0xFFFFFFF9, "Thread"},  // main stack, e.g. bare metal
+		{0xFFFFFFFD, "Thread"},  // process stack
+		{0xFFFFFFF1, "Handler"}, // main stack
+		{0xFFFFFFE1, "Handler"}, // main stack, extended frame
+	}
+	for _, m := range modes {
+		info.ExcReturn = m.excReturn
+		if got = info.Report(); got.Mode != m.want {
+			t.Errorf("Report() EXC_RETURN 0x%08X mode = %s, want %s", m.excReturn, got.Mode, m.want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fault
+
+import (
+	"eventlist/pkg/elf"
+	"fmt"
+)
+
+const notStacked = "unknown (was not stacked)"
+
+// excReturnMode is the mode bit of EXC_RETURN, set if the fault happened in
+// Thread mode. Bit 2 (SPSEL) only selects the stack pointer.
+const excReturnMode = 1 << 3
+
+var exceptions = map[uint32]string{
+	3: "HardFault",
+	4: "MemManage fault",
+	5: "BusFault",
+	6: "UsageFault",
+	7: "SecureFault",
+}
+
+var registerNames = []string{
+	"R0", "R1", "R2", "R3", "R4", "R5", "R6", "R7", "R8", "R9", "R10", "R11", "R12",
+	"LR", "Return Address", "xPSR", "MSP", "PSP", "MSPLIM", "PSPLIM",
+}
+
+var faultRegisterNames = []string{"CFSR", "HFSR", "DFSR", "MMFAR", "BFAR", "AFSR", "SFSR", "SFAR", "RFSR"}
+
+// faultBit explains one bit of a fault status register.
+type faultBit struct {
+	mask uint32
+	text string
+}
+
+// faultGroup explains the bits of a fault status register. If the
+// register holds a valid fault address, it is added to the explanation.
+type faultGroup struct {
+	name      string
+	reg       int // index in Info.FaultRegisters
+	bits      []faultBit
+	valid     uint32 // address valid bit
+	addrReg   int    // index of the fault address register
+	condition uint16 // content bit required for the register
+}
+
+var faultGroups = []faultGroup{
+	{"HardFault", HFSR, []faultBit{
+		{1 << 1, "Bus error on vector read"},
+		{1 << 30, "Escalated fault (original fault was disabled or it caused another lower priority fault)"},
+		{1 << 31, "Breakpoint hit with Debug Monitor disabled"},
+	}, 0, 0, FaultRegs},
+	{"MemManage", CFSR, []faultBit{
+		{1 << 0, "Instruction execution failure due to MPU violation or fault"},
+		{1 << 1, "Data access failure due to MPU violation or fault"},
+		{1 << 3, "Exception exit unstacking failure due to MPU access violation"},
+		{1 << 4, "Exception entry stacking failure due to MPU access violation"},
+		{1 << 5, "Floating-point lazy stacking failure due to MPU access violation"},
+	}, 1 << 7, MMFAR, FaultRegs},
+	{"BusFault", CFSR, []faultBit{
+		{1 << 8, "Instruction prefetch failure due to bus fault"},
+		{1 << 9, "Data access failure due to bus fault (precise)"},
+		{1 << 10, "Data access failure due to bus fault (imprecise)"},
+		{1 << 11, "Exception exit unstacking failure due to bus fault"},
+		{1 << 12, "Exception entry stacking failure due to bus fault"},
+		{1 << 13, "Floating-point lazy stacking failure due to bus fault"},
+	}, 1 << 15, BFAR, FaultRegs},
+	{"UsageFault", CFSR, []faultBit{
+		{1 << 16, "Execution of undefined instruction"},
+		{1 << 17, "Execution of Thumb instruction with Thumb mode turned off"},
+		{1 << 18, "Invalid exception return value"},
+		{1 << 19, "Coprocessor instruction with coprocessor disabled or non-existent"},
+		{1 << 20, "Stack overflow"},
+		{1 << 24, "Unaligned load/store"},
+		{1 << 25, "Divide by 0"},
+	}, 0, 0, FaultRegs},
+	{"SecureFault", SFSR, []faultBit{
+		{1 << 0, "Invalid entry point due to invalid attempt to enter Secure state"},
+		{1 << 1, "Invalid integrity signature in exception stack frame found on unstacking"},
+		{1 << 2, "Invalid exception return due to mismatch on EXC_RETURN.DCRS or EXC_RETURN.ES"},
+		{1 << 3, "Attribution unit violation due to Non-secure access to Secure address space"},
+		{1 << 4, "Invalid transaction caused by domain crossing branch not flagged as such"},
+		{1 << 5, "Lazy stacking preservation failure due to SAU or IDAU violation"},
+		{1 << 7, "Lazy stacking activation or deactivation failure"},
+	}, 1 << 6, SFAR, SecureFaultRegs},
+}
+
+// Register is the name and the value of a register.
+type Register struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value" xml:",chardata"`
+}
+
+// Report is the decoded fault information as shown by ARM_FaultPrint.
+type Report struct {
+	Version        string     `json:"version,omitempty" xml:"version,omitempty"`
+	Count          uint32     `json:"count" xml:"count"`
+	Exception      string     `json:"exception" xml:"exception"`
+	SaveMode       string     `json:"saveMode,omitempty" xml:"saveMode,omitempty"`
+	FaultMode      string     `json:"faultMode,omitempty" xml:"faultMode,omitempty"`
+	Mode           string     `json:"mode" xml:"mode"`
+	Faults         []string   `json:"faults" xml:"fault"`
+	PC             string     `json:"pc" xml:"pc"`
+	Symbol         string     `json:"symbol,omitempty" xml:"symbol,omitempty"`
+	Registers      []Register `json:"registers" xml:"registers>register"`
+	ExceptionState []Register `json:"exceptionState" xml:"exceptionState>register"`
+	FaultRegisters []Register `json:"faultRegisters,omitempty" xml:"faultRegisters>register"`
+}
+
+// hex formats a register value.
+func hex(v uint32) string {
+	return fmt.Sprintf("0x%08X", v)
+}
+
+// trustZoneMode returns the name of the TrustZone mode given by a content bit.
+func (info *Info) trustZoneMode(bit uint16) string {
+	if info.Content&bit != 0 {
+		return "Secure"
+	}
+	return "Non-Secure"
+}
+
+// faults explains the bits set in the fault status registers.
+func (info *Info) faults() []string {
+	faults := []string{}
+	for _, g := range faultGroups {
+		if info.Content&g.condition == 0 {
+			continue
+		}
+		reg := info.FaultRegisters[g.reg]
+		n := len(faults)
+		for _, bit := range g.bits {
+			if reg&bit.mask != 0 {
+				faults = append(faults, g.name+" - "+bit.text)
+			}
+		}
+		if len(faults) > n && g.valid != 0 && reg&g.valid != 0 {
+			faults[len(faults)-1] += ", fault address " + hex(info.FaultRegisters[g.addrReg])
+		}
+	}
+	return faults
+}
+
+// Report returns the decoded fault information. The function containing
+// the faulting program counter is taken from the application file, if loaded.
+func (info *Info) Report() *Report {
+	r := Report{
+		Version: info.Version,
+		Count:   info.Count,
+		Mode:    "Handler",
+		Faults:  info.faults(),
+		PC:      notStacked,
+	}
+
+	exc := info.ExcXPSR & 0x1FF
+	var ok bool
+	if r.Exception, ok = exceptions[exc]; !ok {
+		r.Exception = fmt.Sprintf("unknown, exception number = %d", exc)
+	}
+	if info.Content&TZEnabled != 0 {
+		r.SaveMode = info.trustZoneMode(TZSaveMode)
+		r.FaultMode = info.trustZoneMode(TZFaultMode)
+	}
+	if info.ExcReturn&excReturnMode != 0 {
+		r.Mode = "Thread"
+	}
+
+	stacked := info.Content&StateContext != 0
+	if stacked {
+		pc := info.Registers[ReturnAddress]
+		r.PC = hex(pc)
+		if name, offset, ok := elf.Symbols.FindFunction(uint64(pc)); ok {
+			r.Symbol = name
+			if offset != 0 {
+				r.Symbol += fmt.Sprintf("+0x%X", offset)
+			}
+		}
+	}
+	for i, v := range info.Registers {
+		switch i {
+		case R0, R1, R2, R3, R12, LR, ReturnAddress, XPSR:
+			if !stacked {
+				r.Registers = append(r.Registers, Register{registerNames[i], notStacked})
+				continue
+			}
+		case MSPLIM, PSPLIM:
+			if info.Content&LimitRegs == 0 {
+				continue
+			}
+		}
+		r.Registers = append(r.Registers, Register{registerNames[i], hex(v)})
+	}
+	r.ExceptionState = []Register{{"xPSR", hex(info.ExcXPSR)}, {"Exception Return", hex(info.ExcReturn)}}
+	if info.Content&FaultRegs != 0 {
+		for i, v := range info.FaultRegisters {
+			switch i {
+			case SFSR, SFAR:
+				if info.Content&SecureFaultRegs == 0 {
+					continue
+				}
+			case RFSR:
+				if info.Content&RASFaultReg == 0 {
+					continue
+				}
+			}
+			r.FaultRegisters = append(r.FaultRegisters, Register{faultRegisterNames[i], hex(v)})
+		}
+	}
+	return &r
+}
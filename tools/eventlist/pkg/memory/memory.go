@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var errFormat = errors.New("invalid memory image format")
+
+var errChecksum = errors.New("checksum error in memory image")
+
+var errAddress = errors.New("missing base address for binary memory image, use file@address")
+
+// Reader gives access to target memory.
+type Reader interface {
+	// Read returns size bytes of target memory starting at addr.
+	// The boolean result is false if the memory is not available.
+	Read(addr uint64, size uint64) ([]byte, bool)
+}
+
+// Readers combines several memory readers, the first reader
+// containing the requested memory is used.
+type Readers []Reader
+
+// Read returns size bytes starting at addr from the first reader
+// containing the complete memory range.
+func (rs Readers) Read(addr uint64, size uint64) ([]byte, bool) {
+	for _, r := range rs {
+		if r == nil {
+			continue
+		}
+		if data, ok := r.Read(addr, size); ok {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+type segment struct {
+	addr uint64
+	data []byte
+}
+
+// Image is a memory image consisting of one or more contiguous segments.
+type Image struct {
+	segments []segment
+}
+
+// Read returns size bytes starting at addr if the range is completely
+// contained in one segment of the image.
+func (img *Image) Read(addr uint64, size uint64) ([]byte, bool) {
+	if img == nil {
+		return nil, false
+	}
+	for _, seg := range img.segments {
+		if addr >= seg.addr && addr+size <= seg.addr+uint64(len(seg.data)) && addr+size >= addr {
+			return seg.data[addr-seg.addr : addr-seg.addr+size], true
+		}
+	}
+	return nil, false
+}
+
+// add stores data at addr. Data directly following an existing
+// segment is appended to it, otherwise a new segment is created.
+func (img *Image) add(addr uint64, data []byte) {
+	for i := range img.segments {
+		seg := &img.segments[i]
+		if seg.addr+uint64(len(seg.data)) == addr {
+			seg.data = append(seg.data, data...)
+			return
+		}
+	}
+	img.segments = append(img.segments, segment{addr, append([]byte(nil), data...)})
+}
+
+// sortSegments sorts the segments by address and merges adjacent ones.
+func (img *Image) sortSegments() {
+	sort.Slice(img.segments, func(i, j int) bool {
+		return img.segments[i].addr < img.segments[j].addr
+	})
+	var merged []segment
+	for _, seg := range img.segments {
+		if n := len(merged); n > 0 && merged[n-1].addr+uint64(len(merged[n-1].data)) == seg.addr {
+			merged[n-1].data = append(merged[n-1].data, seg.data...)
+			continue
+		}
+		merged = append(merged, seg)
+	}
+	img.segments = merged
+}
+
+// Load reads a memory image from a file. The format is detected from the content:
+//   - Intel HEX: lines starting with ':'
+//   - Motorola SREC: lines starting with 'S' followed by the record type
+//   - binary: any other content, the load address must be appended to the
+//     file name as file@address, e.g. ram.bin@0x20000000
+//
+// Parameters:
+//   - name: The name of the memory image file, optionally followed by @address.
+//
+// Returns:
+//   - The memory image.
+//   - An error if the file cannot be read or has an invalid format.
+func Load(name string) (*Image, error) {
+	var base uint64
+	var hasBase bool
+	if i := strings.LastIndexByte(name, '@'); i > 0 {
+		addr, err := strconv.ParseUint(name[i+1:], 0, 64)
+		if err == nil {
+			base = addr
+			hasBase = true
+			name = name[:i]
+		}
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	img := new(Image)
+	text := bytes.TrimLeft(data, " \t\r\n")
+	switch {
+	case !hasBase && len(text) > 0 && text[0] == ':':
+		err = img.parseIHex(text)
+	case !hasBase && len(text) > 1 && text[0] == 'S' && text[1] >= '0' && text[1] <= '9':
+		err = img.parseSRec(text)
+	case hasBase:
+		img.add(base, data)
+	default:
+		err = errAddress
+	}
+	if err != nil {
+		return nil, err
+	}
+	img.sortSegments()
+	return img, nil
+}
+
+// decodeLine decodes the hexadecimal digits of one record and verifies
+// that the byte sum including the checksum matches want.
+func decodeLine(line string, want byte) ([]byte, error) {
+	rec, err := hex.DecodeString(line)
+	if err != nil || len(rec) < 1 {
+		return nil, errFormat
+	}
+	var sum byte
+	for _, b := range rec {
+		sum += b
+	}
+	if sum != want {
+		return nil, errChecksum
+	}
+	return rec, nil
+}
+
+// parseIHex parses Intel HEX records (data, end of file,
+// extended segment address and extended linear address).
+func (img *Image) parseIHex(text []byte) error {
+	var upper uint64
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		if line[0] != ':' {
+			return errFormat
+		}
+		rec, err := decodeLine(line[1:], 0)
+		if err != nil {
+			return err
+		}
+		if len(rec) < 5 || int(rec[0]) != len(rec)-5 {
+			return errFormat
+		}
+		addr := uint64(rec[1])<<8 | uint64(rec[2])
+		data := rec[4 : len(rec)-1]
+		switch rec[3] {
+		case 0x00: // data
+			img.add(upper+addr, data)
+		case 0x01: // end of file
+			return nil
+		case 0x02: // extended segment address
+			if len(data) != 2 {
+				return errFormat
+			}
+			upper = (uint64(data[0])<<8 | uint64(data[1])) << 4
+		case 0x04: // extended linear address
+			if len(data) != 2 {
+				return errFormat
+			}
+			upper = (uint64(data[0])<<8 | uint64(data[1])) << 16
+		case 0x03, 0x05: // start address
+		default:
+			return errFormat
+		}
+	}
+	return scanner.Err()
+}
+
+// parseSRec parses Motorola S-records (S1, S2 and S3 data records,
+// header, count and termination records are skipped).
+func (img *Image) parseSRec(text []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		if len(line) < 4 || line[0] != 'S' {
+			return errFormat
+		}
+		rec, err := decodeLine(line[2:], 0xFF)
+		if err != nil {
+			return err
+		}
+		if int(rec[0]) != len(rec)-1 {
+			return errFormat
+		}
+		var addrLen int
+		switch line[1] {
+		case '1':
+			addrLen = 2
+		case '2':
+			addrLen = 3
+		case '3':
+			addrLen = 4
+		case '0', '5', '6', '7', '8', '9':
+			continue
+		default:
+			return errFormat
+		}
+		if len(rec) < 2+addrLen {
+			return errFormat
+		}
+		var addr uint64
+		for _, b := range rec[1 : 1+addrLen] {
+			addr = addr<<8 | uint64(b)
+		}
+		img.add(addr, rec[1+addrLen:len(rec)-1])
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	ihex := write("test.hex",
+		":020000042000DA\n"+
+			":0400000001020304F2\n"+
+			":020004000506EF\n"+
+			":00000001FF\n")
+	srec := write("test.srec",
+		"S00600004844521B\n"+
+			"S3092000000001020304CC\n"+
+			"S307200000040506C9\n"+
+			"S70500000000FA\n")
+	bin := write("test.bin", "\x01\x02\x03\x04\x05\x06")
+	badSum := write("bad.hex", ":0400000001020304F3\n")
+	badRec := write("bad.srec", "S305200000040506CB\n")
+
+	want := &Image{[]segment{{0x20000000, []byte{1, 2, 3, 4, 5, 6}}}}
+
+	tests := []struct {
+		name    string
+		file    string
+		want    *Image
+		wantErr error
+	}{
+		{"ihex", ihex, want, nil},
+		{"srec", srec, want, nil},
+		{"bin", bin + "@0x20000000", want, nil},
+		{"bin no address", bin, nil, errAddress},
+		{"checksum", badSum, nil, errChecksum},
+		{"format", badRec, nil, errFormat},
+		{"nix", filepath.Join(dir, "nix.bin@0"), nil, os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Load(tt.file)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReaders_Read(t *testing.T) {
+	t.Parallel()
+
+	img1 := &Image{[]segment{{0x100, []byte{1, 2, 3, 4}}}}
+	img2 := &Image{[]segment{{0x100, []byte{5, 6, 7, 8, 9, 10}}, {0x200, []byte{11}}}}
+
+	type args struct {
+		addr uint64
+		size uint64
+	}
+	tests := []struct {
+		name   string
+		rs     Readers
+		args   args
+		want   []byte
+		wantOk bool
+	}{
+		{"first", Readers{img1, img2}, args{0x101, 2}, []byte{2, 3}, true},
+		{"second", Readers{img1, img2}, args{0x102, 4}, []byte{7, 8, 9, 10}, true},
+		{"segment", Readers{nil, img1, img2}, args{0x200, 1}, []byte{11}, true},
+		{"missing", Readers{img1, img2}, args{0x1FF, 2}, nil, false},
+		{"empty", Readers{}, args{0x100, 1}, nil, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.rs.Read(tt.args.addr, tt.args.size)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Readers.Read() %s = %v,%v, want %v,%v", tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
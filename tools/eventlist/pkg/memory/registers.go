@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var errRegister = errors.New("invalid register file format")
+
+// Registers holds the values of the core registers, e.g. captured by a
+// debugger together with a memory image. The names are not case sensitive.
+type Registers map[string]uint64
+
+// Register returns the value of a core register.
+//
+// Parameters:
+//   - name: The register name, e.g. R0 or PSP.
+//
+// Returns:
+//   - The value, and true if the register is known.
+func (r Registers) Register(name string) (uint64, bool) {
+	v, ok := r[strings.ToUpper(name)]
+	return v, ok
+}
+
+// LoadRegisters loads a register file. Each line contains a register name
+// and its value, separated by blanks, a colon or an equal sign:
+//
+//	R0  = 0x20000100
+//	PSP: 0x20001F80
+//
+// Empty lines and text following # or ; are ignored.
+//
+// Parameters:
+//   - name: The name of the register file.
+//
+// Returns:
+//   - The register values.
+//   - An error if the file cannot be read or has an invalid format.
+func LoadRegisters(name string) (Registers, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	regs := make(Registers)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ':' || r == '='
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, errRegister
+		}
+		v, err := strconv.ParseUint(fields[1], 0, 64)
+		if err != nil {
+			return nil, errRegister
+		}
+		regs[strings.ToUpper(fields[0])] = v
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return regs, nil
+}
+
+// Target combines the target memory and the core registers,
+// e.g. for the intrinsic functions of SCVD expressions.
+type Target struct {
+	Reader
+	Registers
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadRegisters(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	regs := write("regs.txt", "# captured registers\n"+
+		"R0  = 0x20000100\n"+
+		"\n"+
+		"psp: 0x20001F80 ; process stack\n"+
+		"xPSR\t16777216\n")
+
+	tests := []struct {
+		name    string
+		file    string
+		want    Registers
+		wantErr error
+	}{
+		{"ok", regs, Registers{"R0": 0x20000100, "PSP": 0x20001F80, "XPSR": 0x01000000}, nil},
+		{"empty", write("empty.txt", ""), Registers{}, nil},
+		{"value", write("value.txt", "R0 = x\n"), nil, errRegister},
+		{"fields", write("fields.txt", "R0 1 2\n"), nil, errRegister},
+		{"nix", filepath.Join(dir, "nix.txt"), nil, os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadRegisters(tt.file)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LoadRegisters() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadRegisters() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTarget(t *testing.T) {
+	t.Parallel()
+
+	img := &Image{[]segment{{0x20000000, []byte{1, 2, 3, 4}}}}
+	target := Target{img, Registers{"PSP": 0x20001000}}
+	if data, ok := target.Read(0x20000001, 2); !ok || !reflect.DeepEqual(data, []byte{2, 3}) {
+		t.Errorf("Target.Read() = %v, %t", data, ok)
+	}
+	if v, ok := target.Register("psp"); !ok || v != 0x20001000 {
+		t.Errorf("Target.Register() = %#x, %t", v, ok)
+	}
+	if _, ok := target.Register("MSP"); ok {
+		t.Errorf("Target.Register() MSP found")
+	}
+}
@@ -0,0 +1,563 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package object executes the <objects> definitions of SCVD files against
+// the application file and target memory and renders their <out> elements.
+package object
+
+import (
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/elf"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"eventlist/pkg/memory"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errSymbol = errors.New("symbol not found")
+
+var errRead = errors.New("cannot read target memory")
+
+var errType = errors.New("unknown type")
+
+var errLoop = errors.New("list without limit or too many iterations")
+
+var errFormat = errors.New("invalid format expression")
+
+// maxIterations limits the iterations of a <list> and the elements of a <readlist>.
+const maxIterations = 65536
+
+// errorValue replaces a value which cannot be evaluated.
+const errorValue = "<error>"
+
+// pointerSize is the size of a target address in bytes.
+const pointerSize = 4
+
+// Item is one line of a component view with its sub-items.
+type Item struct {
+	Property string `json:"property" xml:"property"`
+	Value    string `json:"value" xml:"value"`
+	Alert    bool   `json:"alert,omitempty" xml:"alert,omitempty"`
+	Bold     bool   `json:"bold,omitempty" xml:"bold,omitempty"`
+	Items    []Item `json:"items,omitempty" xml:"item"`
+}
+
+// View is the rendered content of an <out> element.
+type View struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Items []Item `json:"items" xml:"item"`
+}
+
+type evaluator struct {
+	typedefs eval.Typedefs
+	tdUsed   map[string]string // typedefs of integer variables
+	mem      memory.Reader
+	views    []View
+}
+
+// Evaluate executes the object definitions in document order and renders
+// their <out> elements. Variables are global for all objects, so an object
+// can use the results of a previous one.
+//
+// Parameters:
+//   - objects: The object definitions of the SCVD files.
+//   - typedefs: The typedefs of the SCVD files.
+//   - mem: The target memory, e.g. a RAM dump combined with the application file.
+//
+// Returns:
+//   - The component views in the order of the <out> elements.
+//   - An error if an object cannot be evaluated.
+func Evaluate(objects scvd.Objects, typedefs eval.Typedefs, mem memory.Reader) ([]View, error) {
+	e := evaluator{typedefs: typedefs, tdUsed: make(map[string]string), mem: mem}
+	eval.ClearNames()
+	for _, obj := range objects {
+		if err := e.statements(obj.Statements); err != nil {
+			return e.views, fmt.Errorf("object %s: %w", obj.Name, err)
+		}
+	}
+	return e.views, nil
+}
+
+// eval evaluates an expression, the end of the expression is not an error.
+func (e *evaluator) eval(expr string) (eval.Value, error) {
+	v, err := eval.Eval(&expr, e.typedefs, e.tdUsed)
+	if errors.Is(err, eval.ErrEof) {
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("%q: %w", strings.TrimSpace(expr), err)
+	}
+	return v, err
+}
+
+// cond evaluates a condition, an empty condition is true. Like in a
+// debugger, a condition which cannot be evaluated is false.
+func (e *evaluator) cond(expr string) bool {
+	if strings.TrimSpace(expr) == "" {
+		return true
+	}
+	v, err := e.eval(expr)
+	if err != nil {
+		return false
+	}
+	if v.IsFloating() {
+		return v.GetFloat() != 0
+	}
+	return v.GetInt() != 0
+}
+
+// flag evaluates an alert or bold attribute, an empty attribute is false.
+func (e *evaluator) flag(expr string) bool {
+	return strings.TrimSpace(expr) != "" && e.cond(expr)
+}
+
+// integer evaluates an expression resulting in an integer, an empty expression is def.
+func (e *evaluator) integer(expr string, def int64) (int64, error) {
+	if strings.TrimSpace(expr) == "" {
+		return def, nil
+	}
+	v, err := e.eval(expr)
+	return v.GetInt(), err
+}
+
+// statements executes the statements of an object or of a list.
+func (e *evaluator) statements(stmts []scvd.StatementType) error {
+	for _, stmt := range stmts {
+		var err error
+		switch {
+		case stmt.Var != nil:
+			err = e.variable(stmt.Var)
+		case stmt.Calc != nil:
+			err = e.calc(stmt.Calc)
+		case stmt.Read != nil:
+			err = e.read(stmt.Read)
+		case stmt.Addr != nil:
+			err = e.addr(stmt.Addr)
+		case stmt.Readlist != nil:
+			err = e.readlist(stmt.Readlist)
+		case stmt.List != nil:
+			err = e.list(stmt.List)
+		case stmt.Out != nil:
+			err = e.out(stmt.Out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// variable creates a variable with its initial value. The typedef
+// of a variable is used for member access.
+func (e *evaluator) variable(v *scvd.VarType) error {
+	val, err := e.integer(v.Value, 0)
+	if err != nil {
+		return err
+	}
+	if _, ok := e.typedefs[v.Type]; ok {
+		e.tdUsed[v.Name] = v.Type
+	}
+	eval.SetVarI(v.Name, val)
+	return nil
+}
+
+// calc executes the assignments of a <calc> element.
+func (e *evaluator) calc(c *scvd.CalcType) error {
+	if !e.cond(c.Cond) || strings.TrimSpace(c.Expr) == "" {
+		return nil
+	}
+	_, err := e.eval(c.Expr)
+	return err
+}
+
+// address returns the target address given by a symbol and an offset.
+func (e *evaluator) address(symbol string, offset string) (uint64, error) {
+	var addr uint64
+	if symbol != "" {
+		var ok bool
+		if addr, _, ok = elf.Symbols.GetAddrSize(symbol); !ok {
+			return 0, fmt.Errorf("%s: %w", symbol, errSymbol)
+		}
+	}
+	off, err := e.integer(offset, 0)
+	return addr + uint64(off), err
+}
+
+// readMemory reads size bytes of target memory.
+func (e *evaluator) readMemory(addr uint64, size uint64) ([]byte, error) {
+	if e.mem == nil {
+		return nil, fmt.Errorf("0x%08X: %w", addr, errRead)
+	}
+	data, ok := e.mem.Read(addr, size)
+	if !ok {
+		return nil, fmt.Errorf("0x%08X: %w", addr, errRead)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// read reads a scalar, a structure or an array of them from target memory.
+func (e *evaluator) read(r *scvd.ReadType) error {
+	if !e.cond(r.Cond) {
+		return nil
+	}
+	sz, ok := eval.SizeOf(r.Type, e.typedefs)
+	if !ok {
+		return fmt.Errorf("%s: %w", r.Type, errType)
+	}
+	addr, err := e.address(r.Symbol, r.Offset)
+	if err != nil {
+		return err
+	}
+	count, err := e.integer(r.Size, 1)
+	if err != nil {
+		return err
+	}
+	if count < 0 || count > maxIterations {
+		return fmt.Errorf("%s: %w", r.Name, errLoop)
+	}
+	data, err := e.readMemory(addr, uint64(count)*uint64(sz))
+	if err != nil {
+		return err
+	}
+	ty, scalar := eval.ITypes[r.Type]
+	switch {
+	case r.Size != "":
+		elements := make([]eval.Value, count)
+		for i := range elements {
+			elements[i] = eval.Block(data[uint32(i)*sz:uint32(i+1)*sz], addr+uint64(i)*uint64(sz), r.Type)
+		}
+		eval.SetVar(r.Name, eval.Array(elements))
+	case scalar:
+		v, err := eval.Scalar(data, ty, r.Endian == "B" || r.Endian == "b")
+		if err != nil {
+			return err
+		}
+		eval.SetVar(r.Name, v)
+	default:
+		eval.SetVar(r.Name, eval.Block(data, addr, r.Type))
+	}
+	return nil
+}
+
+// addr sets a variable to the target address given by a symbol and an offset.
+func (e *evaluator) addr(r *scvd.ReadType) error {
+	if !e.cond(r.Cond) {
+		return nil
+	}
+	addr, err := e.address(r.Symbol, r.Offset)
+	if err != nil {
+		return err
+	}
+	eval.SetVarI(r.Name, int64(addr))
+	return nil
+}
+
+// readlist reads an array or a linked list of structures. Without init
+// the elements are appended to the elements read before, e.g. in a loop.
+func (e *evaluator) readlist(r *scvd.ReadlistType) error {
+	if !e.cond(r.Cond) {
+		return nil
+	}
+	sz, ok := eval.SizeOf(r.Type, e.typedefs)
+	if !ok || sz == 0 {
+		return fmt.Errorf("%s: %w", r.Type, errType)
+	}
+	addr, err := e.address(r.Symbol, r.Offset)
+	if err != nil {
+		return err
+	}
+	if r.Based { // symbol and offset give the address of a pointer to the list
+		data, err := e.readMemory(addr, pointerSize)
+		if err != nil {
+			return err
+		}
+		addr = uint64(binary.LittleEndian.Uint32(data))
+	}
+	count, err := e.integer(r.Count, maxIterations)
+	if err != nil {
+		return err
+	}
+	if r.Next == "" && r.Count == "" {
+		count = 1
+	}
+	var nextOff int64
+	if r.Next != "" {
+		m, ok := e.typedefs[r.Type].Members[r.Next]
+		if !ok {
+			return fmt.Errorf("%s.%s: %w", r.Type, r.Next, errType)
+		}
+		if nextOff, err = e.integer(m.Offset, 0); err != nil {
+			return err
+		}
+	}
+
+	var elements []eval.Value
+	if !r.Init {
+		if v, err := e.eval(r.Name); err == nil && v.IsList() {
+			elements = v.GetList()
+		}
+	}
+	first := addr
+	for i := int64(0); i < count && addr != 0; i++ {
+		data, err := e.readMemory(addr, uint64(sz))
+		if err != nil {
+			return err
+		}
+		elements = append(elements, eval.Block(data, addr, r.Type))
+		if r.Next == "" {
+			addr += uint64(sz)
+			continue
+		}
+		if nextOff < 0 || nextOff+pointerSize > int64(sz) {
+			return fmt.Errorf("%s.%s: %w", r.Type, r.Next, errType)
+		}
+		addr = uint64(binary.LittleEndian.Uint32(data[nextOff:]))
+		if addr == first {
+			break // circular list
+		}
+	}
+	eval.SetVar(r.Name, eval.Array(elements))
+	return nil
+}
+
+// loop executes body for each value of the loop variable: from start
+// while the variable is below limit and the while condition is true.
+func (e *evaluator) loop(name, start, limit, while string, body func() error) error {
+	i, err := e.integer(start, 0)
+	if err != nil {
+		return err
+	}
+	if limit == "" && while == "" {
+		return fmt.Errorf("%s: %w", name, errLoop)
+	}
+	for n := 0; ; n++ {
+		if n == maxIterations {
+			return fmt.Errorf("%s: %w", name, errLoop)
+		}
+		eval.SetVarI(name, i)
+		if limit != "" {
+			lim, err := e.integer(limit, 0)
+			if err != nil {
+				return err
+			}
+			if i >= lim {
+				return nil
+			}
+		}
+		if !e.cond(while) {
+			return nil
+		}
+		if err = body(); err != nil {
+			return err
+		}
+		i++
+	}
+}
+
+// list executes the statements of a <list> for each value of its loop variable.
+func (e *evaluator) list(l *scvd.ObjectListType) error {
+	if !e.cond(l.Cond) {
+		return nil
+	}
+	return e.loop(l.Name, l.Start, l.Limit, l.While, func() error {
+		return e.statements(l.Statements)
+	})
+}
+
+// out renders an <out> element to a component view.
+func (e *evaluator) out(o *scvd.OutType) error {
+	if !e.cond(o.Cond) {
+		return nil
+	}
+	name, err := e.format(o.Name)
+	if err != nil {
+		return err
+	}
+	view := View{Name: name, Items: []Item{}}
+	if view.Items, err = e.elements(o.Elements, view.Items); err != nil {
+		return err
+	}
+	e.views = append(e.views, view)
+	return nil
+}
+
+// elements renders the items of an output element and appends them to items.
+func (e *evaluator) elements(elements []scvd.OutElementType, items []Item) ([]Item, error) {
+	var err error
+	for _, el := range elements {
+		switch {
+		case el.Item != nil:
+			items, err = e.item(el.Item, items)
+		case el.List != nil:
+			items, err = e.outList(el.List, items)
+		}
+		if err != nil {
+			return items, err
+		}
+	}
+	return items, nil
+}
+
+// item renders an <item>, the first <print> with a true condition
+// replaces property and value of the item.
+func (e *evaluator) item(it *scvd.ItemType, items []Item) ([]Item, error) {
+	if !e.cond(it.Cond) {
+		return items, nil
+	}
+	property, value, alert, bold := it.Property, it.Value, it.Alert, it.Bold
+	for _, p := range it.Prints {
+		if e.cond(p.Cond) {
+			property, value, alert, bold = p.Property, string(p.Value), p.Alert, p.Bold
+			break
+		}
+	}
+	item := Item{Alert: e.flag(alert), Bold: e.flag(bold)}
+	var err error
+	if item.Property, err = e.format(property); err != nil {
+		return items, err
+	}
+	if item.Value, err = e.format(value); err != nil {
+		return items, err
+	}
+	if item.Items, err = e.elements(it.Elements, nil); err != nil {
+		return items, err
+	}
+	return append(items, item), nil
+}
+
+// outList renders the elements of a <list> in an output for each value of
+// its loop variable, alert and bold of the list apply to its items.
+func (e *evaluator) outList(l *scvd.OutListType, items []Item) ([]Item, error) {
+	if !e.cond(l.Cond) {
+		return items, nil
+	}
+	err := e.loop(l.Name, l.Start, l.Limit, l.While, func() error {
+		n := len(items)
+		var err error
+		if items, err = e.elements(l.Elements, items); err != nil {
+			return err
+		}
+		alert, bold := e.flag(l.Alert), e.flag(l.Bold)
+		for i := n; i < len(items); i++ {
+			items[i].Alert = items[i].Alert || alert
+			items[i].Bold = items[i].Bold || bold
+		}
+		return nil
+	})
+	return items, err
+}
+
+// format replaces the format specifiers like %x[expression] of a property
+// or value by the formatted values of the expressions.
+func (e *evaluator) format(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '%' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		c = s[i]
+		if c == '%' {
+			sb.WriteByte(c)
+			continue
+		}
+		if i+1 >= len(s) || s[i+1] != '[' {
+			return "", fmt.Errorf("%q: %w", s, errFormat)
+		}
+		end := closingBracket(s, i+1)
+		if end < 0 {
+			return "", fmt.Errorf("%q: %w", s, errFormat)
+		}
+		expr := s[i+2 : end]
+		i = end
+		val, err := e.eval(expr)
+		if err != nil {
+			sb.WriteString(errorValue) // like in a debugger, the view shows the failing expression
+			continue
+		}
+		var out string
+		if c == 'E' {
+			out = e.enum(expr, val)
+		} else if out, err = event.FormatValue(c, val); err != nil {
+			return "", fmt.Errorf("%q: %w", s, err)
+		}
+		sb.WriteString(out)
+	}
+	return sb.String(), nil
+}
+
+// closingBracket returns the position of the bracket closing the one at pos.
+func closingBracket(s string, pos int) int {
+	depth := 0
+	for i := pos; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// enum returns the enumerator name of the value of a member expression
+// like var.member, the enumerators are taken from the typedef of the
+// variable. Without a matching enumerator the value is returned.
+func (e *evaluator) enum(expr string, val eval.Value) string {
+	expr = strings.TrimSpace(expr)
+	for len(expr) > 1 && expr[0] == '(' && closingParen(expr) == len(expr)-1 {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	if i := strings.LastIndexByte(expr, '.'); i > 0 {
+		base, member := strings.TrimSpace(expr[:i]), strings.TrimSpace(expr[i+1:])
+		td := e.tdUsed[base]
+		if v, err := e.eval(base); err == nil && v.IsBlock() {
+			td = v.TypeName()
+		}
+		if name, ok := e.typedefs[td].Members[member].Enums[val.GetInt()]; ok {
+			return name
+		}
+	}
+	return strconv.FormatInt(val.GetInt(), 10)
+}
+
+// closingParen returns the position of the parenthesis closing the first one of s.
+func closingParen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
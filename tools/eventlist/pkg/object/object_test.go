@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package object
+
+import (
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/elf"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/memory"
+	"eventlist/pkg/xml/scvd"
+	"reflect"
+	"testing"
+)
+
+type testMemory map[uint64][]byte
+
+func (m testMemory) Read(addr uint64, size uint64) ([]byte, bool) {
+	for a, d := range m {
+		if addr >= a && addr+size <= a+uint64(len(d)) {
+			return d[addr-a : addr-a+size], true
+		}
+	}
+	return nil, false
+}
+
+func getObjects(t *testing.T, file string) (scvd.Objects, eval.Typedefs) {
+	t.Helper()
+	files := []string{file}
+	typedefs := make(eval.Typedefs)
+	var objects scvd.Objects
+	if err := scvd.GetObjects(&files, make(scvd.Events), typedefs, &objects); err != nil {
+		t.Fatalf("GetObjects() error = %v", err)
+	}
+	return objects, typedefs
+}
+
+func thread(next, id uint32, state, prio uint8) []byte {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint32(b[0:], next)
+	binary.LittleEndian.PutUint32(b[4:], id)
+	b[8], b[9] = state, prio
+	return b
+}
+
+func TestEvaluate(t *testing.T) { //nolint:golint,paralleltest
+	objects, typedefs := getObjects(t, "../../testdata/objects.scvd")
+	threads := append(thread(0x20000110, 1, 2, 10), thread(0, 2, 1, 0)...)
+	mem := testMemory{
+		0x20000000: {0x00, 0x01, 0x00, 0x20},
+		0x20000100: threads,
+		0x20000200: {5, 0, 6, 0, 7, 0, 0, 0, 1, 0},
+	}
+	elf.Symbols.Init("ThreadList", 0x20000000, 4)
+	elf.Symbols.Add("Counters", 0x20000200, 10)
+
+	want := []View{{Name: "Threads", Items: []Item{
+		{Property: "Count", Value: "2"},
+		{Property: "Thread 1", Value: "Running", Bold: true, Items: []Item{{Property: "Address", Value: "0x20000100"}}},
+		{Property: "Idle", Value: "prio 0", Items: []Item{{Property: "Address", Value: "0x20000110"}}},
+		{Property: "Running", Value: "1"},
+		{Property: "Counters", Value: "5 6 7 at 0x20000200"},
+		{Property: "Total", Value: "256"},
+		{Property: "Unknown", Value: "<error>"},
+		{Property: "Load", Value: "100%"},
+	}}}
+	got, err := Evaluate(objects, typedefs, mem)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Evaluate() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEvaluate_fault(t *testing.T) { //nolint:golint,paralleltest
+	objects, typedefs := getObjects(t, "../../../../Fault/ARM_Fault.scvd")
+	info := make([]byte, 140)
+	binary.LittleEndian.PutUint32(info[0:], 0x52746C46)  // MagicNumber
+	binary.LittleEndian.PutUint32(info[8:], 3)           // Count
+	binary.LittleEndian.PutUint16(info[14:], 0x137)      // Content
+	binary.LittleEndian.PutUint32(info[72:], 0x08000123) // ReturnAddress
+	binary.LittleEndian.PutUint32(info[76:], 0x01000003) // xPSR
+	binary.LittleEndian.PutUint32(info[104:], 0x2000000) // CFSR
+	mem := testMemory{0x08000000: []byte("1.2.3\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"), 0x20000000: info}
+	elf.Symbols.Init("ARM_FaultVersion", 0x08000000, 16)
+	elf.Symbols.Add("ARM_FaultInfo", 0x20000000, 140)
+
+	views, err := Evaluate(objects, typedefs, mem)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(views) != 1 || views[0].Name != "Fault" {
+		t.Fatalf("Evaluate() = %+v, want view Fault", views)
+	}
+	items := views[0].Items
+	if len(items) != 6 {
+		t.Fatalf("Evaluate() items = %+v", items)
+	}
+	if !reflect.DeepEqual(items[0], Item{Property: "Version", Value: "1.2.3"}) {
+		t.Errorf("Evaluate() version = %+v", items[0])
+	}
+	if items[2].Property != "TrustZone" || items[2].Items[0].Value != "Secure" || items[2].Items[1].Value != "Non-Secure" {
+		t.Errorf("Evaluate() TrustZone = %+v", items[2])
+	}
+	regs := items[3].Items
+	if regs[14].Value != "0x8000123" || regs[15].Items[0].Value != "HardFault" {
+		t.Errorf("Evaluate() registers = %+v", regs)
+	}
+	faults := items[5]
+	if !faults.Alert || faults.Items[0].Items[0].Value != "0x200" || !reflect.DeepEqual(faults.Items[0].Items[0].Items[0], Item{Property: "DIVBYZERO", Value: "1", Alert: true}) {
+		t.Errorf("Evaluate() fault registers = %+v", faults)
+	}
+
+	// invalid fault information
+	mem[0x20000000] = make([]byte, 140)
+	views, err = Evaluate(objects, typedefs, mem)
+	if err != nil {
+		t.Fatalf("Evaluate() invalid error = %v", err)
+	}
+	want := []Item{
+		{Property: "Version", Value: "1.2.3"},
+		{Property: "Info", Value: "No fault saved yet or fault information is invalid!", Bold: true},
+	}
+	if !reflect.DeepEqual(views[0].Items, want) {
+		t.Errorf("Evaluate() invalid = %+v, want %+v", views[0].Items, want)
+	}
+}
+
+func TestEvaluate_errors(t *testing.T) { //nolint:golint,paralleltest
+	typedefs := eval.Typedefs{"T": {Size: 8}}
+	mem := testMemory{0x20000000: make([]byte, 8)}
+	object := func(stmt scvd.StatementType) scvd.Objects {
+		return scvd.Objects{{Name: "o", Statements: []scvd.StatementType{stmt}}}
+	}
+
+	tests := []struct {
+		name    string
+		objects scvd.Objects
+		mem     testMemory
+		wantErr error
+	}{
+		{"symbol", object(scvd.StatementType{Read: &scvd.ReadType{Name: "v", Type: "T", Symbol: "nix"}}), mem, errSymbol},
+		{"memory", object(scvd.StatementType{Read: &scvd.ReadType{Name: "v", Type: "T", Symbol: "Sym", Offset: "4"}}), mem, errRead},
+		{"no memory", object(scvd.StatementType{Read: &scvd.ReadType{Name: "v", Type: "T", Symbol: "Sym"}}), nil, errRead},
+		{"type", object(scvd.StatementType{Read: &scvd.ReadType{Name: "v", Type: "X", Symbol: "Sym"}}), mem, errType},
+		{"next", object(scvd.StatementType{Readlist: &scvd.ReadlistType{Name: "v", Type: "T", Symbol: "Sym", Next: "nix"}}), mem, errType},
+		{"loop", object(scvd.StatementType{List: &scvd.ObjectListType{Name: "i"}}), mem, errLoop},
+		{"while", object(scvd.StatementType{List: &scvd.ObjectListType{Name: "i", While: "1"}}), mem, errLoop},
+		{"calc", object(scvd.StatementType{Calc: &scvd.CalcType{Expr: "x = (1"}}), mem, eval.ErrSyntax},
+		{"format", object(scvd.StatementType{Out: &scvd.OutType{Name: "%d"}}), mem, errFormat},
+		{"bracket", object(scvd.StatementType{Out: &scvd.OutType{Name: "%d[i"}}), mem, errFormat},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			elf.Symbols.Init("Sym", 0x20000000, 8)
+			var mem memory.Reader
+			if tt.mem != nil {
+				mem = tt.mem
+			}
+			if _, err := Evaluate(tt.objects, typedefs, mem); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Evaluate() %s error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluate_readlist(t *testing.T) { //nolint:golint,paralleltest
+	typedefs := eval.Typedefs{"T": {Size: 4, Members: map[string]eval.Member{"next": {Offset: "0", IType: eval.Uint32}}}}
+	mem := testMemory{0x20000000: {0x08, 0, 0, 0x20, 0, 0, 0, 0x20, 0x04, 0, 0, 0x20}}
+	elf.Symbols.Init("Sym", 0x20000000, 12)
+
+	tests := []struct {
+		name  string
+		stmts []scvd.StatementType
+		want  int64
+	}{
+		{"count", []scvd.StatementType{{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "3"}}}, 3},
+		{"single", []scvd.StatementType{{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym"}}}, 1},
+		{"circular", []scvd.StatementType{{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Next: "next"}}}, 3},
+		{"append", []scvd.StatementType{
+			{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "2", Init: true}},
+			{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "2"}},
+		}, 4},
+		{"init", []scvd.StatementType{
+			{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "2", Init: true}},
+			{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "1", Init: true}},
+		}, 1},
+		{"cond", []scvd.StatementType{
+			{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "2"}},
+			{Readlist: &scvd.ReadlistType{Name: "l", Type: "T", Symbol: "Sym", Count: "1", Cond: "0"}},
+		}, 2},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			objects := scvd.Objects{{Name: "o", Statements: append(tt.stmts, scvd.StatementType{Var: &scvd.VarType{Name: "n", Value: "l._count"}})}}
+			if _, err := Evaluate(objects, typedefs, mem); err != nil {
+				t.Fatalf("Evaluate() %s error = %v", tt.name, err)
+			}
+			expr := "n + 0"
+			got, _ := eval.Eval(&expr, typedefs, nil)
+			if got.GetInt() != tt.want {
+				t.Errorf("Evaluate() %s count = %d, want %d", tt.name, got.GetInt(), tt.want)
+			}
+		})
+	}
+}
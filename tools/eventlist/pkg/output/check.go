@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"os"
+)
+
+// CheckReport describes the layout of the JSON and XML output of the SCVD check.
+type CheckReport struct {
+	Conflicts []scvd.Conflict `json:"conflicts" xml:"conflict"`
+}
+
+// printCheck writes the definitions given more than once in the global
+// FormatType, the text format lists one definition per line followed by
+// the number of conflicts.
+func printCheck(out *bufio.Writer, r *CheckReport) error {
+	switch FormatType {
+	case "json":
+		if r.Conflicts == nil {
+			r.Conflicts = []scvd.Conflict{}
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case "xml":
+		enc := xml.NewEncoder(out)
+		if err := enc.EncodeElement(r, xml.StartElement{Name: xml.Name{Local: "Check"}}); err != nil {
+			return err
+		}
+		return enc.Flush()
+	}
+
+	if _, err := fmt.Fprint(out, "   SCVD check\n   ----------\n\n"); err != nil {
+		return err
+	}
+	var conflicts int
+	for _, c := range r.Conflicts {
+		if !c.Identical {
+			conflicts++
+		}
+		if _, err := fmt.Fprintf(out, "  %s\n", c); err != nil {
+			return err
+		}
+	}
+	if len(r.Conflicts) != 0 {
+		if _, err := fmt.Fprintln(out); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "  Conflicts: %d, identical definitions: %d\n", conflicts, len(r.Conflicts)-conflicts)
+	return err
+}
+
+// PrintCheck writes the definitions given more than once in the SCVD files
+// to a specified file or standard output in a given format.
+//
+// Parameters:
+//   - filename: Pointer to the name of the file where the output will be written. If nil or empty, output is written to stdout.
+//   - formatType: Pointer to the format of the conflict list, one of ReportFormats. If nil or empty, the current format is used.
+//   - conflicts: The definitions given more than once.
+//
+// Returns:
+//   - error: An error if the format is not supported or the file could not be created or written to.
+func PrintCheck(filename *string, formatType *string, conflicts []scvd.Conflict) error {
+	var file *os.File
+	var err error
+
+	if err = setFormat(formatType, ReportFormats); err != nil {
+		return err
+	}
+	if filename != nil && len(*filename) != 0 {
+		if file, err = createOutput(*filename); err != nil {
+			return err
+		}
+		defer file.Close()
+	} else {
+		file = os.Stdout
+	}
+
+	out := bufio.NewWriter(file)
+	err = printCheck(out, &CheckReport{Conflicts: conflicts})
+	if err == nil {
+		err = out.Flush()
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"eventlist/pkg/xml/scvd"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrintCheck(t *testing.T) { //nolint:golint,paralleltest
+	conflicts := []scvd.Conflict{
+		{Kind: "event", Name: "0xEF00", First: scvd.Position{File: "a.scvd", Line: 18}, Second: scvd.Position{File: "b.scvd", Line: 20}},
+		{Kind: "typedef", Name: "attr", First: scvd.Position{File: "a.scvd", Line: 7}, Second: scvd.Position{File: "b.scvd", Line: 7}, Identical: true},
+	}
+
+	txt := "   SCVD check\n" +
+		"   ----------\n\n" +
+		"  event 0xEF00: a.scvd:18 and b.scvd:20\n" +
+		"  typedef attr: a.scvd:7 and b.scvd:7 (identical)\n\n" +
+		"  Conflicts: 1, identical definitions: 1\n"
+	txtNone := "   SCVD check\n" +
+		"   ----------\n\n" +
+		"  Conflicts: 0, identical definitions: 0\n"
+	json := "{\"conflicts\":[{\"kind\":\"event\",\"name\":\"0xEF00\",\"first\":{\"file\":\"a.scvd\",\"line\":18},\"second\":{\"file\":\"b.scvd\",\"line\":20}}," +
+		"{\"kind\":\"typedef\",\"name\":\"attr\",\"first\":{\"file\":\"a.scvd\",\"line\":7},\"second\":{\"file\":\"b.scvd\",\"line\":7},\"identical\":true}]}"
+	xml := "<Check><conflict><kind>event</kind><name>0xEF00</name><first><file>a.scvd</file><line>18</line></first>" +
+		"<second><file>b.scvd</file><line>20</line></second></conflict>" +
+		"<conflict><kind>typedef</kind><name>attr</name><first><file>a.scvd</file><line>7</line></first>" +
+		"<second><file>b.scvd</file><line>7</line></second><identical>true</identical></conflict></Check>"
+
+	tests := []struct {
+		name      string
+		format    string
+		conflicts []scvd.Conflict
+		want      string
+	}{
+		{"txt", "txt", conflicts, txt},
+		{"txt none", "txt", nil, txtNone},
+		{"json", "json", conflicts, json},
+		{"json none", "json", nil, "{\"conflicts\":[]}"},
+		{"xml", "xml", conflicts, xml},
+	}
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			FormatType = "txt"
+			file := filepath.Join(t.TempDir(), "check.txt")
+			if err := PrintCheck(&file, &tt.format, tt.conflicts); err != nil {
+				t.Fatalf("PrintCheck() %s error = %v", tt.name, err)
+			}
+			got, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("PrintCheck() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	csv := "csv"
+	if err := PrintCheck(nil, &csv, conflicts); err == nil {
+		t.Errorf("PrintCheck() csv error = nil")
+	}
+}
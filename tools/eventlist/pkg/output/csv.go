@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errColumn = errors.New("invalid column")
+
+// Columns is the comma separated list of the columns of the csv and tsv
+// formats, empty for all columns of the table.
+var Columns = ""
+
+// eventColumns are the columns of the event table.
+var eventColumns = []string{"index", "time", "component", "property", "value", "alert", "bold"}
+
+// statisticColumns are the columns of the statistics table, the times are in seconds.
+var statisticColumns = []string{"event", "count", "running", "total", "min", "max", "average",
+	"first", "last", "minStart", "maxStart", "minStartText", "minStopText", "maxStartText", "maxStopText"}
+
+// parseColumns checks the selected columns of a table.
+//
+// Parameters:
+//   - s: The comma separated column names, empty for all columns.
+//   - statistic: true for the statistics table, false for the event table.
+//
+// Returns:
+//   - The columns in the selected order.
+//   - An error if a column is not part of the table.
+func parseColumns(s string, statistic bool) ([]string, error) {
+	all := eventColumns
+	if statistic {
+		all = statisticColumns
+	}
+	if strings.TrimSpace(s) == "" {
+		return all, nil
+	}
+	var columns []string
+	for _, item := range strings.Split(s, ",") {
+		name := strings.TrimSpace(item)
+		found := false
+		for _, c := range all {
+			if strings.EqualFold(c, name) {
+				columns = append(columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", errColumn, name)
+		}
+	}
+	return columns, nil
+}
+
+// csvWriter writes a table as comma or tab separated values with a header
+// row, quoted as defined by RFC 4180. The event table is written unless
+// only the statistic is shown, then the statistics table is written.
+type csvWriter struct {
+	o         *Output
+	enc       *csv.Writer
+	statistic bool // the statistics table is written instead of the events
+}
+
+// newCSVWriter creates the writer for the csv or tsv format. All columns
+// are written if the output has no selected columns.
+func newCSVWriter(o *Output, out *bufio.Writer, comma rune) *csvWriter {
+	if o.csvColumns == nil {
+		o.csvColumns, _ = parseColumns("", o.statisticOnly)
+	}
+	enc := csv.NewWriter(out)
+	enc.Comma = comma
+	return &csvWriter{o: o, enc: enc, statistic: o.statisticOnly}
+}
+
+// writeRow writes a row. The encoder buffers are flushed to the output
+// writer at once, which is flushed by the flushWriter in Live mode.
+func (w *csvWriter) writeRow(row []string) error {
+	if err := w.enc.Write(row); err != nil {
+		return err
+	}
+	w.enc.Flush()
+	return w.enc.Error()
+}
+
+// begin writes the header row.
+func (w *csvWriter) begin() error {
+	return w.writeRow(w.o.csvColumns)
+}
+
+// formatSeconds formats a time for spreadsheets, without unit and exponent,
+// in the nanosecond resolution of the timestamps.
+func formatSeconds(v float64) string {
+	return strconv.FormatFloat(v, 'f', 9, 64)
+}
+
+// write writes one event record as row of the event table.
+func (w *csvWriter) write(record *EventRecord) error {
+	if w.statistic {
+		return nil
+	}
+	row := make([]string, 0, len(w.o.csvColumns))
+	for _, c := range w.o.csvColumns {
+		switch c {
+		case "index":
+			row = append(row, strconv.Itoa(record.Index))
+		case "time":
+			row = append(row, formatSeconds(record.Time))
+		case "component":
+			row = append(row, record.Component)
+		case "property":
+			row = append(row, record.EventProperty)
+		case "value":
+			row = append(row, record.Value)
+		case "alert":
+			row = append(row, strconv.FormatBool(record.Alert))
+		case "bold":
+			row = append(row, strconv.FormatBool(record.Bold))
+		}
+	}
+	return w.writeRow(row)
+}
+
+// end writes the statistics table if it is selected. A start/stop event
+// which is running but was never stopped is written with count 0.
+func (w *csvWriter) end(*EventsTable) error {
+	if w.statistic {
+		for i := range w.o.evProps {
+			for j := range w.o.evProps[i].values {
+				es := &w.o.evProps[i].values[j]
+				if !es.evFirst && !es.evStart {
+					continue
+				}
+				if err := w.writeRow(w.statisticRow(fmt.Sprintf("%c(%d)", byte(i+'A'), j), es)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// statisticRow returns the row of the statistics table of a start/stop event,
+// the times are empty if it was never stopped.
+func (w *csvWriter) statisticRow(name string, es *eventStatistic) []string {
+	row := make([]string, 0, len(w.o.csvColumns))
+	for _, c := range w.o.csvColumns {
+		if es.count == 0 && c != "event" && c != "count" && c != "running" {
+			row = append(row, "")
+			continue
+		}
+		switch c {
+		case "event":
+			row = append(row, name)
+		case "count":
+			row = append(row, strconv.Itoa(es.count))
+		case "running":
+			row = append(row, strconv.FormatBool(es.evStart))
+		case "total":
+			row = append(row, formatSeconds(es.tot))
+		case "min":
+			row = append(row, formatSeconds(es.min))
+		case "max":
+			row = append(row, formatSeconds(es.max))
+		case "average":
+			row = append(row, formatSeconds(es.tot/float64(es.count)))
+		case "first":
+			row = append(row, formatSeconds(es.first))
+		case "last":
+			row = append(row, formatSeconds(es.last))
+		case "minStart":
+			row = append(row, formatSeconds(es.minTime))
+		case "maxStart":
+			row = append(row, formatSeconds(es.maxTime))
+		case "minStartText":
+			row = append(row, es.textMinB)
+		case "minStopText":
+			row = append(row, es.textMinE)
+		case "maxStartText":
+			row = append(row, es.textMaxB)
+		case "maxStopText":
+			row = append(row, es.textMaxE)
+		}
+	}
+	return row
+}
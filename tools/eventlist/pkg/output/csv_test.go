@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		s         string
+		statistic bool
+		want      []string
+		wantErr   error
+	}{
+		{"events", "", false, eventColumns, nil},
+		{"statistic", " ", true, statisticColumns, nil},
+		{"selected", "Value, time", false, []string{"value", "time"}, nil},
+		{"statistic selected", "event,average", true, []string{"event", "average"}, nil},
+		{"unknown", "time,foo", false, nil, errColumn},
+		{"wrong table", "count", false, nil, errColumn},
+		{"empty item", "time,", false, nil, errColumn},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseColumns(tt.s, tt.statistic)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseColumns() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_csvWriter(t *testing.T) {
+	t.Parallel()
+
+	records := []EventRecord{
+		{Index: 0, Time: 1.5, Component: "c", EventProperty: "p", Value: "v, w"},
+		{Index: 2, Time: 0.000025, Component: "0xFE", EventProperty: "0xFE00", Value: "say \"hi\"", quoted: true},
+		{Index: 3, Time: 3, Component: "c", EventProperty: "p", Value: "error", Alert: true},
+	}
+	var props [4]eventProperty
+	for i := range props {
+		props[i].init()
+	}
+	props[1].add(1, 2, true, "begin")
+	props[1].add(1.5, 2, false, "end")
+	props[1].add(2, 2, true, "b2")
+	props[1].add(3, 2, false, "e2")
+	props[1].add(4, 2, true, "open")
+	props[2].add(5, 1, true, "never stopped")
+
+	tests := []struct {
+		name      string
+		comma     rune
+		columns   []string
+		statistic bool
+		want      string
+	}{
+		{"csv", ',', nil, false, "index,time,component,property,value,alert,bold\n" +
+			"0,1.500000000,c,p,\"v, w\",false,false\n" +
+			"2,0.000025000,0xFE,0xFE00,\"say \"\"hi\"\"\",false,false\n" +
+			"3,3.000000000,c,p,error,true,false\n"},
+		{"tsv columns", '\t', []string{"time", "value"}, false, "time\tvalue\n" +
+			"1.500000000\tv, w\n" +
+			"0.000025000\t\"say \"\"hi\"\"\"\n" +
+			"3.000000000\terror\n"},
+		{"statistic", ',', []string{"event", "count", "running", "total", "min", "max", "average", "minStart", "maxStopText"},
+			true, "event,count,running,total,min,max,average,minStart,maxStopText\n" +
+				"B(2),2,true,1.500000000,0.500000000,1.000000000,0.750000000,1.000000000,e2\n" +
+				"C(1),0,true,,,,,,\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+			o := &Output{evProps: props, csvColumns: tt.columns, statisticOnly: tt.statistic}
+			w := newCSVWriter(o, out, tt.comma)
+			if err := w.begin(); err != nil {
+				t.Errorf("csvWriter.begin() %s error = %v", tt.name, err)
+			}
+			for i := range records {
+				if err := w.write(&records[i]); err != nil {
+					t.Errorf("csvWriter.write() %s error = %v", tt.name, err)
+				}
+			}
+			if err := w.end(&EventsTable{}); err != nil {
+				t.Errorf("csvWriter.end() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("csvWriter %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"eventlist/pkg/event"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errCTFDir = errors.New("CTF output requires an output directory (-o)")
+
+// Names of the files of a CTF trace directory.
+const (
+	ctfMetadata = "metadata"
+	ctfStream   = "stream"
+)
+
+// ctfMagic starts the packet header of the stream.
+const ctfMagic = 0xC1FC1FC1
+
+// ctfClass is an event class of the CTF trace, there is one class for each
+// component, event property and record type.
+type ctfClass struct {
+	id   int
+	name string
+	typ  uint16
+}
+
+// ctfWriter writes the event records as a CTF 1.8 trace: the stream file is
+// written while decoding, the metadata describing the event classes when all
+// records are written. The timestamps of the trace are in nanoseconds, the
+// payload of an event holds the decoded fields and the raw Event Recorder data.
+type ctfWriter struct {
+	dir     string
+	out     *bufio.Writer
+	classes map[string]*ctfClass
+	order   []*ctfClass
+}
+
+func newCTFWriter(dir string, out *bufio.Writer) *ctfWriter {
+	return &ctfWriter{dir: dir, out: out, classes: make(map[string]*ctfClass)}
+}
+
+// begin writes the packet header of the only packet of the stream.
+func (w *ctfWriter) begin() error {
+	var head [8]byte
+	binary.LittleEndian.PutUint32(head[0:], ctfMagic)
+	binary.LittleEndian.PutUint32(head[4:], 0) // stream_id
+	_, err := w.out.Write(head[:])
+	return err
+}
+
+// class returns the event class of a record, it is added when used first.
+func (w *ctfWriter) class(name string, typ uint16) *ctfClass {
+	key := fmt.Sprintf("%s/%d", name, typ)
+	c, ok := w.classes[key]
+	if !ok {
+		c = &ctfClass{id: len(w.order), name: name, typ: typ}
+		w.classes[key] = c
+		w.order = append(w.order, c)
+	}
+	return c
+}
+
+// appendString appends a null terminated string, null characters of the
+// string are dropped.
+func appendString(b []byte, s string) []byte {
+	return append(append(b, strings.ReplaceAll(s, "\x00", "")...), 0)
+}
+
+// write appends one event to the stream.
+func (w *ctfWriter) write(record *EventRecord) error {
+	raw := record.raw
+	if raw == nil {
+		raw = &event.Data{Typ: 2}
+	}
+	typ := raw.Typ
+	if typ == 1 && raw.Data == nil || typ != 1 && typ != 3 {
+		typ = 2
+	}
+	c := w.class(record.Component+":"+record.EventProperty, typ)
+	ts := math.Round(record.Time * 1e9)
+	if ts < 0 {
+		ts = 0
+	}
+
+	b := make([]byte, 0, 64)
+	b = binary.LittleEndian.AppendUint16(b, uint16(c.id))
+	b = binary.LittleEndian.AppendUint64(b, uint64(ts))
+	b = binary.LittleEndian.AppendUint32(b, uint32(record.Index))
+	b = binary.LittleEndian.AppendUint64(b, raw.Time)
+	b = binary.LittleEndian.AppendUint16(b, uint16(record.id))
+	if record.irq {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = appendString(b, record.Component)
+	b = appendString(b, record.EventProperty)
+	b = appendString(b, record.Value)
+	switch typ {
+	case 1:
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(*raw.Data)))
+		b = append(b, *raw.Data...)
+	case 3:
+		b = binary.LittleEndian.AppendUint32(b, uint32(raw.Value1))
+		b = binary.LittleEndian.AppendUint32(b, uint32(raw.Value2))
+		b = binary.LittleEndian.AppendUint32(b, uint32(raw.Value3))
+		b = binary.LittleEndian.AppendUint32(b, uint32(raw.Value4))
+	default:
+		b = binary.LittleEndian.AppendUint32(b, uint32(raw.Value1))
+		b = binary.LittleEndian.AppendUint32(b, uint32(raw.Value2))
+	}
+	_, err := w.out.Write(b)
+	return err
+}
+
+// ctfHeader declares the types, the trace, the clock and the stream.
+const ctfHeader = `/* CTF 1.8 */
+
+typealias integer { size = 8; align = 8; signed = false; byte_order = le; } := uint8_t;
+typealias integer { size = 16; align = 8; signed = false; byte_order = le; } := uint16_t;
+typealias integer { size = 32; align = 8; signed = false; byte_order = le; } := uint32_t;
+typealias integer { size = 64; align = 8; signed = false; byte_order = le; } := uint64_t;
+typealias integer { size = 64; align = 8; signed = false; byte_order = le; map = clock.eventlist.value; } := uint64_clock_t;
+
+trace {
+	major = 1;
+	minor = 8;
+	byte_order = le;
+	packet.header := struct {
+		uint32_t magic;
+		uint32_t stream_id;
+	};
+};
+
+env {
+	tracer_name = "eventlist";
+	domain = "Event Recorder";
+};
+
+clock {
+	name = eventlist;
+	description = "Event Recorder time";
+	freq = 1000000000;
+	offset = 0;
+};
+
+stream {
+	id = 0;
+	event.header := struct {
+		uint16_t id;
+		uint64_clock_t timestamp;
+	};
+};
+`
+
+// ctfFields are the payload fields of the record types.
+var ctfFields = map[uint16]string{
+	1: "\t\tuint16_t length;\n\t\tuint8_t data[length];\n",
+	2: "\t\tuint32_t val1;\n\t\tuint32_t val2;\n",
+	3: "\t\tuint32_t val1;\n\t\tuint32_t val2;\n\t\tuint32_t val3;\n\t\tuint32_t val4;\n",
+}
+
+// end writes the metadata file declaring the event classes.
+func (w *ctfWriter) end(*EventsTable) error {
+	var b strings.Builder
+	b.WriteString(ctfHeader)
+	for _, c := range w.order {
+		name := strings.ReplaceAll(strings.ReplaceAll(c.name, `\`, `\\`), `"`, `\"`)
+		fmt.Fprintf(&b, "\nevent {\n\tname = \"%s\";\n\tid = %d;\n\tstream_id = 0;\n", name, c.id)
+		b.WriteString("\tfields := struct {\n\t\tuint32_t index;\n\t\tuint64_t er_time;\n\t\tuint16_t er_id;\n" +
+			"\t\tuint8_t irq;\n\t\tstring component;\n\t\tstring property;\n\t\tstring value;\n")
+		b.WriteString(ctfFields[c.typ])
+		b.WriteString("\t};\n};\n")
+	}
+	return os.WriteFile(filepath.Join(w.dir, ctfMetadata), []byte(b.String()), 0o644)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"errors"
+	"eventlist/pkg/ctf"
+	"eventlist/pkg/event"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_ctfWriter(t *testing.T) {
+	t.Parallel()
+
+	data := []uint8{'a', 0, 'b'}
+	records := []EventRecord{
+		{Index: 0, Time: 1.5, Component: "C", EventProperty: "P", Value: "v=1", id: 0x0100,
+			raw: &event.Data{Time: 100, Typ: 2, Value1: 1, Value2: -1}},
+		{Index: 1, Time: 2.0, Component: "C", EventProperty: "P", Value: "x\x00y", id: 0x0100, irq: true,
+			raw: &event.Data{Time: 200, Typ: 3, Value1: 1, Value2: 2, Value3: 3, Value4: 4}},
+		{Index: 2, Time: 2.5, Component: "Quo\"te", EventProperty: "D", Value: "\"a\"", id: 0xFE00,
+			raw: &event.Data{Time: 300, Typ: 1, Data: &data}},
+		{Index: 3, Time: 3.0, Component: "C", EventProperty: "P", Value: "", id: 0x0100,
+			raw: &event.Data{Time: 400, Typ: 2, Value1: 5}},
+	}
+	want := []ctf.Event{
+		{Name: "C:P", Timestamp: 1500000000, Fields: map[string]any{
+			"index": uint64(0), "er_time": uint64(100), "er_id": uint64(0x0100), "irq": uint64(0),
+			"component": "C", "property": "P", "value": "v=1", "val1": uint64(1), "val2": uint64(0xFFFFFFFF)}},
+		{Name: "C:P", Timestamp: 2000000000, Fields: map[string]any{
+			"index": uint64(1), "er_time": uint64(200), "er_id": uint64(0x0100), "irq": uint64(1),
+			"component": "C", "property": "P", "value": "xy",
+			"val1": uint64(1), "val2": uint64(2), "val3": uint64(3), "val4": uint64(4)}},
+		{Name: "Quo\"te:D", Timestamp: 2500000000, Fields: map[string]any{
+			"index": uint64(2), "er_time": uint64(300), "er_id": uint64(0xFE00), "irq": uint64(0),
+			"component": "Quo\"te", "property": "D", "value": "\"a\"", "length": uint64(3), "data": []byte{'a', 0, 'b'}}},
+		{Name: "C:P", Timestamp: 3000000000, Fields: map[string]any{
+			"index": uint64(3), "er_time": uint64(400), "er_id": uint64(0x0100), "irq": uint64(0),
+			"component": "C", "property": "P", "value": "", "val1": uint64(5), "val2": uint64(0)}},
+	}
+
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, ctfStream))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := bufio.NewWriter(file)
+	w := newCTFWriter(dir, out)
+	if err = w.begin(); err != nil {
+		t.Fatalf("ctfWriter.begin() error = %v", err)
+	}
+	for i := range records {
+		if err = w.write(&records[i]); err != nil {
+			t.Fatalf("ctfWriter.write() %d error = %v", i, err)
+		}
+	}
+	if err = w.end(&EventsTable{}); err != nil {
+		t.Fatalf("ctfWriter.end() error = %v", err)
+	}
+	out.Flush()
+	file.Close()
+	if len(w.order) != 3 {
+		t.Errorf("ctfWriter classes = %d, want 3", len(w.order))
+	}
+
+	got, err := ctf.ReadTrace(dir)
+	if err != nil {
+		t.Fatalf("ctf.ReadTrace() error = %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ctf.ReadTrace() = %d events, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].Clock == nil || got[i].Clock.Name != "eventlist" || got[i].Clock.Freq != 1000000000 {
+			t.Errorf("ctf.ReadTrace() event %d clock = %v", i, got[i].Clock)
+		}
+		got[i].Clock = nil
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("ctf.ReadTrace() event %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPrintReader_ctf(t *testing.T) { //nolint:golint,paralleltest
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+
+	format := "ctf"
+	file := "../../testdata/test10.binary"
+	if err := PrintReader(nil, &format, nil, nil, nil, nil, false, false); !errors.Is(err, errCTFDir) {
+		t.Errorf("PrintReader() error = %v, want %v", err, errCTFDir)
+	}
+
+	dir := filepath.Join(t.TempDir(), "trace")
+	var b event.Binary
+	in := b.Open(&file)
+	defer b.Close()
+	if err := PrintReader(&dir, &format, nil, in, nil, nil, false, false); err != nil {
+		t.Fatalf("PrintReader() error = %v", err)
+	}
+	events, err := ctf.ReadTrace(dir)
+	if err != nil {
+		t.Fatalf("ctf.ReadTrace() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Name != "0xFF:0xFF03" || events[1].Name != "0xFE:0xFE00" {
+		t.Errorf("ctf.ReadTrace() = %v", events)
+	}
+}
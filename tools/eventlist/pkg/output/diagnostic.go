@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"errors"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"fmt"
+	"strings"
+)
+
+// Kinds of decode problems, in the order of the summary.
+const (
+	diagUnknownID  = "unknown id"
+	diagEnum       = "enum"
+	diagFormat     = "format"
+	diagExpression = "expression"
+	diagTruncated  = "truncated"
+	diagSkipped    = "skipped"
+)
+
+var diagKinds = []string{diagUnknownID, diagEnum, diagFormat, diagExpression, diagTruncated, diagSkipped}
+
+// maxDiagnosticLines is the number of diagnostics listed in text format.
+const maxDiagnosticLines = 20
+
+// maxDiagnostics is the number of diagnostics kept for the JSON and XML
+// output, the further problems are only counted.
+const maxDiagnostics = 1000
+
+// ErrDecode is returned after the output is written if events were lost or
+// could not be decoded. Events without definition are not counted.
+var ErrDecode = errors.New("decode problems")
+
+// Diagnostic is a problem found while decoding an event. The event is shown
+// with its raw values instead of the text of its definition.
+type Diagnostic struct {
+	Index   int    `json:"index" xml:"index"`
+	ID      string `json:"id,omitempty" xml:"id,omitempty"`
+	Kind    string `json:"kind" xml:"kind"`
+	Message string `json:"message" xml:"message"`
+}
+
+// DiagnosticCount is the number of problems of a kind.
+type DiagnosticCount struct {
+	Kind  string `json:"kind" xml:"kind"`
+	Count int    `json:"count" xml:"count"`
+}
+
+// diagnosticKind returns the kind of the error of evaluating the text of an event.
+func diagnosticKind(err error) string {
+	switch {
+	case errors.Is(err, event.ErrEnum):
+		return diagEnum
+	case errors.Is(err, event.ErrFormat), errors.Is(err, eval.ErrSyntax):
+		return diagFormat
+	}
+	return diagExpression
+}
+
+// diagnose records a decode problem of an event. All problems are counted,
+// only the first maxDiagnostics are kept.
+//
+// Parameters:
+//   - index: The index of the event.
+//   - ev: The event, nil if it could not be read.
+//   - kind: The kind of the problem.
+//   - message: The description of the problem.
+func (o *Output) diagnose(index int, ev *event.Data, kind string, message string) {
+	if o.diagnosticCounts == nil {
+		o.diagnosticCounts = make(map[string]int)
+	}
+	o.diagnosticCounts[kind]++
+	if len(o.diagnostics) == maxDiagnostics {
+		return
+	}
+	d := Diagnostic{Index: index, Kind: kind, Message: message}
+	if ev != nil {
+		d.ID = fmt.Sprintf("0x%04X", ev.Info.ID)
+	}
+	o.diagnostics = append(o.diagnostics, d)
+}
+
+// decodeError returns ErrDecode with the number of problems per kind if
+// events were lost or could not be decoded, otherwise nil.
+func (o *Output) decodeError() error {
+	var kinds []string
+	for _, k := range diagKinds {
+		if k != diagUnknownID && o.diagnosticCounts[k] != 0 {
+			kinds = append(kinds, fmt.Sprintf("%s: %d", k, o.diagnosticCounts[k]))
+		}
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrDecode, strings.Join(kinds, ", "))
+}
+
+// printDiagnostics reports the decode problems, in text format as summary
+// followed by the first problems, otherwise in the events table with the
+// number of problems per kind. Nothing is written if all events were decoded.
+//
+// Parameters:
+//   - out: The buffered writer receiving the text.
+//   - eventTable: The events table receiving the diagnostics.
+//
+// Returns:
+//   - An error if writing fails.
+func (o *Output) printDiagnostics(out *bufio.Writer, eventTable *EventsTable) error {
+	if len(o.diagnostics) == 0 {
+		return nil
+	}
+	eventTable.Diagnostics = o.diagnostics
+	var kinds []string
+	var total int
+	for _, k := range diagKinds {
+		if n := o.diagnosticCounts[k]; n != 0 {
+			kinds = append(kinds, fmt.Sprintf("%s: %d", k, n))
+			eventTable.DiagnosticCounts = append(eventTable.DiagnosticCounts, DiagnosticCount{Kind: k, Count: n})
+			total += n
+		}
+	}
+	err := conditionalWrite(out, "   Decode problems: %d (%s)\n", total, strings.Join(kinds, ", "))
+	for i, d := range o.diagnostics {
+		if err != nil {
+			return err
+		}
+		if i == maxDiagnosticLines {
+			err = conditionalWrite(out, "   ... %d more\n", total-i)
+			break
+		}
+		err = conditionalWrite(out, "%5d %-6s %s: %s\n", d.Index, d.ID, d.Kind, d.Message)
+	}
+	if err == nil {
+		err = conditionalWrite(out, "\n")
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_diagnosticKind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"enum", &eval.NumError{Func: "getEnum", Num: "1", Err: event.ErrEnum}, diagEnum},
+		{"format", fmt.Errorf("x: %w", event.ErrFormat), diagFormat},
+		{"syntax", eval.ErrSyntax, diagFormat},
+		{"expression", eval.ErrType, diagExpression},
+		{"other", errors.New("other"), diagExpression},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := diagnosticKind(tt.err); got != tt.want {
+				t.Errorf("diagnosticKind() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutput_decodeDiagnostics(t *testing.T) { //nolint:golint,paralleltest
+	savedFactor := TimeFactor
+	defer func() { TimeFactor = savedFactor }()
+	factor := 1.0
+	TimeFactor = &factor
+
+	eds := make(scvd.Events)
+	eds[0x0100] = scvd.EventType{Brief: "C", Property: "Ok", Value: "v=%d[val1]"}
+	eds[0x0101] = scvd.EventType{Brief: "C", Property: "Enum", Value: "%E[val1, nix:m]"}
+	eds[0x0102] = scvd.EventType{Brief: "C", Property: "Format", Value: "%x[val1"}
+
+	var log bytes.Buffer
+	event.AppendRecord(&log, 2, 0x0100, 1, false, []uint32{1, 2}, nil)
+	event.AppendRecord(&log, 2, 0x0101, 2, false, []uint32{3, 4}, nil)
+	event.AppendRecord(&log, 2, 0x0102, 3, false, []uint32{5, 6}, nil)
+	event.AppendRecord(&log, 2, 0x0199, 4, false, []uint32{7, 8}, nil)
+	event.AppendRecord(&log, 2, 0x0100, 5, false, []uint32{9, 10}, nil)
+	data := log.Bytes()
+	truncated := data[:len(data)-3]
+
+	lines := "    0 1.00000000 C         Ok             v=1\n" +
+		"    1 2.00000000 C         Enum           val1=0x00000003, val2=0x00000004\n" +
+		"    2 3.00000000 C         Format         val1=0x00000005, val2=0x00000006\n" +
+		"    3 4.00000000 0x01      0x0199         val1=0x00000007, val2=0x00000008\n"
+	kinds := []string{diagEnum, diagFormat, diagUnknownID}
+
+	tests := []struct {
+		name  string
+		data  []byte
+		want  string
+		kinds []string
+	}{
+		{"complete", data, lines + "    4 5.00000000 C         Ok             v=9\n", kinds},
+		{"truncated", truncated, lines, append(kinds, diagTruncated)},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(eds)
+			n, err := o.decode(bufio.NewReader(bytes.NewReader(tt.data)), eds, nil, newRecordWriter(o, out))
+			if err != nil {
+				t.Errorf("Output.decode() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.decode() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			var got []string
+			for _, d := range o.diagnostics {
+				got = append(got, d.Kind)
+			}
+			if !reflect.DeepEqual(got, tt.kinds) {
+				t.Errorf("Output.decode() %s diagnostics = %v, want %v", tt.name, o.diagnostics, tt.kinds)
+			}
+			if last := o.diagnostics[len(o.diagnostics)-1]; tt.name == "truncated" && (last.Index != n || last.ID != "") {
+				t.Errorf("Output.decode() %s truncated = %v, want index %d", tt.name, last, n)
+			}
+			if d := o.diagnostics[0]; d.Index != 1 || d.ID != "0x0101" {
+				t.Errorf("Output.decode() %s diagnostic = %v, want index 1 and ID 0x0101", tt.name, d)
+			}
+		})
+	}
+}
+
+func TestOutput_printDiagnostics(t *testing.T) { //nolint:golint,paralleltest
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+
+	many := make([]Diagnostic, maxDiagnosticLines+2)
+	for i := range many {
+		many[i] = Diagnostic{Index: i, ID: "0x0100", Kind: diagEnum, Message: "m"}
+	}
+	var manyText string
+	for i := 0; i < maxDiagnosticLines; i++ {
+		manyText += fmt.Sprintf("%5d 0x0100 enum: m\n", i)
+	}
+
+	tests := []struct {
+		name        string
+		format      string
+		diagnostics []Diagnostic
+		want        string
+	}{
+		{"none", "txt", nil, ""},
+		{"txt", "txt", []Diagnostic{{1, "0x0101", diagFormat, "bad"}, {2, "0x0199", diagUnknownID, "no event definition"}, {3, "", diagTruncated, "eof"}},
+			"   Decode problems: 3 (unknown id: 1, format: 1, truncated: 1)\n" +
+				"    1 0x0101 format: bad\n" +
+				"    2 0x0199 unknown id: no event definition\n" +
+				"    3        truncated: eof\n\n"},
+		{"many", "txt", many, "   Decode problems: 22 (enum: 22)\n" + manyText + "   ... 2 more\n\n"},
+		{"json", "json", many, ""},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			FormatType = tt.format
+			o := &Output{diagnostics: tt.diagnostics, diagnosticCounts: make(map[string]int)}
+			for _, d := range tt.diagnostics {
+				o.diagnosticCounts[d.Kind]++
+			}
+			var table EventsTable
+			if err := o.printDiagnostics(out, &table); err != nil {
+				t.Errorf("Output.printDiagnostics() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.printDiagnostics() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			if !reflect.DeepEqual(table.Diagnostics, tt.diagnostics) {
+				t.Errorf("Output.printDiagnostics() %s table = %v, want %v", tt.name, table.Diagnostics, tt.diagnostics)
+			}
+			if tt.name == "json" && !reflect.DeepEqual(table.DiagnosticCounts, []DiagnosticCount{{diagEnum, len(many)}}) {
+				t.Errorf("Output.printDiagnostics() %s counts = %v", tt.name, table.DiagnosticCounts)
+			}
+		})
+	}
+}
+
+func TestOutput_diagnose(t *testing.T) {
+	t.Parallel()
+
+	var o Output
+	ev := event.Data{Info: event.Info{ID: 0x0199}}
+	for i := 0; i < maxDiagnostics+5; i++ {
+		o.diagnose(i, &ev, diagUnknownID, "no event definition")
+	}
+	if len(o.diagnostics) != maxDiagnostics || o.diagnosticCounts[diagUnknownID] != maxDiagnostics+5 {
+		t.Errorf("Output.diagnose() kept %d of %d, want %d of %d", len(o.diagnostics), o.diagnosticCounts[diagUnknownID], maxDiagnostics, maxDiagnostics+5)
+	}
+	if err := o.decodeError(); err != nil {
+		t.Errorf("Output.decodeError() unknown id error = %v, want nil", err)
+	}
+	o.diagnose(maxDiagnostics+5, nil, diagSkipped, "skipped 4 bytes at offset 0x10")
+	o.diagnose(maxDiagnostics+6, nil, diagTruncated, "eof")
+	err := o.decodeError()
+	if !errors.Is(err, ErrDecode) || err.Error() != "decode problems: truncated: 1, skipped: 1" {
+		t.Errorf("Output.decodeError() error = %v, want %v", err, ErrDecode)
+	}
+}
+
+func TestOutput_decodeResync(t *testing.T) { //nolint:golint,paralleltest
+	savedFactor := TimeFactor
+	savedResync := Resync
+	defer func() {
+		TimeFactor = savedFactor
+		Resync = savedResync
+	}()
+	factor := 1.0
+	TimeFactor = &factor
+
+	var log bytes.Buffer
+	event.AppendRecord(&log, 2, 0xFF03, 1, false, []uint32{1, 2}, nil)
+	log.Write([]byte{0x03, 0x00, 0xFF})
+	event.AppendRecord(&log, 2, 0xFF03, 2, false, []uint32{3, 4}, nil)
+	data := log.Bytes()
+
+	tests := []struct {
+		name        string
+		resync      bool
+		want        string
+		diagnostics []Diagnostic
+	}{
+		{"without resync", false, "    0 1.00000000 0xFF      0xFF03         val1=0x00000001, val2=0x00000002\n",
+			[]Diagnostic{{Index: 1, Kind: diagTruncated, Message: "truncated event record: unexpected EOF"}}},
+		{"resync", true, "    0 1.00000000 0xFF      0xFF03         val1=0x00000001, val2=0x00000002\n" +
+			"    1 1.00000000 0xFF      0xFF03         val1=0x00000003, val2=0x00000004\n",
+			[]Diagnostic{{Index: 1, Kind: diagSkipped, Message: "skipped 3 bytes at offset 0x18"}}},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+			Resync = tt.resync
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(nil)
+			if _, err := o.decode(bufio.NewReader(bytes.NewReader(data)), nil, nil, newRecordWriter(o, out)); err != nil {
+				t.Errorf("Output.decode() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.decode() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			if !reflect.DeepEqual(o.diagnostics, tt.diagnostics) {
+				t.Errorf("Output.decode() %s diagnostics = %v, want %v", tt.name, o.diagnostics, tt.diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"eventlist/pkg/fault"
+	"fmt"
+	"os"
+)
+
+// printRegisters writes a list of registers in the text format.
+func printRegisters(out *bufio.Writer, title string, regs []fault.Register) error {
+	if len(regs) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(out, "\n  %s:\n", title); err != nil {
+		return err
+	}
+	for _, reg := range regs {
+		if _, err := fmt.Fprintf(out, "   - %-18s%s\n", reg.Name+":", reg.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printFault writes the decoded fault information in the global FormatType,
+// the text format follows the output of ARM_FaultPrint.
+func printFault(out *bufio.Writer, r *fault.Report) error {
+	switch FormatType {
+	case "json":
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case "xml":
+		enc := xml.NewEncoder(out)
+		if err := enc.EncodeElement(r, xml.StartElement{Name: xml.Name{Local: "Fault"}}); err != nil {
+			return err
+		}
+		return enc.Flush()
+	}
+
+	line := func(name, value string) error {
+		_, err := fmt.Fprintf(out, "  %-21s%s\n", name+":", value)
+		return err
+	}
+	var err error
+	if _, err = fmt.Fprint(out, "   Fault information\n   -----------------\n\n"); err != nil {
+		return err
+	}
+	if r.Version != "" {
+		if err = line("Version", r.Version); err != nil {
+			return err
+		}
+	}
+	if err = line("Fault count", fmt.Sprint(r.Count)); err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintln(out); err != nil {
+		return err
+	}
+	handler := r.Exception
+	if r.SaveMode != "" {
+		handler = r.SaveMode + " - " + handler
+	}
+	if err = line("Exception Handler", handler); err != nil {
+		return err
+	}
+	if r.FaultMode != "" {
+		if err = line("State", r.FaultMode); err != nil {
+			return err
+		}
+	}
+	if err = line("Mode", r.Mode); err != nil {
+		return err
+	}
+	for _, f := range r.Faults {
+		if err = line("Fault", f); err != nil {
+			return err
+		}
+	}
+	pc := r.PC
+	if r.Symbol != "" {
+		pc += " (" + r.Symbol + ")"
+	}
+	if err = line("Program Counter", pc); err != nil {
+		return err
+	}
+	if err = printRegisters(out, "Registers", r.Registers); err != nil {
+		return err
+	}
+	if err = printRegisters(out, "Exception State", r.ExceptionState); err != nil {
+		return err
+	}
+	return printRegisters(out, "Fault Registers", r.FaultRegisters)
+}
+
+// PrintFault writes decoded fault information to a specified file
+// or standard output in a given format.
+//
+// Parameters:
+//   - filename: Pointer to the name of the file where the output will be written. If nil or empty, output is written to stdout.
+//   - formatType: Pointer to the format of the fault report, one of ReportFormats. If nil or empty, the current format is used.
+//   - report: The decoded fault information.
+//
+// Returns:
+//   - error: An error if the format is not supported or the file could not be created or written to.
+func PrintFault(filename *string, formatType *string, report *fault.Report) error {
+	var file *os.File
+	var err error
+
+	if err = setFormat(formatType, ReportFormats); err != nil {
+		return err
+	}
+	if filename != nil && len(*filename) != 0 {
+		if file, err = createOutput(*filename); err != nil {
+			return err
+		}
+		defer file.Close()
+	} else {
+		file = os.Stdout
+	}
+
+	out := bufio.NewWriter(file)
+	err = printFault(out, report)
+	if err == nil {
+		err = out.Flush()
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"eventlist/pkg/fault"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrintFault(t *testing.T) { //nolint:golint,paralleltest
+	report := &fault.Report{
+		Version:        "1.0.0",
+		Count:          1,
+		Exception:      "HardFault",
+		SaveMode:       "Secure",
+		FaultMode:      "Non-Secure",
+		Mode:           "Thread",
+		Faults:         []string{"UsageFault - Divide by 0"},
+		PC:             "0x10000360",
+		Symbol:         "main+0x4",
+		Registers:      []fault.Register{{Name: "R0", Value: "0x00000001"}},
+		ExceptionState: []fault.Register{{Name: "Exception Return", Value: "0xFFFFFFFD"}},
+	}
+	minimal := &fault.Report{Exception: "HardFault", Mode: "Handler", Faults: []string{}, PC: "unknown (was not stacked)"}
+
+	txt := "   Fault information\n" +
+		"   -----------------\n\n" +
+		"  Version:             1.0.0\n" +
+		"  Fault count:         1\n\n" +
+		"  Exception Handler:   Secure - HardFault\n" +
+		"  State:               Non-Secure\n" +
+		"  Mode:                Thread\n" +
+		"  Fault:               UsageFault - Divide by 0\n" +
+		"  Program Counter:     0x10000360 (main+0x4)\n\n" +
+		"  Registers:\n" +
+		"   - R0:               0x00000001\n\n" +
+		"  Exception State:\n" +
+		"   - Exception Return: 0xFFFFFFFD\n"
+	txtMinimal := "   Fault information\n" +
+		"   -----------------\n\n" +
+		"  Fault count:         0\n\n" +
+		"  Exception Handler:   HardFault\n" +
+		"  Mode:                Handler\n" +
+		"  Program Counter:     unknown (was not stacked)\n"
+	json := "{\"version\":\"1.0.0\",\"count\":1,\"exception\":\"HardFault\",\"saveMode\":\"Secure\",\"faultMode\":\"Non-Secure\"," +
+		"\"mode\":\"Thread\",\"faults\":[\"UsageFault - Divide by 0\"],\"pc\":\"0x10000360\",\"symbol\":\"main+0x4\"," +
+		"\"registers\":[{\"name\":\"R0\",\"value\":\"0x00000001\"}],\"exceptionState\":[{\"name\":\"Exception Return\",\"value\":\"0xFFFFFFFD\"}]}"
+	xml := "<Fault><version>1.0.0</version><count>1</count><exception>HardFault</exception><saveMode>Secure</saveMode>" +
+		"<faultMode>Non-Secure</faultMode><mode>Thread</mode><fault>UsageFault - Divide by 0</fault><pc>0x10000360</pc>" +
+		"<symbol>main+0x4</symbol><registers><register name=\"R0\">0x00000001</register></registers>" +
+		"<exceptionState><register name=\"Exception Return\">0xFFFFFFFD</register></exceptionState><faultRegisters></faultRegisters></Fault>"
+
+	tests := []struct {
+		name   string
+		format string
+		report *fault.Report
+		want   string
+	}{
+		{"txt", "txt", report, txt},
+		{"txt minimal", "txt", minimal, txtMinimal},
+		{"json", "json", report, json},
+		{"xml", "xml", report, xml},
+	}
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			FormatType = "txt"
+			file := filepath.Join(t.TempDir(), "fault.txt")
+			if err := PrintFault(&file, &tt.format, tt.report); err != nil {
+				t.Fatalf("PrintFault() %s error = %v", tt.name, err)
+			}
+			got, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("PrintFault() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	dir := t.TempDir()
+	if err := PrintFault(&dir, nil, report); err == nil {
+		t.Errorf("PrintFault() directory error = nil")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"errors"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"strings"
+)
+
+var errFilter = errors.New("filter is not a condition")
+
+// filter is the expression selecting the events to be shown, see SetFilter.
+var filter = ""
+
+// filterFields holds the fields of an event which can be used in a filter.
+type filterFields struct {
+	component string
+	group     string
+	property  string
+	id        int64
+	level     string
+	time      float64
+	irq       bool
+	vals      [6]int64
+}
+
+// set sets the fields as variables of the expressions.
+func (f *filterFields) set() {
+	var v eval.Value
+	v.Compose(eval.String, 0, 0, f.component)
+	eval.SetVar("component", v)
+	v.Compose(eval.String, 0, 0, f.group)
+	eval.SetVar("group", v)
+	v.Compose(eval.String, 0, 0, f.property)
+	eval.SetVar("property", v)
+	v.Compose(eval.String, 0, 0, f.level)
+	eval.SetVar("level", v)
+	v.Compose(eval.Floating, 0, f.time, "")
+	eval.SetVar("time", v)
+	eval.SetVarI("id", f.id)
+	irq := int64(0)
+	if f.irq {
+		irq = 1
+	}
+	eval.SetVarI("irq", irq)
+	for i, val := range f.vals {
+		eval.SetVarI(fmt.Sprintf("val%d", i+1), val)
+	}
+}
+
+// match evaluates the filter with the fields of an event.
+//
+// Returns:
+//   - True if the event is shown.
+//   - An error if the filter cannot be evaluated.
+func (f *filterFields) match() (bool, error) {
+	if filter == "" {
+		return true, nil
+	}
+	f.set()
+	expr := filter
+	v, err := eval.Eval(&expr, nil, nil)
+	if errors.Is(err, eval.ErrEof) {
+		err = nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("filter %q: %w", strings.TrimSpace(filter), err)
+	}
+	switch {
+	case v.IsInteger():
+		return v.GetInt() != 0, nil
+	case v.IsFloating():
+		return v.GetFloat() != 0, nil
+	}
+	return false, fmt.Errorf("filter %q: %w", strings.TrimSpace(filter), errFilter)
+}
+
+// newFilterFields returns the fields of a decoded event.
+func newFilterFields(ev *event.Data, record *EventRecord, evdef *scvd.EventType) filterFields {
+	return filterFields{
+		component: record.Component,
+		group:     evdef.Component.Group,
+		property:  record.EventProperty,
+		id:        int64(ev.Info.ID),
+		level:     evdef.Level,
+		time:      record.Time,
+		irq:       ev.Info.IRQ(),
+		vals:      ev.Values(),
+	}
+}
+
+// SetFilter sets the expression selecting the events to be shown. It can
+// use the fields of an event:
+//
+//	component  the component name (string)
+//	group      the group of the component in the SCVD file (string)
+//	property   the event property (string)
+//	id         the event ID
+//	level      the level of the event definition (string)
+//	time       the time in seconds
+//	irq        1 if the event was recorded in an interrupt service routine
+//	val1..val6 the values of the event, for events with data its 32-bit words
+//
+// For example: property == "ThreadSwitched" && val1 == 3 && time >= 2.1 && time < 2.5
+//
+// Parameters:
+//   - expr: The filter expression, empty to show all events.
+//
+// Returns:
+//   - An error if the expression is invalid.
+func SetFilter(expr string) error {
+	filter = expr
+	if strings.TrimSpace(expr) == "" {
+		filter = ""
+		return nil
+	}
+	var f filterFields
+	if _, err := f.match(); err != nil {
+		filter = ""
+		return err
+	}
+	return nil
+}
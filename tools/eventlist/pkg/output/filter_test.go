@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetFilter(t *testing.T) { //nolint:golint,paralleltest
+	fields := `component == "RTX" && group == "RTOS" && property != "" && level == "Op" && id == 0xF404 && !irq`
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"blank", "  ", "", false},
+		{"fields", fields, fields, false},
+		{"time", "time >= 2.1 && time < 2.5", "time >= 2.1 && time < 2.5", false},
+		{"values", "val1 == 3 || val6 > 0", "val1 == 3 || val6 > 0", false},
+		{"unknown", "thread == 3", "", true},
+		{"type", "component == 3", "", true},
+		{"string", `"RTX"`, "", true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetFilter(tt.expr); (err != nil) != tt.wantErr {
+				t.Errorf("SetFilter() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if filter != tt.want {
+				t.Errorf("SetFilter() %s filter = %q, want %q", tt.name, filter, tt.want)
+			}
+		})
+	}
+	if err := SetFilter(`"RTX"`); !errors.Is(err, errFilter) {
+		t.Errorf("SetFilter() error = %v, want %v", err, errFilter)
+	}
+}
+
+func TestOutput_decodeFilter(t *testing.T) { //nolint:golint,paralleltest
+	defer func() { filter = "" }()
+
+	eds := make(scvd.Events)
+	eds[0xFF03] = scvd.EventType{Brief: "EvrRec", Property: "Clock", Value: "value", Level: "Op",
+		Component: scvd.ComponentInfo{Group: "Recorder Control", Name: "Event Recorder Control"}}
+
+	file := "../../testdata/test10.binary"
+	line1 := "    0 0.00000124 EvrRec    Clock          value\n"
+	line2 := "    1 0.00000124 0xFE      0xFE00         \"hello wo\"\n"
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    string
+		wantErr bool
+	}{
+		{"all", "", line1 + line2, false},
+		{"id", "id == 0xFE00", line2, false},
+		{"component", `component == "EvrRec"`, line1, false},
+		{"group", `group == "Recorder Control"`, line1, false},
+		{"property", `property != "Clock"`, line2, false},
+		{"level", `level == "Op"`, line1, false},
+		{"value", "val1 == 4 && val2 == 2", line1, false},
+		{"data", "val1 == 0x6C6C6568 && val3 == 0", line2, false},
+		{"time", "time > 0.000001 && time < 0.000002", line1 + line2, false},
+		{"irq", "irq", "", false},
+		{"error", "10 / val3", "", true},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			filter = tt.filter
+			TimeFactor = nil
+			var ib event.Binary
+			in := ib.Open(&file)
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(eds)
+			_, err := o.decode(in, eds, nil, newRecordWriter(o, out))
+			ib.Close()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Output.decode() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.decode() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrint_filterTime(t *testing.T) { //nolint:golint,paralleltest
+	defer func() { filter = "" }()
+
+	file := "../../testdata/test10.binary"
+	formatType := "txt"
+	level := ""
+	line0 := "    0 7.75000000 0xFF      0xFF03         val1=0x00000004, val2=0x00000002\n"
+	line1 := "    1 7.75000000 0xFE      0xFE00         \"hello wo\"\n"
+
+	// the filter uses the time which is shown
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{"shown time", "time > 7.7 && time < 7.8", []string{line0, line1}},
+		{"before", "time < 1", nil},
+		{"id", "id == 0xFE00 && time >= 7.75", []string{line1}},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			filter = tt.filter
+			TimeFactor = nil
+			name := filepath.Join(t.TempDir(), "out.txt")
+			if err := Print(&name, &formatType, &level, &file, nil, nil, false, false); err != nil {
+				t.Fatalf("Print() %s error = %v", tt.name, err)
+			}
+			data, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, line := range strings.SplitAfter(string(data), "\n") {
+				if strings.HasPrefix(line, "    ") {
+					got = append(got, line)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Print() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"eventlist/pkg/xml/scvd"
+)
+
+// ComponentRecord is a component of the shown events with its group and
+// the metadata of its SCVD definition.
+type ComponentRecord struct {
+	Group  string `json:"group" xml:"group"`
+	Name   string `json:"name" xml:"name"`
+	Brief  string `json:"brief" xml:"brief"`
+	Prefix string `json:"prefix,omitempty" xml:"prefix,omitempty"`
+	Info   string `json:"info,omitempty" xml:"info,omitempty"`
+}
+
+// componentList collects the components of the shown events in the order
+// of their first event, it is only used with Hierarchy.
+type componentList struct {
+	seen map[string]bool // group and name of the collected components
+	list []ComponentRecord
+}
+
+// add collects the component of an event definition. Events of SCVD files
+// without component definitions are ignored.
+//
+// Parameters:
+//   - evdef: The event definition.
+func (c *componentList) add(evdef *scvd.EventType) {
+	info := evdef.Component
+	if info.Name == "" {
+		return
+	}
+	key := info.Group + "/" + info.Name
+	if c.seen[key] {
+		return
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]bool)
+	}
+	c.seen[key] = true
+	c.list = append(c.list, ComponentRecord{
+		Group:  info.Group,
+		Name:   info.Name,
+		Brief:  evdef.Brief,
+		Prefix: info.Prefix,
+		Info:   info.Info,
+	})
+}
+
+// printComponents lists the components of the shown events, in text format
+// as section with the components below their groups, otherwise in the
+// events table. Nothing is written without Hierarchy or components.
+//
+// Parameters:
+//   - out: The buffered writer receiving the text.
+//   - eventTable: The events table receiving the components.
+//
+// Returns:
+//   - An error if writing fails.
+func (o *Output) printComponents(out *bufio.Writer, eventTable *EventsTable) error {
+	if o.components == nil || len(o.components.list) == 0 {
+		return nil
+	}
+	eventTable.Components = o.components.list
+	if err := conditionalWrite(out, "   Component hierarchy\n   -------------------\n\n"); err != nil {
+		return err
+	}
+	var groups []string
+	byGroup := make(map[string][]ComponentRecord)
+	for _, c := range o.components.list {
+		if _, ok := byGroup[c.Group]; !ok {
+			groups = append(groups, c.Group)
+		}
+		byGroup[c.Group] = append(byGroup[c.Group], c)
+	}
+	for _, g := range groups {
+		if err := conditionalWrite(out, "%s\n", g); err != nil {
+			return err
+		}
+		for _, c := range byGroup[g] {
+			text := "    " + c.Name + " (" + c.Brief
+			if c.Prefix != "" {
+				text += ", prefix " + c.Prefix
+			}
+			text += ")"
+			if c.Info != "" {
+				text += ": " + c.Info
+			}
+			if err := conditionalWrite(out, "%s\n", text); err != nil {
+				return err
+			}
+		}
+	}
+	return conditionalWrite(out, "\n")
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"eventlist/pkg/xml/scvd"
+	"reflect"
+	"testing"
+)
+
+func TestOutput_printComponents(t *testing.T) { //nolint:golint,paralleltest
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+
+	ctrl := scvd.EventType{Brief: "EvCtrl", Component: scvd.ComponentInfo{Group: "Recorder Control", Name: "Event Recorder Control", Info: "Event Recorder Control Events"}}
+	stat := scvd.EventType{Brief: "EvStat", Component: scvd.ComponentInfo{Group: "Event Statistics", Name: "Start/Stop Statistics", Prefix: "Event"}}
+	other := scvd.EventType{Brief: "EvOther", Component: scvd.ComponentInfo{Group: "Recorder Control", Name: "Other"}}
+	noComponent := scvd.EventType{Brief: "X"}
+
+	list := []ComponentRecord{
+		{Group: "Recorder Control", Name: "Event Recorder Control", Brief: "EvCtrl", Info: "Event Recorder Control Events"},
+		{Group: "Event Statistics", Name: "Start/Stop Statistics", Brief: "EvStat", Prefix: "Event"},
+		{Group: "Recorder Control", Name: "Other", Brief: "EvOther"},
+	}
+	text := "   Component hierarchy\n   -------------------\n\n" +
+		"Recorder Control\n" +
+		"    Event Recorder Control (EvCtrl): Event Recorder Control Events\n" +
+		"    Other (EvOther)\n" +
+		"Event Statistics\n" +
+		"    Start/Stop Statistics (EvStat, prefix Event)\n\n"
+
+	tests := []struct {
+		name       string
+		format     string
+		components *componentList
+		evdefs     []scvd.EventType
+		want       string
+		table      []ComponentRecord
+	}{
+		{"no hierarchy", "txt", nil, nil, "", nil},
+		{"no components", "txt", &componentList{}, []scvd.EventType{noComponent}, "", nil},
+		{"txt", "txt", &componentList{}, []scvd.EventType{ctrl, stat, ctrl, noComponent, other}, text, list},
+		{"json", "json", &componentList{}, []scvd.EventType{ctrl, stat, other, stat}, "", list},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			FormatType = tt.format
+			o := &Output{components: tt.components}
+			for i := range tt.evdefs {
+				o.components.add(&tt.evdefs[i])
+			}
+			var table EventsTable
+			if err := o.printComponents(out, &table); err != nil {
+				t.Errorf("Output.printComponents() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.printComponents() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			if !reflect.DeepEqual(table.Components, tt.table) {
+				t.Errorf("Output.printComponents() %s table = %v, want %v", tt.name, table.Components, tt.table)
+			}
+		})
+	}
+}
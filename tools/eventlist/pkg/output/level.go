@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errLevel = errors.New("invalid level")
+
+// levels are the levels of the event definitions, the most important first.
+var levels = [...]string{"Error", "API", "Op", "Detail"}
+
+// noLevel names the events without level, e.g. events without definition.
+const noLevel = "none"
+
+// EventsDropped is the number of events of a level dropped by the level filter.
+type EventsDropped struct {
+	Level string `json:"level" xml:"level"`
+	Count int    `json:"count" xml:"count"`
+}
+
+// levelFilter is the set of levels of the events to be shown, nil for all events.
+type levelFilter map[string]bool
+
+// levelIndex looks up a level in levels, not case sensitive.
+//
+// Returns:
+//   - The index in levels, -1 if the level is unknown.
+func levelIndex(name string) int {
+	for i, l := range levels {
+		if strings.EqualFold(name, l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseLevel parses the levels of the events to be shown: a comma separated
+// list of levels or thresholds. A threshold >=level selects the level and all
+// more important levels, e.g. >=Op selects Error, API and Op.
+//
+// Parameters:
+//   - s: The levels, empty for all events.
+//
+// Returns:
+//   - The level filter, nil for all events.
+//   - An error if a level is unknown.
+func parseLevel(s string) (levelFilter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	f := make(levelFilter)
+	for _, item := range strings.Split(s, ",") {
+		name := strings.TrimSpace(item)
+		threshold := strings.HasPrefix(name, ">=")
+		if threshold {
+			name = strings.TrimSpace(name[2:])
+		}
+		i := levelIndex(name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", errLevel, strings.TrimSpace(item))
+		}
+		if threshold {
+			for _, l := range levels[:i+1] {
+				f[l] = true
+			}
+		} else {
+			f[levels[i]] = true
+		}
+	}
+	return f, nil
+}
+
+// level returns the name of a level used by the filter and its report.
+func level(name string) string {
+	if i := levelIndex(strings.TrimSpace(name)); i >= 0 {
+		return levels[i]
+	}
+	return noLevel
+}
+
+// match checks if the events of a level are shown.
+func (f levelFilter) match(name string) bool {
+	return f == nil || f[level(name)]
+}
+
+// droppedEvents returns the number of events dropped by the level filter
+// for each level, in the order of levels.
+func (o *Output) droppedEvents() []EventsDropped {
+	var dropped []EventsDropped
+	for _, l := range append(levels[:], noLevel) {
+		if n := o.dropped[l]; n != 0 {
+			dropped = append(dropped, EventsDropped{Level: l, Count: n})
+		}
+	}
+	return dropped
+}
+
+// printDropped reports the events dropped by the level filter, in text
+// format as single line, otherwise in the events table.
+//
+// Parameters:
+//   - out: The buffered writer receiving the text.
+//   - eventTable: The events table receiving the dropped events.
+//
+// Returns:
+//   - An error if writing fails.
+func (o *Output) printDropped(out *bufio.Writer, eventTable *EventsTable) error {
+	if o.levels == nil {
+		return nil
+	}
+	eventTable.Dropped = o.droppedEvents()
+	var total int
+	counts := make([]string, 0, len(eventTable.Dropped))
+	for _, d := range eventTable.Dropped {
+		total += d.Count
+		counts = append(counts, fmt.Sprintf("%s: %d", d.Level, d.Count))
+	}
+	text := fmt.Sprintf("   Level filter %s: %d events dropped", Level, total)
+	if len(counts) != 0 {
+		text += " (" + strings.Join(counts, ", ") + ")"
+	}
+	return conditionalWrite(out, "%s\n\n", text)
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"reflect"
+	"testing"
+)
+
+func Test_parseLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    levelFilter
+		wantErr error
+	}{
+		{"empty", "", nil, nil},
+		{"one", "Op", levelFilter{"Op": true}, nil},
+		{"list", "error, detail", levelFilter{"Error": true, "Detail": true}, nil},
+		{"threshold", ">=Op", levelFilter{"Error": true, "API": true, "Op": true}, nil},
+		{"threshold error", ">= Error", levelFilter{"Error": true}, nil},
+		{"threshold list", ">=API,Detail", levelFilter{"Error": true, "API": true, "Detail": true}, nil},
+		{"unknown", "Op,Debug", nil, errLevel},
+		{"empty item", "Op,", nil, errLevel},
+		{"threshold unknown", ">=", nil, errLevel},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseLevel(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseLevel() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLevel() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_levelFilter_match(t *testing.T) {
+	t.Parallel()
+
+	f := levelFilter{"Error": true, "Op": true}
+	tests := []struct {
+		name  string
+		f     levelFilter
+		level string
+		want  bool
+	}{
+		{"all", nil, "Detail", true},
+		{"all none", nil, "", true},
+		{"match", f, "Op", true},
+		{"case", f, "op", true},
+		{"no match", f, "API", false},
+		{"none", f, "", false},
+		{"unknown", f, "Debug", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.f.match(tt.level); got != tt.want {
+				t.Errorf("levelFilter.match() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutput_decodeLevel(t *testing.T) { //nolint:golint,paralleltest
+	eds := make(scvd.Events)
+	eds[0xFF03] = scvd.EventType{Brief: "EvrRec", Property: "Clock", Value: "value", Level: "Detail"}
+	eds[0xEF00] = scvd.EventType{Brief: "EvrStat", Property: "Start", Value: "value", Level: "Op"}
+
+	line0 := "    0 0.00000124 EvrRec    Clock          value\n"
+	line1 := "    1 0.00000124 0xFE      0xFE00         \"hello wo\"\n"
+	start := "    0 0.00000124 EvrStat   Start          value\n"
+
+	tests := []struct {
+		name    string
+		file    string
+		level   string
+		want    string
+		started bool
+		dropped []EventsDropped
+	}{
+		{"all", "../../testdata/test10.binary", "", line0 + line1, false, nil},
+		{"detail", "../../testdata/test10.binary", "Detail", line0, false, []EventsDropped{{noLevel, 1}}},
+		{"threshold", "../../testdata/test10.binary", ">=Op", "", false, []EventsDropped{{"Detail", 1}, {noLevel, 1}}},
+		{"statistic", "../../testdata/test7.binary", ">=Op", start, true, nil},
+		{"statistic dropped", "../../testdata/test7.binary", "Error", "", false, []EventsDropped{{"Op", 1}}},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			TimeFactor = nil
+			var ib event.Binary
+			in := ib.Open(&tt.file)
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(eds)
+			o.levels, _ = parseLevel(tt.level)
+			if _, err := o.decode(in, eds, nil, newRecordWriter(o, out)); err != nil {
+				t.Errorf("Output.decode() %s error = %v", tt.name, err)
+			}
+			ib.Close()
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.decode() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			if o.evProps[0].values[0].evStart != tt.started {
+				t.Errorf("Output.decode() %s started = %v, want %v", tt.name, o.evProps[0].values[0].evStart, tt.started)
+			}
+			if got := o.droppedEvents(); !reflect.DeepEqual(got, tt.dropped) {
+				t.Errorf("Output.droppedEvents() %s = %v, want %v", tt.name, got, tt.dropped)
+			}
+		})
+	}
+}
+
+func TestOutput_printDropped(t *testing.T) { //nolint:golint,paralleltest
+	savedLevel := Level
+	defer func() { Level = savedLevel }()
+
+	tests := []struct {
+		name    string
+		level   string
+		dropped map[string]int
+		want    string
+	}{
+		{"no filter", "", map[string]int{"Op": 1}, ""},
+		{"nothing dropped", "Op", map[string]int{}, "   Level filter Op: 0 events dropped\n\n"},
+		{"dropped", ">=API", map[string]int{noLevel: 2, "Detail": 3, "Op": 1},
+			"   Level filter >=API: 6 events dropped (Op: 1, Detail: 3, none: 2)\n\n"},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			Level = tt.level
+			o := &Output{dropped: tt.dropped}
+			o.levels, _ = parseLevel(tt.level)
+			var table EventsTable
+			if err := o.printDropped(out, &table); err != nil {
+				t.Errorf("Output.printDropped() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.printDropped() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"os"
+)
+
+// LintReport describes the layout of the JSON and XML output of the SCVD lint.
+type LintReport struct {
+	Problems []scvd.Problem `json:"problems" xml:"problem"`
+}
+
+// printLint writes the problems of the SCVD files in the global FormatType,
+// the text format lists one problem per line followed by their number.
+func printLint(out *bufio.Writer, r *LintReport) error {
+	switch FormatType {
+	case "json":
+		if r.Problems == nil {
+			r.Problems = []scvd.Problem{}
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case "xml":
+		enc := xml.NewEncoder(out)
+		if err := enc.EncodeElement(r, xml.StartElement{Name: xml.Name{Local: "Lint"}}); err != nil {
+			return err
+		}
+		return enc.Flush()
+	}
+
+	if _, err := fmt.Fprint(out, "   SCVD lint\n   ---------\n\n"); err != nil {
+		return err
+	}
+	for _, p := range r.Problems {
+		if _, err := fmt.Fprintf(out, "  %s\n", p); err != nil {
+			return err
+		}
+	}
+	if len(r.Problems) != 0 {
+		if _, err := fmt.Fprintln(out); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "  Problems: %d\n", len(r.Problems))
+	return err
+}
+
+// PrintLint writes the problems of the SCVD files to a specified file or
+// standard output in a given format.
+//
+// Parameters:
+//   - filename: Pointer to the name of the file where the output will be written. If nil or empty, output is written to stdout.
+//   - formatType: Pointer to the format of the problem list, one of ReportFormats. If nil or empty, the current format is used.
+//   - problems: The problems of the SCVD files.
+//
+// Returns:
+//   - error: An error if the format is not supported or the file could not be created or written to.
+func PrintLint(filename *string, formatType *string, problems []scvd.Problem) error {
+	var file *os.File
+	var err error
+
+	if err = setFormat(formatType, ReportFormats); err != nil {
+		return err
+	}
+	if filename != nil && len(*filename) != 0 {
+		if file, err = createOutput(*filename); err != nil {
+			return err
+		}
+		defer file.Close()
+	} else {
+		file = os.Stdout
+	}
+
+	out := bufio.NewWriter(file)
+	err = printLint(out, &LintReport{Problems: problems})
+	if err == nil {
+		err = out.Flush()
+	}
+	return err
+}
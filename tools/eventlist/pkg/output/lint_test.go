@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"eventlist/pkg/xml/scvd"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrintLint(t *testing.T) { //nolint:golint,paralleltest
+	problems := []scvd.Problem{
+		{Pos: scvd.Position{File: "a.scvd", Line: 17, Column: 24}, Message: "unknown attribute colour of <component>"},
+		{Pos: scvd.Position{File: "b.scvd"}, Message: "bad"},
+	}
+
+	txt := "   SCVD lint\n" +
+		"   ---------\n\n" +
+		"  a.scvd:17:24: unknown attribute colour of <component>\n" +
+		"  b.scvd: bad\n\n" +
+		"  Problems: 2\n"
+	txtNone := "   SCVD lint\n" +
+		"   ---------\n\n" +
+		"  Problems: 0\n"
+	json := "{\"problems\":[{\"position\":{\"file\":\"a.scvd\",\"line\":17,\"column\":24},\"message\":\"unknown attribute colour of \\u003ccomponent\\u003e\"}," +
+		"{\"position\":{\"file\":\"b.scvd\",\"line\":0},\"message\":\"bad\"}]}"
+	xml := "<Lint><problem><position><file>a.scvd</file><line>17</line><column>24</column></position>" +
+		"<message>unknown attribute colour of &lt;component&gt;</message></problem>" +
+		"<problem><position><file>b.scvd</file><line>0</line></position><message>bad</message></problem></Lint>"
+
+	tests := []struct {
+		name     string
+		format   string
+		problems []scvd.Problem
+		want     string
+	}{
+		{"txt", "txt", problems, txt},
+		{"txt none", "txt", nil, txtNone},
+		{"json", "json", problems, json},
+		{"json none", "json", nil, "{\"problems\":[]}"},
+		{"xml", "xml", problems, xml},
+	}
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			FormatType = "txt"
+			file := filepath.Join(t.TempDir(), "lint.txt")
+			if err := PrintLint(&file, &tt.format, tt.problems); err != nil {
+				t.Fatalf("PrintLint() %s error = %v", tt.name, err)
+			}
+			got, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("PrintLint() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	csv := "csv"
+	if err := PrintLint(nil, &csv, problems); err == nil {
+		t.Errorf("PrintLint() csv error = nil")
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrFormat = errors.New("invalid output format")
+var ErrOutput = errors.New("cannot create output")
+
+// Formats are the output formats of the event list.
+var Formats = []string{"txt", "json", "xml", "csv", "tsv", "perfetto", "ctf", "vcd"}
+
+// ReportFormats are the output formats of the reports: the fault information,
+// the component views, the lint problems and the definition conflicts.
+var ReportFormats = []string{"txt", "json", "xml"}
+
+// CheckFormat checks an output format.
+//
+// Parameters:
+//   - formatType: The format type, empty for the default format.
+//   - formats: The supported formats, Formats or ReportFormats.
+//
+// Returns:
+//   - An error wrapping ErrFormat if the format is not supported.
+func CheckFormat(formatType string, formats []string) error {
+	if formatType == "" {
+		return nil
+	}
+	for _, f := range formats {
+		if formatType == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrFormat, formatType)
+}
+
+// setFormat sets the global FormatType, it is unchanged if no format is given.
+//
+// Parameters:
+//   - formatType: Pointer to the format type, nil or empty for the current format.
+//   - formats: The supported formats.
+//
+// Returns:
+//   - An error wrapping ErrFormat if the format is not supported.
+func setFormat(formatType *string, formats []string) error {
+	if formatType == nil || *formatType == "" {
+		return nil
+	}
+	if err := CheckFormat(*formatType, formats); err != nil {
+		return err
+	}
+	FormatType = *formatType
+	return nil
+}
+
+// CheckLevel checks the levels of the level filter, see the -l option.
+//
+// Parameters:
+//   - level: The levels, empty for all events.
+//
+// Returns:
+//   - An error if a level is unknown.
+func CheckLevel(level string) error {
+	_, err := parseLevel(level)
+	return err
+}
+
+// CheckColumns checks the columns selected for the csv and tsv formats.
+//
+// Parameters:
+//   - columns: The comma separated column names, empty for all columns.
+//   - statistic: true if the statistics table is written instead of the events.
+//
+// Returns:
+//   - An error if a column is not part of the table.
+func CheckColumns(columns string, statistic bool) error {
+	_, err := parseColumns(columns, statistic)
+	return err
+}
+
+// createOutput creates the output file.
+//
+// Parameters:
+//   - name: The file name.
+//
+// Returns:
+//   - The created file.
+//   - An error wrapping ErrOutput if the file cannot be created.
+func createOutput(name string) (*os.File, error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOutput, err)
+	}
+	return file, nil
+}
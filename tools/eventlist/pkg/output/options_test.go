@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		formatType string
+		formats    []string
+		wantErr    error
+	}{
+		{"default", "", Formats, nil},
+		{"csv", "csv", Formats, nil},
+		{"unknown", "yaml", Formats, ErrFormat},
+		{"case", "JSON", Formats, ErrFormat},
+		{"report", "json", ReportFormats, nil},
+		{"report csv", "csv", ReportFormats, ErrFormat},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := CheckFormat(tt.formatType, tt.formats); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckFormat() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_setFormat(t *testing.T) { //nolint:golint,paralleltest
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+
+	empty := ""
+	xml := "xml"
+	csv := "csv"
+	tests := []struct {
+		name       string
+		formatType *string
+		formats    []string
+		want       string
+		wantErr    error
+	}{
+		{"nil", nil, Formats, "json", nil},
+		{"empty", &empty, Formats, "json", nil},
+		{"xml", &xml, Formats, "xml", nil},
+		{"unsupported", &csv, ReportFormats, "json", ErrFormat},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			FormatType = "json"
+			if err := setFormat(tt.formatType, tt.formats); !errors.Is(err, tt.wantErr) {
+				t.Errorf("setFormat() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if FormatType != tt.want {
+				t.Errorf("setFormat() %s FormatType = %s, want %s", tt.name, FormatType, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLevel(t *testing.T) {
+	t.Parallel()
+
+	if err := CheckLevel(">=Op"); err != nil {
+		t.Errorf("CheckLevel() error = %v", err)
+	}
+	if err := CheckLevel("Op,Debug"); !errors.Is(err, errLevel) {
+		t.Errorf("CheckLevel() error = %v, want %v", err, errLevel)
+	}
+}
+
+func TestCheckColumns(t *testing.T) {
+	t.Parallel()
+
+	if err := CheckColumns("count,total", true); err != nil {
+		t.Errorf("CheckColumns() error = %v", err)
+	}
+	if err := CheckColumns("count,total", false); !errors.Is(err, errColumn) {
+		t.Errorf("CheckColumns() error = %v, want %v", err, errColumn)
+	}
+}
+
+func Test_createOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file, err := createOutput(filepath.Join(dir, "out.txt"))
+	if err != nil {
+		t.Fatalf("createOutput() error = %v", err)
+	}
+	file.Close()
+	_, err = createOutput(filepath.Join(dir, "nix", "out.txt"))
+	if !errors.Is(err, ErrOutput) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("createOutput() error = %v, want %v", err, ErrOutput)
+	}
+}
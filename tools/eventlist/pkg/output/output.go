@@ -20,24 +20,35 @@ package output
 
 import (
 	"bufio"
-	"bytes"
-	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"eventlist/pkg/eval"
 	"eventlist/pkg/event"
 	"eventlist/pkg/xml/scvd"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"path/filepath"
 )
 
-var errNoEvents = errors.New("cannot open event file")
+var ErrNoEvents = errors.New("cannot open event file")
 
 var TimeFactor *float64
 var FormatType = "txt"
 var Level = ""
 
+// Live is set for event streams: every record is written as soon as it is
+// decoded and the statistics are printed when the stream ends.
+var Live = false
+
+// Hierarchy is set to show the component of an event as group/component
+// path of the SCVD files instead of the short component name.
+var Hierarchy = false
+
+// Resync is set for damaged log files: data which is not a plausible record
+// is skipped up to the next plausible record and reported as diagnostic.
+var Resync = false
+
 func TimeInSecs(time uint64) float64 {
 	if TimeFactor == nil {
 		return 4e-8 * float64(time) // default
@@ -68,11 +79,17 @@ type eventStatistic struct {
 }
 
 type EventRecord struct {
-	Index         int     `json:"index" xml:"index"`
-	Time          float64 `json:"time" xml:"time"`
-	Component     string  `json:"component" xml:"component"`
-	EventProperty string  `json:"eventProperty" xml:"eventProperty"`
-	Value         string  `json:"value" xml:"value"`
+	Index         int         `json:"index" xml:"index"`
+	Time          float64     `json:"time" xml:"time"`
+	Component     string      `json:"component" xml:"component"`
+	EventProperty string      `json:"eventProperty" xml:"eventProperty"`
+	Value         string      `json:"value" xml:"value"`
+	Alert         bool        `json:"alert,omitempty" xml:"alert,omitempty"`
+	Bold          bool        `json:"bold,omitempty" xml:"bold,omitempty"`
+	quoted        bool        // value is printed in quotes in text format
+	id            scvd.IDType // event ID, e.g. for the start/stop events
+	irq           bool        // recorded in an interrupt service routine
+	raw           *event.Data // the decoded event, e.g. for the CTF payload
 }
 
 type EventRecordStatistic struct {
@@ -99,9 +116,17 @@ type EventRecordStatistic struct {
 	TextMaxE    string  `json:"textMaxE" xml:"textMaxE"`
 }
 
+// EventsTable describes the layout of the JSON and XML output. The events
+// are written one by one while decoding, only the statistics are collected.
 type EventsTable struct {
-	Events     []EventRecord          `json:"events" xml:"events"`
-	Statistics []EventRecordStatistic `json:"statistics" xml:"statistics"`
+	Events           []EventRecord          `json:"events" xml:"events"`
+	Statistics       []EventRecordStatistic `json:"statistics" xml:"statistics"`
+	Dropped          []EventsDropped        `json:"dropped,omitempty" xml:"dropped,omitempty"`
+	States           []HandleStates         `json:"states,omitempty" xml:"states,omitempty"`
+	Diagnostics      []Diagnostic           `json:"diagnostics,omitempty" xml:"diagnostics,omitempty"`
+	DiagnosticCounts []DiagnosticCount      `json:"diagnosticCounts,omitempty" xml:"diagnosticCounts,omitempty"`
+	Timestamps       *Timestamps            `json:"timestamps,omitempty" xml:"timestamps,omitempty"`
+	Components       []ComponentRecord      `json:"components,omitempty" xml:"components,omitempty"`
 }
 
 // init initializes the eventStatistic struct by setting default values for its fields.
@@ -375,94 +400,306 @@ func (ep *eventProperty) getLast(idx uint16) string {
 }
 
 type Output struct {
-	evProps       [4]eventProperty
-	columns       []string
-	componentSize int
-	propertySize  int
+	evProps          [4]eventProperty
+	columns          []string
+	componentSize    int
+	propertySize     int
+	beforeClockEvent float64
+	lastClockEvent   uint64
+	highlight        bool // alert and bold records are highlighted by ANSI escape sequences
+	levels           levelFilter
+	dropped          map[string]int // events dropped by the level filter per level
+	dir              string         // directory of the CTF trace
+	states           *stateTracker  // states of the handles, nil if not tracked
+	csvColumns       []string       // columns of the csv and tsv formats
+	statisticOnly    bool           // the statistic is shown but no events
+	diagnostics      []Diagnostic   // first problems of decoding the events
+	diagnosticCounts map[string]int // problems of decoding the events per kind
+	timestamps       timestampTracker
+	components       *componentList // components of the shown events, only with Hierarchy
+}
+
+// setWidths sets the component and property column widths of the text output
+// to the widths of the column headers and of the given event definitions.
+// The widths grow with the events of the log, see widen.
+//
+// Parameters:
+//   - evdefs: a map of event definitions (scvd.Events), nil for the header widths only.
+func (o *Output) setWidths(evdefs scvd.Events) {
+	o.componentSize = len(o.columns[2]) // use minimum width of header
+	o.propertySize = len(o.columns[3])
+	for _, evdef := range evdefs {
+		evdef := evdef
+		o.widen(&evdef)
+	}
+}
+
+// widen widens the component and property columns for an event definition.
+func (o *Output) widen(evdef *scvd.EventType) {
+	if component := componentName(evdef); len(component) > o.componentSize {
+		o.componentSize = len(component)
+	}
+	if len(evdef.Property) > o.propertySize {
+		o.propertySize = len(evdef.Property)
+	}
+}
+
+// componentName returns the component of an event definition: the short
+// name, with Hierarchy the group and the name of the component.
+func componentName(evdef *scvd.EventType) string {
+	if !Hierarchy || evdef.Component.Name == "" {
+		return evdef.Brief
+	}
+	if evdef.Component.Group == "" {
+		return evdef.Component.Name
+	}
+	return evdef.Component.Group + "/" + evdef.Component.Name
+}
+
+// updateClock processes the EventRecorderInitialize (ID 0xFF00) and
+// EventRecorderClock (ID 0xFF03) events which change the timestamp frequency
+// and returns the time of the event in seconds. The time of a clock event
+// itself is converted with the frequency valid before it.
+//
+// Parameters:
+//   - ev: the event to be processed.
+//
+// Returns:
+//
+//	The time of the event in seconds.
+func (o *Output) updateClock(ev *event.Data) float64 {
+	switch ev.Info.ID {
+	case 0xFF00: // EventRecorderInitialize
+		if ev.Value2 != 0 {
+			o.beforeClockEvent = TimeInSecs(ev.Time)
+			o.lastClockEvent = ev.Time
+			setClock(ev.Value2)
+		}
+	case 0xFF03: // EventRecorderClock
+		if ev.Value1 != 0 {
+			o.beforeClockEvent = TimeInSecs(ev.Time - o.lastClockEvent)
+			o.lastClockEvent = ev.Time
+			setClock(ev.Value1)
+		}
+	}
+	return o.beforeClockEvent + TimeInSecs(ev.Time-o.lastClockEvent)
 }
 
-// TODO: escape-sequeces for Color and Bold
+// setClock sets the time factor of a clock record.
+func setClock(freq int32) {
+	if TimeFactor == nil {
+		TimeFactor = new(float64)
+	}
+	*TimeFactor = 1.0 / float64(freq)
+}
+
+// clockFrequency returns the timestamp frequency set by a clock record, 0 for other events.
+func clockFrequency(ev *event.Data) int32 {
+	switch ev.Info.ID {
+	case 0xFF00: // EventRecorderInitialize
+		return ev.Value2
+	case 0xFF03: // EventRecorderClock
+		return ev.Value1
+	}
+	return 0
+}
 
-// buildStatistic processes events from the provided bufio.Reader and updates
-// the Output structure with statistics about the events. It reads events,
-// evaluates their properties, and updates the component and property sizes
-// based on the event definitions. It also handles specific event classes and
-// updates the event properties accordingly.
+// scan reads the events of a log file before they are decoded: the
+// columns are widened for the events with a definition and the time factor
+// is set by the last clock record of the log. The events before the clock
+// records are therefore shown with the frequency of the target.
 //
 // Parameters:
 //   - in: a bufio.Reader from which events are read.
 //   - evdefs: a map of event definitions (scvd.Events).
-//   - typedefs: a map of type definitions (eval.Typedefs).
+func (o *Output) scan(in *bufio.Reader, evdefs scvd.Events) {
+	var resync *event.Resync
+	if Resync {
+		resync = event.NewResync(in)
+	}
+	var freq int32
+	for {
+		var ev event.Data
+		var err error
+		if resync != nil {
+			err = resync.Read(&ev)
+		} else {
+			err = ev.Read(in)
+		}
+		if err != nil {
+			break
+		}
+		if evdef, ok := evdefs[ev.Info.ID]; ok {
+			o.widen(&evdef)
+		}
+		if f := clockFrequency(&ev); f != 0 {
+			freq = f
+		}
+	}
+	if freq != 0 {
+		setClock(freq)
+	}
+}
+
+// bufferInput copies the events of a reader to a temporary file, so they
+// can be read twice.
+//
+// Parameters:
+//   - in: a bufio.Reader from which events are read.
 //
 // Returns:
+//   - The temporary file, positioned at its start, the caller removes it.
+//   - An error if the file cannot be created or written.
+func bufferInput(in *bufio.Reader) (*os.File, error) {
+	file, err := os.CreateTemp("", "eventlist-*.log")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(file, in); err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return file, nil
+}
+
+// decode reads all events from the provided bufio.Reader in a single pass.
+// Every event is decoded once: it updates the start/stop statistics and
+// the tracked states of the handles and, if a record writer is given, is passed to the writer when it is not
+// filtered out by the filter expression. Events filtered out by the level
+// are dropped, they are neither shown nor part of the statistics. An event
+// which cannot be decoded is recorded as diagnostic and shown with its raw
+// values; a truncated record ends the decoding. The timestamps are
+// reconstructed if they wrap around or decrease. The time of an event is
+// computed once and used for the output, the filter, the statistics and the
+// states.
 //
-//	The total number of events processed.
-func (o *Output) buildStatistic(in *bufio.Reader, evdefs scvd.Events, typedefs eval.Typedefs) int {
-	o.componentSize = len(o.columns[2]) // use minimum width of header
-	o.propertySize = len(o.columns[3])
+// Parameters:
+//   - in: a bufio.Reader from which events are read.
+//   - evdefs: a map of event definitions (scvd.Events).
+//   - typedefs: a map of type definitions (eval.Typedefs).
+//   - w: the writer receiving the event records, nil if only statistics are built.
+//
+// Returns:
+//   - The total number of events processed.
+//   - An error if reading the events or writing a record fails.
+func (o *Output) decode(in *bufio.Reader, evdefs scvd.Events, typedefs eval.Typedefs, w recordWriter) (int, error) {
 	for i := uint16(0); i < uint16(len(o.evProps)); i++ {
 		o.evProps[i].init()
 	}
-	var beforeClockEvent float64
-	var lastClockEvent uint64
+	o.beforeClockEvent = 0
+	o.lastClockEvent = 0
+	o.dropped = make(map[string]int)
+	o.diagnostics = nil
+	o.diagnosticCounts = nil
+	o.timestamps = timestampTracker{}
+	var resync *event.Resync
+	if Resync {
+		resync = event.NewResync(in)
+	}
 	var eventCount int
 	for {
 		var ev event.Data
-		if err := ev.Read(in); err != nil {
+		if err := o.read(&ev, in, resync, eventCount); err != nil {
+			if errors.Is(err, event.ErrTruncated) {
+				o.diagnose(eventCount, nil, diagTruncated, err.Error())
+				return eventCount, nil // the rest of the input cannot be decoded
+			}
 			if errors.Is(err, eval.ErrEof) {
-				break
+				return eventCount, nil // end of event data reached
 			}
-			fmt.Println(err)
-			return 0
+			return eventCount, err
+		}
+		ev.Time = o.timestamps.reconstruct(&ev)
+		record := EventRecord{
+			Index: eventCount,
+			Time:  o.updateClock(&ev),
+			id:    ev.Info.ID,
+			irq:   ev.Info.IRQ(),
+			raw:   &ev,
 		}
 		eventCount++
-		var evdef scvd.EventType
-		var ok bool
-		var rep string
-		if evdef, ok = evdefs[ev.Info.ID]; ok {
-			if len(evdef.Brief) > o.componentSize {
-				o.componentSize = len(evdef.Brief)
+
+		if o.states != nil {
+			o.states.end = record.Time
+		}
+		evdef, ok := evdefs[ev.Info.ID]
+		if !o.levels.match(evdef.Level) {
+			o.dropped[level(evdef.Level)]++
+			continue
+		}
+		if ok && o.states != nil {
+			o.states.update(&ev, &evdef, typedefs, record.Time)
+		}
+		show := w != nil
+		class, group, idx, start := ev.Info.SplitID()
+		if !show && class != 0xEF {
+			continue
+		}
+		if ok {
+			record.Component = componentName(&evdef)
+			record.EventProperty = evdef.Property
+		} else {
+			record.Component = fmt.Sprintf("0x%02X", uint8(ev.Info.ID>>8))
+			record.EventProperty = fmt.Sprintf("0x%04X", ev.Info.ID)
+		}
+		switch {
+		case ev.Info.ID == 0xFE00 && ev.Data != nil: // special case stdout
+			record.Value = escapeGen(string(*ev.Data))
+			record.quoted = true
+		case ok:
+			line, err := ev.EvalPrint(evdef, typedefs)
+			if err != nil {
+				o.diagnose(record.Index, &ev, diagnosticKind(err), err.Error())
+				line.Text = ev.GetValuesAsString()
 			}
-			if len(evdef.Property) > o.propertySize {
-				o.propertySize = len(evdef.Property)
+			record.Value = line.Text
+			record.Alert = line.Alert
+			record.Bold = line.Bold
+			if line.Property != "" {
+				record.EventProperty = line.Property
 			}
-			class, _, _, _ := ev.Info.SplitID()
-			switch class {
-			case 0xEF:
-				rep, _ = ev.EvalLine(evdef, typedefs)
+		default: // wrong or missing SCVD files
+			if len(evdefs) != 0 {
+				o.diagnose(record.Index, &ev, diagUnknownID, "no event definition")
 			}
+			record.Value = ev.GetValuesAsString()
 		}
-		class, group, idx, start := ev.Info.SplitID()
-		switch class {
-		case 0xEF:
-			if !ok { // rep not yet built up because of wrong or missing SCVD files
-				rep = ev.GetValuesAsString()
+		if class == 0xEF {
+			o.evProps[group].add(record.Time, idx, start, record.Value)
+		}
+		if show && filter != "" {
+			fields := newFilterFields(&ev, &record, &evdef)
+			var err error
+			if show, err = fields.match(); err != nil {
+				return eventCount, err
 			}
-			o.evProps[group].add(beforeClockEvent+TimeInSecs(ev.Time-lastClockEvent), idx, start, rep)
-		case 0xFF:
-			switch ev.Info.ID {
-			case 0xFF00: // EventRecorderInitialize
-				if ev.Value2 != 0 {
-					beforeClockEvent = TimeInSecs(ev.Time)
-					lastClockEvent = ev.Time
-					if TimeFactor == nil {
-						TimeFactor = new(float64)
-					}
-					*TimeFactor = 1.0 / float64(ev.Value2)
-				}
-			case 0xFF03: // EventRecorderClock
-				if ev.Value1 != 0 {
-					beforeClockEvent = TimeInSecs(ev.Time - lastClockEvent)
-					lastClockEvent = ev.Time
-					if TimeFactor == nil {
-						TimeFactor = new(float64)
-					}
-					*TimeFactor = 1.0 / float64(ev.Value1)
-				}
+		}
+		if show {
+			if ok && o.components != nil {
+				o.components.add(&evdef)
+			}
+			if err := w.write(&record); err != nil {
+				return eventCount, err
 			}
 		}
 	}
-	return eventCount
+}
+
+// read reads the next event, with resync the skipped data is reported as
+// diagnostic.
+func (o *Output) read(ev *event.Data, in *bufio.Reader, resync *event.Resync, index int) error {
+	if resync == nil {
+		return ev.Read(in)
+	}
+	err := resync.Read(ev)
+	for _, s := range resync.Skipped() {
+		o.diagnose(index, nil, diagSkipped, fmt.Sprintf("skipped %d bytes at offset 0x%X", s.Length, s.Offset))
+	}
+	return err
 }
 
 // conditionalWrite writes formatted data to the provided bufio.Writer
@@ -628,116 +865,6 @@ func escapeGen(s string) string {
 	return t
 }
 
-// printEvents reads events from the input buffer, processes them, and writes the formatted events to the output buffer.
-// It also updates the event table with the processed events.
-//
-// Parameters:
-//   - out: A buffered writer to which the formatted events will be written.
-//   - in: A buffered reader from which the events will be read.
-//   - evdefs: A map of event definitions used to interpret the events.
-//   - typedefs: A map of type definitions used for evaluating event data.
-//   - eventTable: A table to store the processed events.
-//
-// Returns:
-//   - error: An error if any occurs during reading, processing, or writing events.
-//
-// The function processes special events such as EventRecorderInitialize (ID 0xFF00) and Clock event (ID 0xFF03)
-// to adjust the time factor. It filters events based on the specified level and formats them accordingly.
-// The function handles special cases like stdout events (ID 0xFE00) differently.
-func (o *Output) printEvents(out *bufio.Writer, in *bufio.Reader, evdefs scvd.Events, typedefs eval.Typedefs, eventTable *EventsTable) error {
-	if out == nil || in == nil {
-		return nil
-	}
-	var err error
-	no := 0
-	var beforeClockEvent float64
-	var lastClockEvent uint64
-	for {
-		var ev event.Data
-		if err = ev.Read(in); err != nil {
-			if errors.Is(err, eval.ErrEof) {
-				err = nil
-				break // end of event data reached
-			}
-			fmt.Println(err)
-		}
-		if err != nil {
-			break
-		}
-		if ev.Info.ID == 0xFF00 { // EventRecorderInitialize
-			if ev.Value2 != 0 {
-				beforeClockEvent = TimeInSecs(ev.Time)
-				lastClockEvent = ev.Time
-				if TimeFactor == nil {
-					TimeFactor = new(float64)
-				}
-				*TimeFactor = 1.0 / float64(ev.Value2)
-			}
-		}
-		if ev.Info.ID == 0xFF03 { // Clock event
-			if ev.Value1 != 0 {
-				beforeClockEvent = TimeInSecs(ev.Time - lastClockEvent)
-				lastClockEvent = ev.Time
-				if TimeFactor == nil {
-					TimeFactor = new(float64)
-				}
-				*TimeFactor = 1.0 / float64(ev.Value1)
-			}
-		}
-		eventRecord := EventRecord{
-			Index: no,
-			Time:  beforeClockEvent + TimeInSecs(ev.Time-lastClockEvent),
-		}
-		var rep string
-		if evdef, ok := evdefs[ev.Info.ID]; ok {
-			// Filter events by level
-			if Level == "" || evdef.Level == Level {
-				eventRecord.Component = evdef.Brief
-				eventRecord.EventProperty = evdef.Property
-				if ev.Info.ID == 0xFE00 && ev.Data != nil { // special case stdout
-					s := escapeGen(string(*ev.Data))
-					eventRecord.Value = s
-					err = conditionalWrite(out, "%5d %.8f %*s %*s \"%s\"\n",
-						eventRecord.Index, eventRecord.Time, -o.componentSize,
-						eventRecord.Component, -o.propertySize, eventRecord.EventProperty, eventRecord.Value)
-				} else {
-					rep, err = ev.EvalLine(evdef, typedefs)
-					if err == nil {
-						eventRecord.Value = rep
-						err = conditionalWrite(out, "%5d %.8f %*s %*s %s\n",
-							eventRecord.Index, eventRecord.Time, -o.componentSize,
-							eventRecord.Component, -o.propertySize, eventRecord.EventProperty, eventRecord.Value)
-					}
-				}
-			}
-		} else {
-			eventRecord.Component = fmt.Sprintf("0x%02X%*s", uint8(ev.Info.ID>>8), 0, "")
-			eventRecord.EventProperty = fmt.Sprintf("0x%04X%*s", ev.Info.ID, 0, "")
-			if ev.Info.ID == 0xFE00 && ev.Data != nil { // special case stdout
-				s := escapeGen(string(*ev.Data))
-				eventRecord.Value = s
-				err = conditionalWrite(out, "%5d %.8f 0x%02X%*s 0x%04X%*s \"%s\"\n",
-					eventRecord.Index, eventRecord.Time,
-					uint8(ev.Info.ID>>8), -(o.componentSize - 4), "",
-					ev.Info.ID, -(o.propertySize - 6), "", eventRecord.Value)
-			} else {
-				rep = ev.GetValuesAsString()
-				eventRecord.Value = rep
-				err = conditionalWrite(out, "%5d %.8f 0x%02X%*s 0x%04X%*s %s\n",
-					eventRecord.Index, eventRecord.Time,
-					uint8(ev.Info.ID>>8), -(o.componentSize - 4), "",
-					ev.Info.ID, -(o.propertySize - 6), "", eventRecord.Value)
-			}
-		}
-		eventTable.Events = append(eventTable.Events, eventRecord)
-		if err != nil {
-			break
-		}
-		no++
-	}
-	return err
-}
-
 // printHeader writes the header section of the detailed event list to the provided bufio.Writer.
 // It includes the title, a separator line, and column headers formatted according to the Output struct's settings.
 //
@@ -766,84 +893,163 @@ func (o *Output) printHeader(out *bufio.Writer) error {
 	return err
 }
 
-// print generates and writes the output for the given event file and definitions.
-// It processes the events, builds statistics, and prints the event details and statistics
-// based on the provided flags.
+// print generates and writes the output for the given event stream and definitions.
+// The event stream is decoded once, the statistics are built while the events
+// are written to a temporary file. After decoding, the text columns have the
+// widths of the events and the time factor of the log is known, then the
+// events are written. In Live mode the events are written at once with the
+// widths of the definitions and the statistics always follow the events.
 //
 // Parameters:
 //   - out: A buffered writer to write the output.
-//   - eventFile: A pointer to the event file path string.
+//   - in: A buffered reader providing the events in log file format.
 //   - evdefs: Event definitions.
 //   - typedefs: Type definitions.
 //   - statBegin: A flag indicating whether to print statistics at the beginning.
 //   - showStatistic: A flag indicating whether to show statistics.
-//   - eventsTable: A pointer to the events table.
+//   - eventsTable: A pointer to the events table receiving the statistics.
 //
 // Returns:
 //   - An error if any operation fails, otherwise nil.
-func (o *Output) print(out *bufio.Writer, eventFile *string, evdefs scvd.Events,
+func (o *Output) print(out *bufio.Writer, in *bufio.Reader, evdefs scvd.Events,
 	typedefs eval.Typedefs, statBegin bool, showStatistic bool, eventsTable *EventsTable) error {
-	var b event.Binary
 	var err error
 	var eventCount int
 
 	o.columns = []string{"Index", "Time (s)", "Component", "Event Property", "Value"}
-
-	if eventFile == nil {
-		return errNoEvents
-	}
-	in := b.Open(eventFile)
-	if in != nil {
-		eventCount = o.buildStatistic(in, evdefs, typedefs)
-		err = b.Close()
+	if Live {
+		o.setWidths(evdefs)
 	} else {
-		err = errNoEvents
+		o.setWidths(nil)
+	}
+	o.states = newStateTracker()
+	o.statisticOnly = showStatistic
+	if Hierarchy {
+		o.components = &componentList{}
 	}
 
-	if err == nil && statBegin {
-		err = o.printStatistic(out, eventCount, eventsTable)
-		if err == nil && !showStatistic {
-			err = conditionalWrite(out, "\n")
+	if in == nil {
+		return ErrNoEvents
+	}
+	if Live {
+		statBegin = false
+	} else {
+		// the log is read twice, for the column widths and the time factor
+		var file *os.File
+		if file, err = bufferInput(in); err != nil {
+			return err
 		}
+		defer func() {
+			file.Close()
+			os.Remove(file.Name())
+		}()
+		o.scan(bufio.NewReader(file), evdefs)
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		in = bufio.NewReader(file)
 	}
 
-	if err == nil && !showStatistic {
-		err = o.printHeader(out)
-		if err == nil {
-			in = b.Open(eventFile)
-			if in != nil {
-				err = o.printEvents(out, in, evdefs, typedefs, eventsTable)
-				if err != nil {
-					_ = b.Close()
-				} else {
-					err = b.Close()
-				}
-			} else {
-				err = errNoEvents // cannot happen because eventFile already was read
+	w := newRecordWriter(o, out)
+	if Live {
+		w = &flushWriter{w, out}
+	}
+	if err = w.begin(); err != nil {
+		return err
+	}
+	var sink recordWriter
+	var spool *spoolWriter
+	if !showStatistic {
+		sink = w
+		if statBegin {
+			if spool, err = newSpoolWriter(); err != nil {
+				return err
 			}
+			defer spool.close()
+			sink = spool
+		} else if err = o.printHeader(out); err != nil {
+			return err
 		}
 	}
+	if eventCount, err = o.decode(in, evdefs, typedefs, sink); err != nil {
+		return err
+	}
 
+	if statBegin {
+		if err = o.printDiagnostics(out, eventsTable); err != nil {
+			return err
+		}
+		if err = o.printTimestamps(out, eventsTable); err != nil {
+			return err
+		}
+		if err = o.printDropped(out, eventsTable); err != nil {
+			return err
+		}
+		if err = o.printStatistic(out, eventCount, eventsTable); err != nil {
+			return err
+		}
+		if err = o.printStates(out, eventsTable); err != nil {
+			return err
+		}
+		if err = o.printComponents(out, eventsTable); err != nil {
+			return err
+		}
+		if !showStatistic {
+			err = conditionalWrite(out, "\n")
+		}
+	}
+	if err == nil && spool != nil {
+		if err = o.printHeader(out); err == nil {
+			err = spool.replay(w)
+		}
+	}
 	if err == nil && !statBegin {
 		if !showStatistic {
 			err = conditionalWrite(out, "\n")
 		}
+		if err == nil {
+			err = o.printDiagnostics(out, eventsTable)
+		}
+		if err == nil {
+			err = o.printTimestamps(out, eventsTable)
+		}
+		if err == nil {
+			err = o.printDropped(out, eventsTable)
+		}
 		if err == nil {
 			err = o.printStatistic(out, eventCount, eventsTable)
 		}
+		if err == nil {
+			err = o.printStates(out, eventsTable)
+		}
+		if err == nil {
+			err = o.printComponents(out, eventsTable)
+		}
+	}
+	if err == nil {
+		err = w.end(eventsTable)
 	}
 	if err == nil {
 		err = out.Flush()
 	}
+	if err == nil {
+		err = o.decodeError()
+	}
 	return err
 }
 
-// Print generates and writes event data to a specified file or standard output in a given format.
-// It supports XML and JSON formats and can include statistics if specified.
+// isTerminal checks if a file is a terminal, which can show ANSI escape sequences.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Print generates and writes event data of a log file to a specified file or standard output in a given format.
+// It supports the formats listed in Formats and can include statistics if specified.
 //
 // Parameters:
 //   - filename: Pointer to the name of the file where the output will be written. If nil or empty, output is written to stdout.
-//   - formatType: Pointer to the format type ("xml" or "json"). If nil or invalid, default format is used.
+//   - formatType: Pointer to the format type, one of Formats. If nil or empty, the current format is used.
 //   - level: Pointer to the level of detail for the output. If nil or empty, default level is used.
 //   - eventFile: Pointer to the event file name.
 //   - evdefs: Event definitions.
@@ -854,6 +1060,23 @@ func (o *Output) print(out *bufio.Writer, eventFile *string, evdefs scvd.Events,
 // Returns:
 //   - error: An error if the file could not be created or written to, or if there was an error during the output generation.
 func Print(filename *string, formatType *string, level *string, eventFile *string, evdefs scvd.Events,
+	typedefs eval.Typedefs, statBegin bool, showStatistic bool) error {
+	var b event.Binary
+
+	if eventFile == nil {
+		return ErrNoEvents
+	}
+	in := b.Open(eventFile)
+	if in == nil {
+		return ErrNoEvents
+	}
+	defer b.Close()
+	return PrintReader(filename, formatType, level, in, evdefs, typedefs, statBegin, showStatistic)
+}
+
+// PrintReader generates and writes the event data provided by a reader in log file format,
+// e.g. events reconstructed from a memory image. The parameters are the same as for Print.
+func PrintReader(filename *string, formatType *string, level *string, in *bufio.Reader, evdefs scvd.Events,
 	typedefs eval.Typedefs, statBegin bool, showStatistic bool) error {
 	var file *os.File
 	var err error
@@ -870,51 +1093,47 @@ func Print(filename *string, formatType *string, level *string, eventFile *strin
 	if *TimeFactor == 0.0 {
 		*TimeFactor = 4e-8
 	}
-	if formatType != nil {
-		if *formatType == "xml" || *formatType == "json" {
-			FormatType = *formatType
-		}
+	if err = setFormat(formatType, Formats); err != nil {
+		return err
 	}
-	if level != nil && *level != "" {
+	if level != nil {
 		Level = *level
 	}
+	if o.levels, err = parseLevel(Level); err != nil {
+		return err
+	}
+	if FormatType == "csv" || FormatType == "tsv" {
+		if o.csvColumns, err = parseColumns(Columns, showStatistic); err != nil {
+			return err
+		}
+	}
 
-	if filename != nil && len(*filename) != 0 {
-		if file, err = os.Create(*filename); err != nil {
+	name := filename
+	if FormatType == "ctf" {
+		// the trace is a directory with the metadata and the stream file
+		if filename == nil || len(*filename) == 0 {
+			return errCTFDir
+		}
+		if err = os.MkdirAll(*filename, 0o755); err != nil {
+			return fmt.Errorf("%w: %w", ErrOutput, err)
+		}
+		o.dir = *filename
+		name = new(string)
+		*name = filepath.Join(o.dir, ctfStream)
+	}
+	if name != nil && len(*name) != 0 {
+		if file, err = createOutput(*name); err != nil {
 			return err
 		}
 		defer file.Close()
 	} else {
 		file = os.Stdout
+		o.highlight = isTerminal(file)
 	}
 
 	out := bufio.NewWriter(file)
-	err = o.print(out, eventFile, evdefs, typedefs, statBegin, showStatistic, &eventsTable)
-	if err == nil {
-		var output []byte
-		switch FormatType {
-		case "json":
-			output, err = json.Marshal(eventsTable)
-			if err == nil {
-				buf := bytes.NewBuffer(output)
-				_, err = fmt.Fprint(out, buf)
-				if err == nil {
-					err = out.Flush()
-				}
-			}
-		case "xml":
-			output, err = xml.Marshal(eventsTable)
-			if err == nil {
-				buf := bytes.NewBuffer(output)
-				_, err = fmt.Fprint(out, buf)
-				if err == nil {
-					err = out.Flush()
-				}
-			}
-		default:
-			err = out.Flush()
-		}
-	} else {
+	err = o.print(out, in, evdefs, typedefs, statBegin, showStatistic, &eventsTable)
+	if err != nil {
 		_ = out.Flush()
 	}
 	return err
@@ -603,7 +603,102 @@ func Test_eventProperty_getLast(t *testing.T) {
 	}
 }
 
-func TestOutput_buildStatistic(t *testing.T) { //nolint:golint,paralleltest
+func TestOutput_setWidths(t *testing.T) {
+	t.Parallel()
+
+	eds := make(scvd.Events)
+	eds[0xEF00] = scvd.EventType{Brief: "briefbriefbrief", Property: "propertypropertyproperty", Value: "value"}
+	eds[0xEF01] = scvd.EventType{Brief: "brief", Property: "property", Value: "value"}
+
+	tests := []struct {
+		name   string
+		evdefs scvd.Events
+		want1  int
+		want2  int
+	}{
+		{"nil", nil, 9, 14},
+		{"empty", make(scvd.Events), 9, 14},
+		{"defs", eds, 15, 24},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(tt.evdefs)
+			if o.componentSize != tt.want1 || o.propertySize != tt.want2 {
+				t.Errorf("Output.setWidths() %s = %v,%v, want %v,%v", tt.name, o.componentSize, o.propertySize, tt.want1, tt.want2)
+			}
+		})
+	}
+}
+
+func Test_componentName(t *testing.T) { //nolint:golint,paralleltest
+	savedHierarchy := Hierarchy
+	defer func() { Hierarchy = savedHierarchy }()
+
+	full := scvd.EventType{Brief: "EvCtrl", Component: scvd.ComponentInfo{Group: "Recorder Control", Name: "Event Recorder Control"}}
+	noGroup := scvd.EventType{Brief: "EvCtrl", Component: scvd.ComponentInfo{Name: "Event Recorder Control"}}
+	noComponent := scvd.EventType{Brief: "EvCtrl"}
+
+	tests := []struct {
+		name      string
+		hierarchy bool
+		evdef     scvd.EventType
+		want      string
+	}{
+		{"brief", false, full, "EvCtrl"},
+		{"hierarchy", true, full, "Recorder Control/Event Recorder Control"},
+		{"no group", true, noGroup, "Event Recorder Control"},
+		{"no component", true, noComponent, "EvCtrl"},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			Hierarchy = tt.hierarchy
+			if got := componentName(&tt.evdef); got != tt.want {
+				t.Errorf("componentName() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutput_updateClock(t *testing.T) { //nolint:golint,paralleltest
+	init0 := event.Data{Time: 8, Value1: 1, Value2: 4, Info: event.Info{ID: 0xFF00}}
+	clock0 := event.Data{Time: 12, Value1: 2, Info: event.Info{ID: 0xFF03}}
+	clock1 := event.Data{Time: 14, Value1: 8, Info: event.Info{ID: 0xFF03}}
+	clockNix := event.Data{Time: 16, Info: event.Info{ID: 0xFF03}}
+	ev := event.Data{Time: 30, Info: event.Info{ID: 0xEF00}}
+
+	tests := []struct {
+		name   string
+		events []event.Data
+		want   []float64
+		factor float64
+	}{
+		{"no clock", []event.Data{ev}, []float64{15}, 0.5},
+		{"init", []event.Data{init0, ev}, []float64{4, 9.5}, 0.25},
+		{"clock first", []event.Data{clock0, ev}, []float64{6, 15}, 0.5},
+		{"init clock", []event.Data{init0, clock0, clock1, clockNix, ev}, []float64{4, 1, 1, 1.25, 3}, 0.125},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			factor := 0.5
+			TimeFactor = &factor
+			o := &Output{}
+			for i, ev := range tt.events {
+				if got := o.updateClock(&ev); got != tt.want[i] {
+					t.Errorf("Output.updateClock() %s[%d] = %v, want %v", tt.name, i, got, tt.want[i])
+				}
+			}
+			if TimeFactor != nil && *TimeFactor != tt.factor {
+				t.Errorf("Output.updateClock() %s factor = %v, want %v", tt.name, *TimeFactor, tt.factor)
+			}
+		})
+	}
+}
+
+func TestOutput_decode(t *testing.T) { //nolint:golint,paralleltest
 	eds0 := make(scvd.Events)
 	eds := make(scvd.Events)
 	eds[0xEF00] = scvd.EventType{Brief: "briefbriefbrief", Property: "propertypropertyproperty", Value: "value"}
@@ -616,53 +711,54 @@ func TestOutput_buildStatistic(t *testing.T) { //nolint:golint,paralleltest
 	var s6 = "../../testdata/test6.binary"
 	var s7 = "../../testdata/test7.binary"
 
-	type fields struct {
-		evProps       [4]eventProperty
-		columns       []string
-		componentSize int
-		propertySize  int
-	}
 	type args struct {
 		file     string
 		evdefs   scvd.Events
 		typedefs eval.Typedefs
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   int
-		want1  int
-		want2  int
-		want3  float64
+		name    string
+		args    args
+		want    int
+		want1   int
+		want2   int
+		want3   float64
+		started bool
+		wantErr bool
 	}{
-		{"test1", fields{[4]eventProperty{}, []string{"Index", "Time (s)", "Component", "Event Property", "Value"}, 0, 0}, args{s1, eds0, tds}, 0, 9, 14, 0.0},
-		{"test3", fields{[4]eventProperty{}, []string{"Index", "Time (s)", "Component", "Event Property", "Value"}, 0, 0}, args{s3, eds0, tds}, 1, 9, 14, 0.0},
-		{"test4", fields{[4]eventProperty{}, []string{"Index", "Time (s)", "Component", "Event Property", "Value"}, 0, 0}, args{s4, eds0, tds}, 1, 9, 14, 0.5},
-		{"test6", fields{[4]eventProperty{}, []string{"Index", "Time (s)", "Component", "Event Property", "Value"}, 0, 0}, args{s6, eds0, tds}, 1, 9, 14, 0.25},
-		{"test7a", fields{[4]eventProperty{}, []string{"Index", "Time (s)", "Component", "Event Property", "Value"}, 0, 0}, args{s7, eds0, tds}, 1, 9, 14, 0.25},
-		{"test7b", fields{[4]eventProperty{}, []string{"Index", "Time (s)", "Component", "Event Property", "Value"}, 0, 0}, args{s7, eds, tds}, 1, 15, 24, 0.25},
+		{"test1", args{s1, eds0, tds}, 0, 9, 14, 0.0, false, false},
+		{"test3", args{s3, eds0, tds}, 1, 9, 14, 0.0, false, false},
+		{"test4", args{s4, eds0, tds}, 1, 9, 14, 0.5, false, false},
+		{"test6", args{s6, eds0, tds}, 1, 9, 14, 0.25, false, false},
+		{"test7a", args{s7, eds0, tds}, 1, 9, 14, 0.25, true, false},
+		{"test7b", args{s7, eds, tds}, 1, 15, 24, 0.25, true, false},
 	}
 	for _, tt := range tests { //nolint:golint,paralleltest
 		t.Run(tt.name, func(t *testing.T) {
-			o := &Output{
-				evProps:       tt.fields.evProps,
-				columns:       tt.fields.columns,
-				componentSize: tt.fields.componentSize,
-				propertySize:  tt.fields.propertySize,
-			}
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(nil)
 			TimeFactor = nil
 			var b event.Binary
+			o.scan(b.Open(&tt.args.file), tt.args.evdefs)
+			b.Close()
+			TimeFactor = nil
 			in := b.Open(&tt.args.file)
-			if got := o.buildStatistic(in, tt.args.evdefs, tt.args.typedefs); got != tt.want {
-				t.Errorf("Output.buildStatistic() %s = %v, want %v", tt.name, got, tt.want)
-			}
+			got, err := o.decode(in, tt.args.evdefs, tt.args.typedefs, nil)
 			b.Close()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Output.decode() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Output.decode() %s = %v, want %v", tt.name, got, tt.want)
+			}
 			if o.componentSize != tt.want1 || o.propertySize != tt.want2 {
-				t.Errorf("Output.buildStatistic() %s = %v,%v, want %v,%v", tt.name, o.componentSize, o.propertySize, tt.want1, tt.want2)
+				t.Errorf("Output.scan() %s = %v,%v, want %v,%v", tt.name, o.componentSize, o.propertySize, tt.want1, tt.want2)
+			}
+			if o.evProps[0].values[0].evStart != tt.started {
+				t.Errorf("Output.decode() %s started = %v, want %v", tt.name, o.evProps[0].values[0].evStart, tt.started)
 			}
 			if TimeFactor != nil && *TimeFactor != tt.want3 {
-				t.Errorf("Output.buildStatistic() %s = %v, want %v", tt.name, TimeFactor, tt.want3)
+				t.Errorf("Output.decode() %s = %v, want %v", tt.name, TimeFactor, tt.want3)
 			}
 		})
 	}
@@ -764,7 +860,7 @@ func Test_escapeGen(t *testing.T) {
 	}
 }
 
-func TestOutput_printEvents(t *testing.T) { //nolint:golint,paralleltest
+func TestOutput_decodeEvents(t *testing.T) { //nolint:golint,paralleltest
 	var b bytes.Buffer
 
 	eds := make(scvd.Events)
@@ -777,67 +873,51 @@ func TestOutput_printEvents(t *testing.T) { //nolint:golint,paralleltest
 	var s11 = "../../testdata/test11.binary"
 	var sNix = "../../testdata/xxxx"
 
-	line1 := "    0 0.00000124 0xFF     0xFF03       val1=0x00000004, val2=0x00000002\n" +
-		"    1 0.00000124 0xFE     0xFE00       \"hello wo\"\n"
+	line1 := "    0 0.00000124 0xFF      0xFF03         val1=0x00000004, val2=0x00000002\n" +
+		"    1 0.00000124 0xFE      0xFE00         \"hello wo\"\n"
 	line2 := "    0 0.00000124 briefbriefbrief propertypropertyproperty value\n" +
 		"    1 0.00000124 briefbriefbrief propertypropertyproperty \"hello wo\"\n"
-	line3 := "    0 0.00000124 0xFF     0xFF00       val1=0x00000004, val2=0x00000002\n" +
-		"    1 0.00000124 0xFE     0xFE00       \"hello wo\"\n"
+	line3 := "    0 0.00000124 0xFF      0xFF00         val1=0x00000004, val2=0x00000002\n" +
+		"    1 0.00000124 0xFE      0xFE00         \"hello wo\"\n"
 
-	type fields struct {
-		evProps       [4]eventProperty
-		columns       []string
-		componentSize int
-		propertySize  int
-	}
 	type args struct {
-		out      *bufio.Writer
 		in       *bufio.Reader
 		evdefs   scvd.Events
 		typedefs eval.Typedefs
 	}
 	tests := []struct {
 		name    string
-		fields  fields
 		args    args
 		file    *string
 		want    string
 		wantErr bool
 	}{
-		{"readErr0", fields{}, args{}, &s0, "", false},
-		{"readErr1", fields{}, args{}, &s1, "", true},
-		{"read1", fields{}, args{}, &s10, line1, false},
-		{"read2", fields{}, args{evdefs: eds}, &s10, line2, false},
-		{"read3", fields{}, args{}, &s11, line3, false},
-		{"readNix", fields{}, args{}, &sNix, "", false},
-	}
-	eventsTable := EventsTable{
-		Events:     []EventRecord{},
-		Statistics: []EventRecordStatistic{},
+		{"readErr0", args{}, &s0, "", false},
+		{"readErr1", args{}, &s1, "", false},
+		{"read1", args{}, &s10, line1, false},
+		{"read2", args{evdefs: eds}, &s10, line2, false},
+		{"read3", args{}, &s11, line3, false},
+		{"readNix", args{}, &sNix, "", false},
 	}
 	for _, tt := range tests { //nolint:golint,paralleltest
 		t.Run(tt.name, func(t *testing.T) {
-			tt.args.out = bufio.NewWriter(&b)
+			out := bufio.NewWriter(&b)
 
 			TimeFactor = nil
 			var ib event.Binary
 			tt.args.in = ib.Open(tt.file)
-			o := &Output{
-				evProps:       tt.fields.evProps,
-				columns:       tt.fields.columns,
-				componentSize: tt.fields.componentSize,
-				propertySize:  tt.fields.propertySize,
-			}
-			if err := o.printEvents(tt.args.out, tt.args.in, tt.args.evdefs, tt.args.typedefs, &eventsTable); (err != nil) != tt.wantErr {
-				t.Errorf("Output.printEvents() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+			o.setWidths(tt.args.evdefs)
+			if _, err := o.decode(tt.args.in, tt.args.evdefs, tt.args.typedefs, newRecordWriter(o, out)); (err != nil) != tt.wantErr {
+				t.Errorf("Output.decode() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
-			tt.args.out.Flush()
+			out.Flush()
 			str, err := b.ReadString('\000')
 			if err != nil && !errors.Is(err, io.EOF) {
-				t.Errorf("Output.printStatistic() err = %v", err)
+				t.Errorf("Output.decode() err = %v", err)
 			}
 			if str != tt.want {
-				t.Errorf("Output.printStatistic() %s = %v, want %v", tt.name, str, tt.want)
+				t.Errorf("Output.decode() %s = %v, want %v", tt.name, str, tt.want)
 			}
 		})
 	}
@@ -976,7 +1056,14 @@ func TestOutput_print(t *testing.T) { //nolint:golint,paralleltest
 				componentSize: tt.fields.componentSize,
 				propertySize:  tt.fields.propertySize,
 			}
-			if err := o.print(tt.args.out, tt.args.eventFile, tt.args.evdefs, tt.args.typedefs, tt.args.statBegin, tt.args.showStatistic, &eventsTable); (err != nil) != tt.wantErr {
+			var ib event.Binary
+			var in *bufio.Reader
+			if tt.args.eventFile != nil {
+				if in = ib.Open(tt.args.eventFile); in != nil {
+					defer ib.Close()
+				}
+			}
+			if err := o.print(tt.args.out, in, tt.args.evdefs, tt.args.typedefs, tt.args.statBegin, tt.args.showStatistic, &eventsTable); (err != nil) != tt.wantErr {
 				t.Errorf("Output.print() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			tt.args.out.Flush()
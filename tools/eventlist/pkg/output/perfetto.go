@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"eventlist/pkg/event"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tracks of the Chrome Trace Event format, the start/stop events of
+// group A..D and slot 0..15 use the tracks tidSlots + 16 * group + slot.
+const (
+	tracePid  = 1
+	tidEvents = 1
+	tidISR    = 2
+	tidSlots  = 16
+)
+
+// traceEvent is an event of the Chrome Trace Event format, which can
+// be shown by ui.perfetto.dev or chrome://tracing.
+type traceEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat,omitempty"`
+	Ph   string         `json:"ph"`
+	Ts   float64        `json:"ts"` // in microseconds
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	S    string         `json:"s,omitempty"` // scope of instant events
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// perfettoWriter writes the event records in the Chrome Trace Event format:
+// the start/stop events as slices per group and slot, all other records as
+// instant events on the event track or, if recorded in an interrupt service
+// routine, on the ISR track. Records with a numeric value also update a
+// counter per component and event property.
+type perfettoWriter struct {
+	out     *bufio.Writer
+	count   int
+	threads map[int]bool // tracks with a name
+	open    map[int]bool // tracks with an open slice
+	last    float64      // time of the last record
+}
+
+func newPerfettoWriter(out *bufio.Writer) *perfettoWriter {
+	return &perfettoWriter{out: out, threads: make(map[int]bool), open: make(map[int]bool)}
+}
+
+// emit appends one trace event to the traceEvents array.
+func (w *perfettoWriter) emit(ev *traceEvent) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if w.count > 0 {
+		if err = w.out.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	w.count++
+	_, err = w.out.Write(data)
+	return err
+}
+
+// track names a track when it is used first.
+func (w *perfettoWriter) track(tid int, name string) error {
+	if w.threads[tid] {
+		return nil
+	}
+	w.threads[tid] = true
+	return w.emit(&traceEvent{Name: "thread_name", Ph: "M", Pid: tracePid, Tid: tid, Args: map[string]any{"name": name}})
+}
+
+// numeric returns the number of a value consisting of a number only.
+func numeric(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if i, err := strconv.ParseInt(value, 0, 64); err == nil {
+		return float64(i), true
+	}
+	if u, err := strconv.ParseUint(value, 0, 64); err == nil {
+		return float64(u), true
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	return f, err == nil
+}
+
+// begin opens the JSON object and the traceEvents array and names the process.
+func (w *perfettoWriter) begin() error {
+	if _, err := w.out.WriteString("{\"traceEvents\":["); err != nil {
+		return err
+	}
+	return w.emit(&traceEvent{Name: "process_name", Ph: "M", Pid: tracePid, Args: map[string]any{"name": "eventlist"}})
+}
+
+// write converts one event record to trace events.
+func (w *perfettoWriter) write(record *EventRecord) error {
+	ts := record.Time * 1e6
+	w.last = ts
+	info := event.Info{ID: record.id}
+	class, group, idx, start := info.SplitID()
+	if class == 0xEF {
+		tid := tidSlots + 16*int(group) + int(idx)
+		name := fmt.Sprintf("%c(%d)", byte(group+'A'), idx)
+		if err := w.track(tid, name); err != nil {
+			return err
+		}
+		args := map[string]any{"index": record.Index, "value": record.Value}
+		if !start {
+			if !w.open[tid] { // stop without start
+				return w.emit(&traceEvent{Name: name, Cat: record.Component, Ph: "i", Ts: ts, Pid: tracePid, Tid: tid, S: "t", Args: args})
+			}
+			w.open[tid] = false
+			return w.emit(&traceEvent{Ph: "E", Ts: ts, Pid: tracePid, Tid: tid, Args: args})
+		}
+		if w.open[tid] { // restarted before the stop
+			if err := w.emit(&traceEvent{Ph: "E", Ts: ts, Pid: tracePid, Tid: tid}); err != nil {
+				return err
+			}
+		}
+		w.open[tid] = true
+		return w.emit(&traceEvent{Name: name, Cat: record.Component, Ph: "B", Ts: ts, Pid: tracePid, Tid: tid, Args: args})
+	}
+
+	tid, name := tidEvents, "Events"
+	if record.irq {
+		tid, name = tidISR, "ISR"
+	}
+	if err := w.track(tid, name); err != nil {
+		return err
+	}
+	args := map[string]any{"index": record.Index, "value": record.Value}
+	if record.irq {
+		args["irq"] = true
+	}
+	err := w.emit(&traceEvent{Name: record.EventProperty, Cat: record.Component, Ph: "i", Ts: ts, Pid: tracePid, Tid: tid, S: "t", Args: args})
+	if err != nil {
+		return err
+	}
+	if v, ok := numeric(record.Value); ok {
+		name := record.Component + " " + record.EventProperty
+		return w.emit(&traceEvent{Name: name, Ph: "C", Ts: ts, Pid: tracePid, Tid: tid, Args: map[string]any{"value": v}})
+	}
+	return nil
+}
+
+// end closes the open slices at the time of the last record and closes
+// the traceEvents array. The statistics are not part of the trace.
+func (w *perfettoWriter) end(*EventsTable) error {
+	var tids []int
+	for tid, open := range w.open {
+		if open {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Ints(tids)
+	for _, tid := range tids {
+		if err := w.emit(&traceEvent{Ph: "E", Ts: w.last, Pid: tracePid, Tid: tid}); err != nil {
+			return err
+		}
+	}
+	_, err := w.out.WriteString("],\"displayTimeUnit\":\"ns\"}")
+	return err
+}
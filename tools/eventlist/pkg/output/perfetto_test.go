@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func Test_numeric(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		value  string
+		want   float64
+		wantOk bool
+	}{
+		{"decimal", "42", 42, true},
+		{"negative", "-3", -3, true},
+		{"hex", " 0x10 ", 16, true},
+		{"unsigned", "0xFFFFFFFFFFFFFFFF", 18446744073709551615, true},
+		{"floating", "2.5", 2.5, true},
+		{"text", "val1=0x00000004", 0, false},
+		{"empty", "", 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := numeric(tt.value)
+			if ok != tt.wantOk || (ok && got != tt.want) {
+				t.Errorf("numeric() %s = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_perfettoWriter(t *testing.T) {
+	t.Parallel()
+
+	records := []EventRecord{
+		{Index: 0, Time: 1.0, Component: "C", EventProperty: "P", Value: "text", id: 0x0100},
+		{Index: 1, Time: 1.5, Component: "C", EventProperty: "Count", Value: "7", id: 0x0101, irq: true},
+		{Index: 2, Time: 2.0, Component: "EvStat", EventProperty: "Start", Value: "a", id: 0xEF42}, // B(2) start
+		{Index: 3, Time: 2.5, Component: "EvStat", EventProperty: "Stop", Value: "b", id: 0xEF62},  // B(2) stop
+		{Index: 4, Time: 3.0, Component: "EvStat", EventProperty: "Stop", Value: "c", id: 0xEF62},  // B(2) stop without start
+		{Index: 5, Time: 3.5, Component: "EvStat", EventProperty: "Start", Value: "d", id: 0xEF01}, // A(1) start
+		{Index: 6, Time: 4.0, Component: "EvStat", EventProperty: "Start", Value: "e", id: 0xEF01}, // A(1) restart
+		{Index: 7, Time: 4.5, Component: "EvStat", EventProperty: "Start", Value: "f", id: 0xEFC3}, // D(3) start
+		{Index: 8, Time: 5.0, Component: "C", EventProperty: "P", Value: "", id: 0x0100},
+	}
+	want := `{"traceEvents":[` +
+		`{"name":"process_name","ph":"M","ts":0,"pid":1,"tid":0,"args":{"name":"eventlist"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":1,"args":{"name":"Events"}},` +
+		`{"name":"P","cat":"C","ph":"i","ts":1000000,"pid":1,"tid":1,"s":"t","args":{"index":0,"value":"text"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":2,"args":{"name":"ISR"}},` +
+		`{"name":"Count","cat":"C","ph":"i","ts":1500000,"pid":1,"tid":2,"s":"t","args":{"index":1,"irq":true,"value":"7"}},` +
+		`{"name":"C Count","ph":"C","ts":1500000,"pid":1,"tid":2,"args":{"value":7}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":34,"args":{"name":"B(2)"}},` +
+		`{"name":"B(2)","cat":"EvStat","ph":"B","ts":2000000,"pid":1,"tid":34,"args":{"index":2,"value":"a"}},` +
+		`{"name":"","ph":"E","ts":2500000,"pid":1,"tid":34,"args":{"index":3,"value":"b"}},` +
+		`{"name":"B(2)","cat":"EvStat","ph":"i","ts":3000000,"pid":1,"tid":34,"s":"t","args":{"index":4,"value":"c"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":17,"args":{"name":"A(1)"}},` +
+		`{"name":"A(1)","cat":"EvStat","ph":"B","ts":3500000,"pid":1,"tid":17,"args":{"index":5,"value":"d"}},` +
+		`{"name":"","ph":"E","ts":4000000,"pid":1,"tid":17},` +
+		`{"name":"A(1)","cat":"EvStat","ph":"B","ts":4000000,"pid":1,"tid":17,"args":{"index":6,"value":"e"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":67,"args":{"name":"D(3)"}},` +
+		`{"name":"D(3)","cat":"EvStat","ph":"B","ts":4500000,"pid":1,"tid":67,"args":{"index":7,"value":"f"}},` +
+		`{"name":"P","cat":"C","ph":"i","ts":5000000,"pid":1,"tid":1,"s":"t","args":{"index":8,"value":""}},` +
+		`{"name":"","ph":"E","ts":5000000,"pid":1,"tid":17},` +
+		`{"name":"","ph":"E","ts":5000000,"pid":1,"tid":67}` +
+		`],"displayTimeUnit":"ns"}`
+
+	var b bytes.Buffer
+	out := bufio.NewWriter(&b)
+	w := newPerfettoWriter(out)
+	if err := w.begin(); err != nil {
+		t.Fatalf("perfettoWriter.begin() error = %v", err)
+	}
+	for i := range records {
+		if err := w.write(&records[i]); err != nil {
+			t.Fatalf("perfettoWriter.write() %d error = %v", i, err)
+		}
+	}
+	if err := w.end(&EventsTable{}); err != nil {
+		t.Fatalf("perfettoWriter.end() error = %v", err)
+	}
+	out.Flush()
+	if got := b.String(); got != want {
+		t.Errorf("perfettoWriter = %v, want %v", got, want)
+	}
+	if !json.Valid(b.Bytes()) {
+		t.Errorf("perfettoWriter = %v, invalid JSON", b.String())
+	}
+}
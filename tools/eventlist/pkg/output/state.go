@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"strings"
+)
+
+// handleState is the state of a handle of a component, e.g. of a thread.
+type handleState struct {
+	component string       // brief name of the component
+	no        uint8        // number of the component
+	handle    int64        // value of the handle
+	name      string       // name of the handle, the first hname
+	states    []scvd.State // states of the component
+	state     string       // current state, empty if the handle is not tracked
+}
+
+// label returns the name of the handle, the handle value if it is unnamed.
+func (h *handleState) label() string {
+	if h.name != "" {
+		return h.name
+	}
+	return fmt.Sprintf("0x%08X", uint32(h.handle))
+}
+
+// stateChange is a change of the state of a handle.
+type stateChange struct {
+	time  float64 // in seconds
+	h     *handleState
+	state string // empty if the tracking of the handle stops
+}
+
+type handleKey struct {
+	no     uint8
+	handle int64
+}
+
+// stateTracker follows the states of the handles of the components as
+// defined by the state, handle, hname, tracking and reset attributes of the
+// events. The tracking of a handle starts with tracking="Start" or with its
+// first state and ends with tracking="Stop". tracking="Reset" or reset="1"
+// stop the tracking of all handles of the component. A handle which gets a
+// unique state takes it away from the other handles of the component, they
+// fall back to the dormant state, if the component defines one.
+type stateTracker struct {
+	handles map[handleKey]*handleState
+	order   []*handleState // handles in the order of their first event
+	changes []stateChange
+	end     float64 // time of the last event in seconds
+}
+
+func newStateTracker() *stateTracker {
+	return &stateTracker{handles: make(map[handleKey]*handleState)}
+}
+
+// set changes the state of a handle, unchanged states are not recorded.
+func (t *stateTracker) set(time float64, h *handleState, state string) {
+	if h.state == state {
+		return
+	}
+	h.state = state
+	t.changes = append(t.changes, stateChange{time: time, h: h, state: state})
+}
+
+// findState returns the definition of a state of a component.
+func findState(states []scvd.State, name string) (scvd.State, bool) {
+	for _, s := range states {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return scvd.State{}, false
+}
+
+// dormantState returns the state of the handles which lose a unique state.
+func dormantState(states []scvd.State) string {
+	for _, s := range states {
+		if s.Dormant {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// update applies the state attributes of an event definition. A handle
+// which cannot be evaluated is ignored, like a false print condition.
+//
+// Parameters:
+//   - ev: The event.
+//   - evdef: The definition of the event.
+//   - typedefs: The type definitions used for evaluating the handle.
+//   - time: The time of the event in seconds.
+func (t *stateTracker) update(ev *event.Data, evdef *scvd.EventType, typedefs eval.Typedefs, time float64) {
+	tracking := strings.ToLower(strings.TrimSpace(evdef.Tracking))
+	no := uint8(ev.Info.ID >> 8)
+	if tracking == "reset" || evdef.Reset {
+		for _, h := range t.order {
+			if h.no == no {
+				t.set(time, h, "")
+			}
+		}
+	}
+	if strings.TrimSpace(evdef.Handle) == "" {
+		return
+	}
+	value, name, err := ev.EvalHandle(*evdef, typedefs)
+	if err != nil {
+		return
+	}
+	key := handleKey{no, value}
+	h, ok := t.handles[key]
+	if !ok {
+		h = &handleState{component: evdef.Brief, no: no, handle: value, states: evdef.States}
+		t.handles[key] = h
+		t.order = append(t.order, h)
+	}
+	if h.name == "" {
+		h.name = name
+	}
+	state := evdef.State
+	if state == "" && tracking == "start" && h.state == "" && len(h.states) != 0 {
+		state = h.states[0].Name // started without state
+	}
+	if state != "" {
+		s, ok := findState(h.states, state)
+		if dormant := dormantState(h.states); ok && s.Unique && dormant != "" {
+			for _, other := range t.order {
+				if other != h && other.no == no && other.state == state {
+					t.set(time, other, dormant)
+				}
+			}
+		}
+		t.set(time, h, state)
+	}
+	if tracking == "stop" {
+		t.set(time, h, "")
+	}
+}
+
+// StateInterval is a period of time in which a handle has a state.
+type StateInterval struct {
+	State    string  `json:"state" xml:"state"`
+	Start    float64 `json:"start" xml:"start"`
+	Duration float64 `json:"duration" xml:"duration"`
+}
+
+// StateDuration is the time a handle spent in a state.
+type StateDuration struct {
+	State string  `json:"state" xml:"state"`
+	Count int     `json:"count" xml:"count"`
+	Total float64 `json:"total" xml:"total"`
+}
+
+// HandleStates is the state timeline of a handle and the time it spent
+// in each of its states.
+type HandleStates struct {
+	Component string          `json:"component" xml:"component"`
+	Handle    string          `json:"handle" xml:"handle"`
+	Name      string          `json:"name,omitempty" xml:"name,omitempty"`
+	Durations []StateDuration `json:"durations" xml:"duration"`
+	Timeline  []StateInterval `json:"timeline" xml:"interval"`
+}
+
+// timeline returns the states of the tracked handles in the order of their
+// first event. A state which is still set at the end lasts until the last
+// event. The durations are listed in the order of the state definitions of
+// the component, followed by undefined states.
+func (t *stateTracker) timeline() []HandleStates {
+	timelines := make(map[*handleState][]StateInterval, len(t.order))
+	open := make(map[*handleState]*StateInterval, len(t.order))
+	closeInterval := func(h *handleState, time float64) {
+		if s := open[h]; s != nil {
+			if time > s.Start {
+				s.Duration = time - s.Start
+			}
+			timelines[h] = append(timelines[h], *s)
+			delete(open, h)
+		}
+	}
+	for _, c := range t.changes {
+		closeInterval(c.h, c.time)
+		if c.state != "" {
+			open[c.h] = &StateInterval{State: c.state, Start: c.time}
+		}
+	}
+	var handles []HandleStates
+	for _, h := range t.order {
+		closeInterval(h, t.end)
+		hs := HandleStates{
+			Component: h.component,
+			Handle:    fmt.Sprintf("0x%08X", uint32(h.handle)),
+			Name:      h.name,
+			Durations: []StateDuration{},
+			Timeline:  timelines[h],
+		}
+		if hs.Timeline == nil {
+			hs.Timeline = []StateInterval{}
+		}
+		index := make(map[string]int)
+		for _, s := range h.states {
+			index[s.Name] = len(hs.Durations)
+			hs.Durations = append(hs.Durations, StateDuration{State: s.Name})
+		}
+		for _, s := range hs.Timeline {
+			i, ok := index[s.State]
+			if !ok {
+				i = len(hs.Durations)
+				index[s.State] = i
+				hs.Durations = append(hs.Durations, StateDuration{State: s.State})
+			}
+			hs.Durations[i].Count++
+			hs.Durations[i].Total += s.Duration
+		}
+		handles = append(handles, hs)
+	}
+	return handles
+}
+
+// printStates writes the state timeline of the tracked handles, in text
+// format as section with the time spent in each state followed by the state
+// changes, otherwise in the events table. Nothing is written if no handle
+// is tracked.
+//
+// Parameters:
+//   - out: The buffered writer receiving the text.
+//   - eventTable: The events table receiving the states.
+//
+// Returns:
+//   - An error if writing fails.
+func (o *Output) printStates(out *bufio.Writer, eventTable *EventsTable) error {
+	if o.states == nil || len(o.states.order) == 0 {
+		return nil
+	}
+	eventTable.States = o.states.timeline()
+	if len(eventTable.Statistics) == 0 { // statistic header without events
+		if err := conditionalWrite(out, "\n"); err != nil {
+			return err
+		}
+	}
+	if err := conditionalWrite(out, "   State timeline\n   --------------\n\n"); err != nil {
+		return err
+	}
+	for _, hs := range eventTable.States {
+		label := hs.Handle
+		if hs.Name != "" {
+			label = hs.Name + " (" + hs.Handle + ")"
+		}
+		if err := conditionalWrite(out, "%s %s\n", hs.Component, label); err != nil {
+			return err
+		}
+		width := 0
+		for _, d := range hs.Durations {
+			if len(d.State) > width {
+				width = len(d.State)
+			}
+		}
+		for _, d := range hs.Durations {
+			if err := conditionalWrite(out, "    %*s %5d %s\n", -width, d.State, d.Count, convertUnit(d.Total, "s")); err != nil {
+				return err
+			}
+		}
+		for _, s := range hs.Timeline {
+			if err := conditionalWrite(out, "      %.8f %*s %s\n", s.Start, -width, s.State, convertUnit(s.Duration, "s")); err != nil {
+				return err
+			}
+		}
+		if err := conditionalWrite(out, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
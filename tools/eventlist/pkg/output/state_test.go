@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_stateTracker_update(t *testing.T) { //nolint:golint,paralleltest
+	states := []scvd.State{{Name: "Idle"}, {Name: "Active", Unique: true}}
+	start := scvd.EventType{Brief: "C", Handle: "val1", Tracking: "Start", States: states}
+	active := scvd.EventType{Brief: "C", Handle: "val1", State: "Active", States: states}
+	stop := scvd.EventType{Brief: "C", Handle: "val1", Tracking: "stop", States: states}
+	reset := scvd.EventType{Brief: "C", Reset: true, States: states}
+	invalid := scvd.EventType{Brief: "C", Handle: "val1 +", State: "Active", States: states}
+
+	type step struct {
+		evdef  scvd.EventType
+		handle int32
+	}
+	tests := []struct {
+		name  string
+		steps []step
+		want  []string
+	}{
+		{"start without state", []step{{start, 1}}, []string{"1:Idle"}},
+		{"unique without dormant", []step{{active, 1}, {active, 2}}, []string{"1:Active", "2:Active"}},
+		{"stop", []step{{active, 1}, {stop, 1}, {stop, 1}}, []string{"1:Active", "1:"}},
+		{"reset attribute", []step{{start, 1}, {active, 2}, {reset, 0}}, []string{"1:Idle", "2:Active", "1:", "2:"}},
+		{"invalid handle", []step{{invalid, 1}}, nil},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newStateTracker()
+			for i, s := range tt.steps {
+				ev := event.Data{Typ: 2, Value1: s.handle, Info: event.Info{ID: 0x0100}}
+				tr.update(&ev, &s.evdef, nil, float64(i))
+			}
+			var got []string
+			for _, c := range tr.changes {
+				got = append(got, fmt.Sprintf("%d:%s", c.h.handle, c.state))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stateTracker.update() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stateTracker_timeline(t *testing.T) {
+	t.Parallel()
+
+	states := []scvd.State{{Name: "Idle"}, {Name: "Active"}}
+	h1 := &handleState{component: "C", handle: 1, name: "one", states: states}
+	h2 := &handleState{component: "C", handle: 2, states: states}
+	tr := &stateTracker{
+		order: []*handleState{h1, h2},
+		changes: []stateChange{
+			{1, h1, "Idle"},
+			{2, h2, "Active"},
+			{3, h1, "Active"},
+			{4, h2, ""},
+			{5, h1, "Idle"},
+			{6, h2, "Other"},
+		},
+		end: 8,
+	}
+	want := []HandleStates{
+		{Component: "C", Handle: "0x00000001", Name: "one",
+			Durations: []StateDuration{{"Idle", 2, 5}, {"Active", 1, 2}},
+			Timeline:  []StateInterval{{"Idle", 1, 2}, {"Active", 3, 2}, {"Idle", 5, 3}}},
+		{Component: "C", Handle: "0x00000002",
+			Durations: []StateDuration{{"Idle", 0, 0}, {"Active", 1, 2}, {"Other", 1, 2}},
+			Timeline:  []StateInterval{{"Active", 2, 2}, {"Other", 6, 2}}},
+	}
+	if got := tr.timeline(); !reflect.DeepEqual(got, want) {
+		t.Errorf("stateTracker.timeline() = %v, want %v", got, want)
+	}
+}
+
+func TestOutput_printStates(t *testing.T) { //nolint:golint,paralleltest
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+
+	states := []scvd.State{{Name: "Idle"}, {Name: "Active"}}
+	h1 := &handleState{component: "C", handle: 1, name: "one", states: states}
+	h2 := &handleState{component: "C", handle: 2, states: states}
+	tracker := &stateTracker{
+		order:   []*handleState{h1, h2},
+		changes: []stateChange{{1, h1, "Idle"}, {2, h2, "Active"}, {3, h1, "Active"}},
+		end:     4,
+	}
+	text := "   State timeline\n   --------------\n\n" +
+		"C one (0x00000001)\n" +
+		"    Idle       1   2.00000s \n" +
+		"    Active     1   1.00000s \n" +
+		"      1.00000000 Idle     2.00000s \n" +
+		"      3.00000000 Active   1.00000s \n\n" +
+		"C 0x00000002\n" +
+		"    Idle       0   0.00000s \n" +
+		"    Active     1   2.00000s \n" +
+		"      2.00000000 Active   2.00000s \n\n"
+
+	tests := []struct {
+		name    string
+		format  string
+		states  *stateTracker
+		table   EventsTable
+		want    string
+		handles int
+	}{
+		{"not tracked", "txt", nil, EventsTable{}, "", 0},
+		{"no handles", "txt", newStateTracker(), EventsTable{}, "", 0},
+		{"txt", "txt", tracker, EventsTable{Statistics: []EventRecordStatistic{{}}}, text, 2},
+		{"txt without statistic", "txt", tracker, EventsTable{}, "\n" + text, 2},
+		{"json", "json", tracker, EventsTable{}, "", 2},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			FormatType = tt.format
+			o := &Output{states: tt.states}
+			if err := o.printStates(out, &tt.table); err != nil {
+				t.Errorf("Output.printStates() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.printStates() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			if got := len(tt.table.States); got != tt.handles {
+				t.Errorf("Output.printStates() %s handles = %d, want %d", tt.name, got, tt.handles)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"eventlist/pkg/event"
+)
+
+// Timestamps is the number of timestamps which had to be reconstructed. It
+// is only reported if a reconstruction was needed.
+type Timestamps struct {
+	Wraparounds int `json:"wraparounds" xml:"wraparounds"` // rollovers of 32-bit timestamps
+	Decreasing  int `json:"decreasing" xml:"decreasing"`   // timestamps before the previous one
+}
+
+// timestampTracker reconstructs monotonic 64-bit timestamps from the
+// timestamps of the records. A log holding only the lower 32 bits of the
+// timestamps rolls over, this is detected by a decrease of more than half of
+// the 32-bit range. An event with any other decrease, e.g. a record written
+// out of order, is shown at the time of the previous event, the following
+// events keep their times. EventRecorderInitialize (ID 0xFF00) starts a new
+// time base and is taken as is.
+type timestampTracker struct {
+	started bool
+	last    uint64 // last timestamp of a record in order
+	time    uint64 // last reconstructed timestamp
+	offset  uint64 // added to the timestamps of the records
+	count   Timestamps
+}
+
+// reconstruct returns the monotonic timestamp of an event.
+//
+// Parameters:
+//   - ev: The event with the timestamp of its record.
+//
+// Returns:
+//   - The reconstructed timestamp.
+func (t *timestampTracker) reconstruct(ev *event.Data) uint64 {
+	ts := ev.Time
+	if !t.started || ev.Info.ID == 0xFF00 {
+		t.started = true
+		t.last = ts
+		t.time = ts
+		t.offset = 0
+		return ts
+	}
+	if ts < t.last && t.last <= 0xFFFFFFFF && t.last-ts > 0x80000000 {
+		t.offset += 1 << 32
+		t.count.Wraparounds++
+	}
+	full := ts + t.offset
+	if full < t.time {
+		t.count.Decreasing++
+		return t.time
+	}
+	t.last = ts
+	t.time = full
+	return full
+}
+
+// reconstructed checks if timestamps had to be reconstructed.
+func (t *timestampTracker) reconstructed() bool {
+	return t.count.Wraparounds != 0 || t.count.Decreasing != 0
+}
+
+// printTimestamps warns if timestamps had to be reconstructed, in text
+// format as single line, otherwise in the events table.
+//
+// Parameters:
+//   - out: The buffered writer receiving the text.
+//   - eventTable: The events table receiving the counts.
+//
+// Returns:
+//   - An error if writing fails.
+func (o *Output) printTimestamps(out *bufio.Writer, eventTable *EventsTable) error {
+	if !o.timestamps.reconstructed() {
+		return nil
+	}
+	count := o.timestamps.count
+	eventTable.Timestamps = &count
+	return conditionalWrite(out, "   Timestamps reconstructed (wraparounds: %d, decreasing: %d)\n\n",
+		count.Wraparounds, count.Decreasing)
+}
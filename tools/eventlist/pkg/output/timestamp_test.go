@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"reflect"
+	"testing"
+)
+
+func Test_timestampTracker_reconstruct(t *testing.T) {
+	t.Parallel()
+
+	type ts struct {
+		id   uint16
+		time uint64
+	}
+	tests := []struct {
+		name  string
+		in    []ts
+		want  []uint64
+		count Timestamps
+	}{
+		{"monotonic", []ts{{1, 10}, {1, 10}, {1, 20}}, []uint64{10, 10, 20}, Timestamps{}},
+		{"64-bit", []ts{{1, 0xFFFFFFF0}, {1, 0x100000010}}, []uint64{0xFFFFFFF0, 0x100000010}, Timestamps{}},
+		{"wraparound", []ts{{1, 0xFFFFFFF0}, {1, 0x10}, {1, 0x20}, {1, 0xFFFFFF00}, {1, 0x05}},
+			[]uint64{0xFFFFFFF0, 0x100000010, 0x100000020, 0x1FFFFFF00, 0x200000005}, Timestamps{Wraparounds: 2}},
+		{"decreasing", []ts{{1, 100}, {1, 200}, {1, 50}, {1, 80}, {1, 300}},
+			[]uint64{100, 200, 200, 200, 300}, Timestamps{Decreasing: 2}},
+		{"64-bit decreasing", []ts{{1, 0x100000010}, {1, 0x10}, {1, 0x20}},
+			[]uint64{0x100000010, 0x100000010, 0x100000010}, Timestamps{Decreasing: 2}},
+		{"reordered", []ts{{1, 100}, {1, 200}, {1, 190}, {1, 300}, {1, 290}, {1, 280}, {1, 400}, {1, 500}, {1, 600}},
+			[]uint64{100, 200, 200, 300, 300, 300, 400, 500, 600}, Timestamps{Decreasing: 3}},
+		{"reordered at wraparound", []ts{{1, 0xFFFFFFF0}, {1, 0xFFFFFFE0}, {1, 0x10}, {1, 0x08}, {1, 0x20}},
+			[]uint64{0xFFFFFFF0, 0xFFFFFFF0, 0x100000010, 0x100000010, 0x100000020}, Timestamps{Wraparounds: 1, Decreasing: 2}},
+		{"initialize", []ts{{1, 0xFFFFFFF0}, {1, 0x10}, {0xFF00, 0x05}, {1, 0x08}},
+			[]uint64{0xFFFFFFF0, 0x100000010, 0x05, 0x08}, Timestamps{Wraparounds: 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tr timestampTracker
+			var got []uint64
+			for _, in := range tt.in {
+				ev := event.Data{Time: in.time}
+				ev.Info.ID = scvd.IDType(in.id)
+				got = append(got, tr.reconstruct(&ev))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("timestampTracker.reconstruct() %s = %X, want %X", tt.name, got, tt.want)
+			}
+			if tr.count != tt.count {
+				t.Errorf("timestampTracker.reconstruct() %s count = %v, want %v", tt.name, tr.count, tt.count)
+			}
+			if tr.reconstructed() != (tt.count != Timestamps{}) {
+				t.Errorf("timestampTracker.reconstructed() %s = %v", tt.name, tr.reconstructed())
+			}
+		})
+	}
+}
+
+func TestOutput_printTimestamps(t *testing.T) { //nolint:golint,paralleltest
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+
+	tests := []struct {
+		name   string
+		format string
+		count  Timestamps
+		want   string
+		table  *Timestamps
+	}{
+		{"none", "txt", Timestamps{}, "", nil},
+		{"txt", "txt", Timestamps{2, 1}, "   Timestamps reconstructed (wraparounds: 2, decreasing: 1)\n\n", &Timestamps{2, 1}},
+		{"json", "json", Timestamps{0, 3}, "", &Timestamps{0, 3}},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+
+			FormatType = tt.format
+			o := &Output{timestamps: timestampTracker{count: tt.count}}
+			var table EventsTable
+			if err := o.printTimestamps(out, &table); err != nil {
+				t.Errorf("Output.printTimestamps() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("Output.printTimestamps() %s = %q, want %q", tt.name, got, tt.want)
+			}
+			if !reflect.DeepEqual(table.Timestamps, tt.table) {
+				t.Errorf("Output.printTimestamps() %s table = %v, want %v", tt.name, table.Timestamps, tt.table)
+			}
+		})
+	}
+}
+
+func TestOutput_decodeTimestamps(t *testing.T) { //nolint:golint,paralleltest
+	savedFactor := TimeFactor
+	savedFormat := FormatType
+	defer func() {
+		TimeFactor = savedFactor
+		FormatType = savedFormat
+	}()
+	FormatType = "txt"
+	factor := 1.0
+	TimeFactor = &factor
+
+	var log bytes.Buffer
+	event.AppendRecord(&log, 2, 0xFF01, 0xFFFFFFFE, false, []uint32{1, 2}, nil)
+	event.AppendRecord(&log, 2, 0xFF01, 0x00000001, false, []uint32{3, 4}, nil)
+	event.AppendRecord(&log, 2, 0xFF01, 0x00000000, false, []uint32{5, 6}, nil)
+
+	var b bytes.Buffer
+	out := bufio.NewWriter(&b)
+	o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+	o.setWidths(nil)
+	if _, err := o.decode(bufio.NewReader(&log), nil, nil, newRecordWriter(o, out)); err != nil {
+		t.Errorf("Output.decode() error = %v", err)
+	}
+	out.Flush()
+	want := "    0 4294967294.00000000 0xFF      0xFF01         val1=0x00000001, val2=0x00000002\n" +
+		"    1 4294967297.00000000 0xFF      0xFF01         val1=0x00000003, val2=0x00000004\n" +
+		"    2 4294967297.00000000 0xFF      0xFF01         val1=0x00000005, val2=0x00000006\n"
+	if got := b.String(); got != want {
+		t.Errorf("Output.decode() = %q, want %q", got, want)
+	}
+	if want := (Timestamps{Wraparounds: 1, Decreasing: 1}); o.timestamps.count != want {
+		t.Errorf("Output.decode() timestamps = %v, want %v", o.timestamps.count, want)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// vcdUntracked is the value of the state variable of a handle which is
+// not tracked.
+const vcdUntracked = "-"
+
+// vcdWriter writes the states of the handles as Value Change Dump, e.g. for
+// GTKWave. The event records are not part of the dump. Each handle is a
+// scope within the scope of its component and has a string variable with
+// the name of its state and a wire for each state to be plotted, which is
+// 1 while the handle has this state and z while it is not tracked.
+type vcdWriter struct {
+	out    *bufio.Writer
+	states *stateTracker
+}
+
+// newVCDWriter creates the writer, and the state tracker of the output if
+// it has none.
+func newVCDWriter(o *Output, out *bufio.Writer) *vcdWriter {
+	if o.states == nil {
+		o.states = newStateTracker()
+	}
+	return &vcdWriter{out: out, states: o.states}
+}
+
+func (w *vcdWriter) begin() error {
+	return nil
+}
+
+func (w *vcdWriter) write(*EventRecord) error {
+	return nil
+}
+
+// vcdName replaces the white space of a scope or variable name.
+func vcdName(name string) string {
+	return strings.Join(strings.Fields(name), "_")
+}
+
+// vcdID returns the identifier code of the n-th variable.
+func vcdID(n int) string {
+	var id []byte
+	for {
+		id = append(id, byte('!'+n%94))
+		n /= 94
+		if n == 0 {
+			return string(id)
+		}
+		n--
+	}
+}
+
+// vcdVars are the variables of a handle.
+type vcdVars struct {
+	state string            // identifier of the state variable
+	wires map[string]string // identifiers of the wires by state
+}
+
+// value returns the values of the variables of a handle for a state.
+func (v *vcdVars) values(state string) map[string]string {
+	values := make(map[string]string, len(v.wires)+1)
+	if state == "" {
+		values[v.state] = "s" + vcdUntracked + " "
+	} else {
+		values[v.state] = "s" + vcdName(state) + " "
+	}
+	for name, id := range v.wires {
+		switch {
+		case state == "":
+			values[id] = "z"
+		case state == name:
+			values[id] = "1"
+		default:
+			values[id] = "0"
+		}
+	}
+	return values
+}
+
+// declare writes the scopes and variables of the handles.
+func (w *vcdWriter) declare() (map[*handleState]*vcdVars, []string, error) {
+	vars := make(map[*handleState]*vcdVars)
+	var ids []string // in the order of the declaration
+	var components []string
+	handles := make(map[string][]*handleState)
+	for _, h := range w.states.order {
+		if _, ok := handles[h.component]; !ok {
+			components = append(components, h.component)
+		}
+		handles[h.component] = append(handles[h.component], h)
+	}
+	for _, c := range components {
+		if _, err := fmt.Fprintf(w.out, "$scope module %s $end\n", vcdName(c)); err != nil {
+			return nil, nil, err
+		}
+		labels := make(map[string]int)
+		for _, h := range handles[c] {
+			labels[h.label()]++
+		}
+		for _, h := range handles[c] {
+			label := h.label()
+			if labels[label] > 1 {
+				label = fmt.Sprintf("%s_0x%08X", label, uint32(h.handle))
+			}
+			v := &vcdVars{state: vcdID(len(ids)), wires: make(map[string]string)}
+			ids = append(ids, v.state)
+			text := fmt.Sprintf("$scope module %s $end\n$var string 1 %s state $end\n", vcdName(label), v.state)
+			for _, s := range h.states {
+				if s.Plot == "" || strings.EqualFold(s.Plot, "off") {
+					continue
+				}
+				id := vcdID(len(ids))
+				ids = append(ids, id)
+				v.wires[s.Name] = id
+				text += fmt.Sprintf("$var wire 1 %s %s $end\n", id, vcdName(s.Name))
+			}
+			if _, err := w.out.WriteString(text + "$upscope $end\n"); err != nil {
+				return nil, nil, err
+			}
+			vars[h] = v
+		}
+		if _, err := w.out.WriteString("$upscope $end\n"); err != nil {
+			return nil, nil, err
+		}
+	}
+	return vars, ids, nil
+}
+
+// end writes the dump: the declarations, the initial values and the state
+// changes. The times are in nanoseconds and do not decrease.
+func (w *vcdWriter) end(*EventsTable) error {
+	if _, err := w.out.WriteString("$version eventlist $end\n$timescale 1 ns $end\n"); err != nil {
+		return err
+	}
+	vars, ids, err := w.declare()
+	if err != nil {
+		return err
+	}
+	if _, err = w.out.WriteString("$enddefinitions $end\n#0\n$dumpvars\n"); err != nil {
+		return err
+	}
+	current := make(map[string]string)
+	for _, h := range w.states.order {
+		for id, value := range vars[h].values("") {
+			current[id] = value
+		}
+	}
+	for _, id := range ids {
+		if _, err = fmt.Fprintf(w.out, "%s%s\n", current[id], id); err != nil {
+			return err
+		}
+	}
+	if _, err = w.out.WriteString("$end\n"); err != nil {
+		return err
+	}
+
+	var last int64
+	pending := make(map[string]string)
+	flush := func() error {
+		changed := false
+		for _, id := range ids {
+			value, ok := pending[id]
+			if !ok || value == current[id] {
+				continue
+			}
+			if !changed {
+				if _, err := fmt.Fprintf(w.out, "#%d\n", last); err != nil {
+					return err
+				}
+				changed = true
+			}
+			current[id] = value
+			if _, err := fmt.Fprintf(w.out, "%s%s\n", value, id); err != nil {
+				return err
+			}
+		}
+		pending = make(map[string]string)
+		return nil
+	}
+	for _, c := range w.states.changes {
+		t := int64(math.Round(c.time * 1e9))
+		if t > last {
+			if err = flush(); err != nil {
+				return err
+			}
+			last = t
+		}
+		for id, value := range vars[c.h].values(c.state) {
+			pending[id] = value
+		}
+	}
+	return flush()
+}
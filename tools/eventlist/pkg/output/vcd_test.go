@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"eventlist/pkg/eval"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"testing"
+)
+
+// stateEvent is an event of testdata/states.scvd, the time is in microseconds.
+type stateEvent struct {
+	id     uint16
+	ts     uint64
+	handle uint32
+	val2   uint32
+}
+
+// stateLog returns the events in log file format.
+func stateLog(events []stateEvent) *bufio.Reader {
+	var b bytes.Buffer
+	for _, ev := range events {
+		event.AppendRecord(&b, 2, ev.id, ev.ts, false, []uint32{ev.handle, ev.val2}, nil)
+	}
+	return bufio.NewReader(&b)
+}
+
+// stateDefs returns the event definitions of testdata/states.scvd.
+func stateDefs(t *testing.T) (scvd.Events, eval.Typedefs) {
+	files := []string{"../../testdata/states.scvd"}
+	evdefs := make(scvd.Events)
+	typedefs := make(eval.Typedefs)
+	if err := scvd.Get(&files, evdefs, typedefs); err != nil {
+		t.Fatalf("scvd.Get() error = %v", err)
+	}
+	return evdefs, typedefs
+}
+
+func Test_vcdWriter(t *testing.T) { //nolint:golint,paralleltest
+	savedFactor := TimeFactor
+	defer func() { TimeFactor = savedFactor }()
+	factor := 1e-6
+	TimeFactor = &factor
+
+	evdefs, typedefs := stateDefs(t)
+	in := stateLog([]stateEvent{
+		{0xB00, 1, 0x100, 1}, // thread1 Create
+		{0xB00, 2, 0x200, 2}, // thread2 Create
+		{0xB01, 3, 0x100, 0}, // thread1 Running
+		{0xB01, 4, 0x200, 0}, // thread2 Running, thread1 back to Ready
+		{0xC00, 5, 0x300, 0}, // mutex Owned, tracked without Start
+		{0xB02, 6, 0x200, 0}, // thread2 Blocked
+		{0xB03, 7, 0x100, 0}, // thread1 Terminate
+		{0xB04, 8, 0, 0},     // Reset of all threads
+		{0xC01, 9, 0x300, 0}, // mutex Free
+		{0xC01, 9, 0x300, 0}, // no change
+	})
+	want := "$version eventlist $end\n$timescale 1 ns $end\n" +
+		"$scope module Thread $end\n" +
+		"$scope module thread1 $end\n$var string 1 ! state $end\n" +
+		"$var wire 1 \" Ready $end\n$var wire 1 # Running $end\n$var wire 1 $ Blocked $end\n$upscope $end\n" +
+		"$scope module thread2 $end\n$var string 1 % state $end\n" +
+		"$var wire 1 & Ready $end\n$var wire 1 ' Running $end\n$var wire 1 ( Blocked $end\n$upscope $end\n" +
+		"$upscope $end\n" +
+		"$scope module Mutex $end\n" +
+		"$scope module 0x00000300 $end\n$var string 1 ) state $end\n" +
+		"$var wire 1 * Free $end\n$var wire 1 + Owned $end\n$upscope $end\n" +
+		"$upscope $end\n" +
+		"$enddefinitions $end\n#0\n$dumpvars\n" +
+		"s- !\nz\"\nz#\nz$\ns- %\nz&\nz'\nz(\ns- )\nz*\nz+\n$end\n" +
+		"#1000\nsReady !\n1\"\n0#\n0$\n" +
+		"#2000\nsReady %\n1&\n0'\n0(\n" +
+		"#3000\nsRunning !\n0\"\n1#\n" +
+		"#4000\nsReady !\n1\"\n0#\nsRunning %\n0&\n1'\n" +
+		"#5000\nsOwned )\n0*\n1+\n" +
+		"#6000\nsBlocked %\n0'\n1(\n" +
+		"#7000\ns- !\nz\"\nz#\nz$\n" +
+		"#8000\ns- %\nz&\nz'\nz(\n" +
+		"#9000\nsFree )\n1*\n0+\n"
+
+	var b bytes.Buffer
+	out := bufio.NewWriter(&b)
+	o := &Output{columns: []string{"Index", "Time (s)", "Component", "Event Property", "Value"}}
+	o.setWidths(evdefs)
+	w := newVCDWriter(o, out)
+	if _, err := o.decode(in, evdefs, typedefs, w); err != nil {
+		t.Fatalf("Output.decode() error = %v", err)
+	}
+	if err := w.end(&EventsTable{}); err != nil {
+		t.Fatalf("vcdWriter.end() error = %v", err)
+	}
+	out.Flush()
+	if got := b.String(); got != want {
+		t.Errorf("vcdWriter = %v, want %v", got, want)
+	}
+}
+
+func Test_vcdID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "!"},
+		{93, "~"},
+		{94, "!!"},
+		{95, "\"!"},
+		{94 + 94*94 - 1, "~~"},
+		{94 + 94*94, "!!!"},
+	}
+	for _, tt := range tests {
+		if got := vcdID(tt.n); got != tt.want {
+			t.Errorf("vcdID(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"eventlist/pkg/object"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// indentSize is the indentation of sub-items in the text format.
+const indentSize = 2
+
+// ViewsTable holds the component views in the JSON and XML formats.
+type ViewsTable struct {
+	XMLName xml.Name      `json:"-" xml:"ComponentViews"`
+	Views   []object.View `json:"views" xml:"view"`
+}
+
+// propertyWidth returns the width of the property column of items
+// including the indentation of the sub-items.
+func propertyWidth(items []object.Item, indent int) int {
+	width := 0
+	for _, item := range items {
+		if w := indent + len(item.Property); w > width {
+			width = w
+		}
+		if w := propertyWidth(item.Items, indent+indentSize); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// printItems writes items as indented tree, alert items are marked with '!'.
+func printItems(out *bufio.Writer, items []object.Item, indent int, width int) error {
+	for _, item := range items {
+		mark := ' '
+		if item.Alert {
+			mark = '!'
+		}
+		line := strings.TrimRight(fmt.Sprintf("%c %*s%-*s  %s", mark, indent, "", width-indent, item.Property, item.Value), " ")
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+		if err := printItems(out, item.Items, indent+indentSize, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printViews writes the component views in the global FormatType.
+func printViews(out *bufio.Writer, views []object.View) error {
+	switch FormatType {
+	case "json":
+		table := ViewsTable{Views: views}
+		if table.Views == nil {
+			table.Views = []object.View{}
+		}
+		data, err := json.Marshal(table)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case "xml":
+		enc := xml.NewEncoder(out)
+		if err := enc.Encode(ViewsTable{Views: views}); err != nil {
+			return err
+		}
+		return enc.Flush()
+	}
+	for i, view := range views {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+		title := "Component view: " + view.Name
+		if _, err := fmt.Fprintf(out, "   %s\n   %s\n\n", title, strings.Repeat("-", len(title))); err != nil {
+			return err
+		}
+		if err := printItems(out, view.Items, 0, propertyWidth(view.Items, 0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintViews writes component views rendered from SCVD object definitions
+// to a specified file or standard output in a given format.
+//
+// Parameters:
+//   - filename: Pointer to the name of the file where the output will be written. If nil or empty, output is written to stdout.
+//   - formatType: Pointer to the format of the views, one of ReportFormats. If nil or empty, the current format is used.
+//   - views: The component views.
+//
+// Returns:
+//   - error: An error if the format is not supported or the file could not be created or written to.
+func PrintViews(filename *string, formatType *string, views []object.View) error {
+	var file *os.File
+	var err error
+
+	if err = setFormat(formatType, ReportFormats); err != nil {
+		return err
+	}
+	if filename != nil && len(*filename) != 0 {
+		if file, err = createOutput(*filename); err != nil {
+			return err
+		}
+		defer file.Close()
+	} else {
+		file = os.Stdout
+	}
+
+	out := bufio.NewWriter(file)
+	err = printViews(out, views)
+	if err == nil {
+		err = out.Flush()
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"eventlist/pkg/object"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrintViews(t *testing.T) { //nolint:golint,paralleltest
+	views := []object.View{
+		{Name: "Fault", Items: []object.Item{
+			{Property: "Count", Value: "1"},
+			{Property: "Registers", Items: []object.Item{
+				{Property: "CFSR", Value: "0x200", Alert: true, Items: []object.Item{{Property: "PRECISERR", Value: "1", Bold: true}}},
+			}},
+		}},
+		{Name: "Empty", Items: []object.Item{}},
+	}
+
+	txt := "   Component view: Fault\n" +
+		"   ---------------------\n\n" +
+		"  Count          1\n" +
+		"  Registers\n" +
+		"!   CFSR         0x200\n" +
+		"      PRECISERR  1\n" +
+		"\n" +
+		"   Component view: Empty\n" +
+		"   ---------------------\n\n"
+	json := "{\"views\":[{\"name\":\"Fault\",\"items\":[{\"property\":\"Count\",\"value\":\"1\"}," +
+		"{\"property\":\"Registers\",\"value\":\"\",\"items\":[{\"property\":\"CFSR\",\"value\":\"0x200\",\"alert\":true," +
+		"\"items\":[{\"property\":\"PRECISERR\",\"value\":\"1\",\"bold\":true}]}]}]}," +
+		"{\"name\":\"Empty\",\"items\":[]}]}"
+	xml := "<ComponentViews><view name=\"Fault\"><item><property>Count</property><value>1</value></item>" +
+		"<item><property>Registers</property><value></value><item><property>CFSR</property><value>0x200</value><alert>true</alert>" +
+		"<item><property>PRECISERR</property><value>1</value><bold>true</bold></item></item></item></view>" +
+		"<view name=\"Empty\"></view></ComponentViews>"
+
+	tests := []struct {
+		name   string
+		format string
+		views  []object.View
+		want   string
+	}{
+		{"txt", "txt", views, txt},
+		{"json", "json", views, json},
+		{"json empty", "json", nil, "{\"views\":[]}"},
+		{"xml", "xml", views, xml},
+	}
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			FormatType = "txt"
+			file := filepath.Join(t.TempDir(), "views.txt")
+			if err := PrintViews(&file, &tt.format, tt.views); err != nil {
+				t.Fatalf("PrintViews() %s error = %v", tt.name, err)
+			}
+			got, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("PrintViews() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	dir := t.TempDir()
+	if err := PrintViews(&dir, nil, views); err == nil {
+		t.Errorf("PrintViews() directory error = nil")
+	}
+}
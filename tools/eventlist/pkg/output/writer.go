@@ -0,0 +1,375 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"eventlist/pkg/event"
+	"eventlist/pkg/xml/scvd"
+	"fmt"
+	"io"
+	"os"
+)
+
+// recordWriter writes the event records while the event file is decoded,
+// so that no event has to be kept in memory.
+type recordWriter interface {
+	begin() error
+	write(record *EventRecord) error
+	end(table *EventsTable) error
+}
+
+// newRecordWriter returns the record writer for the global FormatType.
+//
+// Parameters:
+//   - o: The Output providing the column widths of the text format.
+//   - out: The buffered writer receiving the records.
+//
+// Returns:
+//
+//	The record writer for the selected format.
+func newRecordWriter(o *Output, out *bufio.Writer) recordWriter {
+	switch FormatType {
+	case "json":
+		return &jsonWriter{out: out}
+	case "xml":
+		return &xmlWriter{out: out, enc: xml.NewEncoder(out)}
+	case "perfetto":
+		return newPerfettoWriter(out)
+	case "ctf":
+		return newCTFWriter(o.dir, out)
+	case "vcd":
+		return newVCDWriter(o, out)
+	case "csv":
+		return newCSVWriter(o, out, ',')
+	case "tsv":
+		return newCSVWriter(o, out, '\t')
+	}
+	return &txtWriter{o: o, out: out}
+}
+
+type txtWriter struct {
+	o   *Output
+	out *bufio.Writer
+}
+
+func (w *txtWriter) begin() error {
+	return nil
+}
+
+// ANSI escape sequences highlighting alert and bold records.
+const (
+	ansiAlert = "\x1b[31m" // red
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// write writes one event record as a line of the detailed event list.
+// Alert and bold records are highlighted if the output is a terminal.
+func (w *txtWriter) write(record *EventRecord) error {
+	format := "%5d %.8f %*s %*s %s"
+	if record.quoted {
+		format = "%5d %.8f %*s %*s \"%s\""
+	}
+	var begin, end string
+	if w.o.highlight && (record.Alert || record.Bold) {
+		if record.Alert {
+			begin = ansiAlert
+		}
+		if record.Bold {
+			begin += ansiBold
+		}
+		end = ansiReset
+	}
+	_, err := fmt.Fprintf(w.out, "%s"+format+"%s\n", begin, record.Index, record.Time,
+		-w.o.componentSize, record.Component, -w.o.propertySize, record.EventProperty, record.Value, end)
+	return err
+}
+
+func (w *txtWriter) end(*EventsTable) error {
+	return nil
+}
+
+type jsonWriter struct {
+	out   *bufio.Writer
+	count int
+}
+
+// begin opens the JSON object and the events array.
+func (w *jsonWriter) begin() error {
+	_, err := w.out.WriteString("{\"events\":[")
+	return err
+}
+
+// write appends one event record to the events array.
+func (w *jsonWriter) write(record *EventRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if w.count > 0 {
+		if err = w.out.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	w.count++
+	_, err = w.out.Write(data)
+	return err
+}
+
+// end closes the events array and writes the statistics array, the
+// events dropped by the level filter, the states of the handles, the
+// decode diagnostics, the reconstructed timestamps and the components.
+func (w *jsonWriter) end(table *EventsTable) error {
+	statistics := table.Statistics
+	if statistics == nil {
+		statistics = []EventRecordStatistic{}
+	}
+	data, err := json.Marshal(statistics)
+	if err != nil {
+		return err
+	}
+	if _, err = w.out.WriteString("],\"statistics\":"); err != nil {
+		return err
+	}
+	if _, err = w.out.Write(data); err != nil {
+		return err
+	}
+	if len(table.Dropped) != 0 {
+		if data, err = json.Marshal(table.Dropped); err != nil {
+			return err
+		}
+		if _, err = w.out.WriteString(",\"dropped\":"); err != nil {
+			return err
+		}
+		if _, err = w.out.Write(data); err != nil {
+			return err
+		}
+	}
+	if len(table.States) != 0 {
+		if data, err = json.Marshal(table.States); err != nil {
+			return err
+		}
+		if _, err = w.out.WriteString(",\"states\":"); err != nil {
+			return err
+		}
+		if _, err = w.out.Write(data); err != nil {
+			return err
+		}
+	}
+	if len(table.Diagnostics) != 0 {
+		if data, err = json.Marshal(table.Diagnostics); err != nil {
+			return err
+		}
+		if _, err = w.out.WriteString(",\"diagnostics\":"); err != nil {
+			return err
+		}
+		if _, err = w.out.Write(data); err != nil {
+			return err
+		}
+	}
+	if len(table.DiagnosticCounts) != 0 {
+		if data, err = json.Marshal(table.DiagnosticCounts); err != nil {
+			return err
+		}
+		if _, err = w.out.WriteString(",\"diagnosticCounts\":"); err != nil {
+			return err
+		}
+		if _, err = w.out.Write(data); err != nil {
+			return err
+		}
+	}
+	if table.Timestamps != nil {
+		if data, err = json.Marshal(table.Timestamps); err != nil {
+			return err
+		}
+		if _, err = w.out.WriteString(",\"timestamps\":"); err != nil {
+			return err
+		}
+		if _, err = w.out.Write(data); err != nil {
+			return err
+		}
+	}
+	if len(table.Components) != 0 {
+		if data, err = json.Marshal(table.Components); err != nil {
+			return err
+		}
+		if _, err = w.out.WriteString(",\"components\":"); err != nil {
+			return err
+		}
+		if _, err = w.out.Write(data); err != nil {
+			return err
+		}
+	}
+	return w.out.WriteByte('}')
+}
+
+type xmlWriter struct {
+	out *bufio.Writer
+	enc *xml.Encoder
+}
+
+var xmlTable = xml.StartElement{Name: xml.Name{Local: "EventsTable"}}
+
+// begin opens the EventsTable element.
+func (w *xmlWriter) begin() error {
+	return w.enc.EncodeToken(xmlTable)
+}
+
+// write writes one event record as events element.
+func (w *xmlWriter) write(record *EventRecord) error {
+	return w.enc.EncodeElement(record, xml.StartElement{Name: xml.Name{Local: "events"}})
+}
+
+// end writes the statistics, dropped, states, diagnostics, diagnosticCounts, timestamps and
+// components elements and
+// closes the EventsTable element.
+func (w *xmlWriter) end(table *EventsTable) error {
+	for i := range table.Statistics {
+		if err := w.enc.EncodeElement(&table.Statistics[i], xml.StartElement{Name: xml.Name{Local: "statistics"}}); err != nil {
+			return err
+		}
+	}
+	for i := range table.Dropped {
+		if err := w.enc.EncodeElement(&table.Dropped[i], xml.StartElement{Name: xml.Name{Local: "dropped"}}); err != nil {
+			return err
+		}
+	}
+	for i := range table.States {
+		if err := w.enc.EncodeElement(&table.States[i], xml.StartElement{Name: xml.Name{Local: "states"}}); err != nil {
+			return err
+		}
+	}
+	for i := range table.Diagnostics {
+		if err := w.enc.EncodeElement(&table.Diagnostics[i], xml.StartElement{Name: xml.Name{Local: "diagnostics"}}); err != nil {
+			return err
+		}
+	}
+	for i := range table.DiagnosticCounts {
+		if err := w.enc.EncodeElement(&table.DiagnosticCounts[i], xml.StartElement{Name: xml.Name{Local: "diagnosticCounts"}}); err != nil {
+			return err
+		}
+	}
+	if table.Timestamps != nil {
+		if err := w.enc.EncodeElement(table.Timestamps, xml.StartElement{Name: xml.Name{Local: "timestamps"}}); err != nil {
+			return err
+		}
+	}
+	for i := range table.Components {
+		if err := w.enc.EncodeElement(&table.Components[i], xml.StartElement{Name: xml.Name{Local: "components"}}); err != nil {
+			return err
+		}
+	}
+	if err := w.enc.EncodeToken(xmlTable.End()); err != nil {
+		return err
+	}
+	return w.enc.Flush()
+}
+
+// flushWriter flushes every record of a live event stream.
+type flushWriter struct {
+	recordWriter
+	out *bufio.Writer
+}
+
+func (w *flushWriter) write(record *EventRecord) error {
+	if err := w.recordWriter.write(record); err != nil {
+		return err
+	}
+	return w.out.Flush()
+}
+
+// spoolWriter buffers the records in a temporary file while the statistics
+// shown before the events are built.
+type spoolWriter struct {
+	file *os.File
+	out  *bufio.Writer
+	enc  *gob.Encoder
+}
+
+// spooledRecord is a buffered record with its unexported fields.
+type spooledRecord struct {
+	Record EventRecord
+	Quoted bool
+	ID     scvd.IDType
+	IRQ    bool
+	Raw    *event.Data
+}
+
+func newSpoolWriter() (*spoolWriter, error) {
+	file, err := os.CreateTemp("", "eventlist-*.spool")
+	if err != nil {
+		return nil, err
+	}
+	out := bufio.NewWriter(file)
+	return &spoolWriter{file: file, out: out, enc: gob.NewEncoder(out)}, nil
+}
+
+func (w *spoolWriter) begin() error {
+	return nil
+}
+
+func (w *spoolWriter) write(record *EventRecord) error {
+	return w.enc.Encode(spooledRecord{Record: *record, Quoted: record.quoted, ID: record.id, IRQ: record.irq, Raw: record.raw})
+}
+
+func (w *spoolWriter) end(*EventsTable) error {
+	return nil
+}
+
+// replay writes the buffered records in their order.
+//
+// Parameters:
+//   - to: The record writer of the output.
+//
+// Returns:
+//   - An error if the buffer cannot be read or a record cannot be written.
+func (w *spoolWriter) replay(to recordWriter) error {
+	if err := w.out.Flush(); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	dec := gob.NewDecoder(bufio.NewReader(w.file))
+	for {
+		var r spooledRecord
+		if err := dec.Decode(&r); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		record := r.Record
+		record.quoted, record.id, record.irq, record.raw = r.Quoted, r.ID, r.IRQ, r.Raw
+		if err := to.write(&record); err != nil {
+			return err
+		}
+	}
+}
+
+// close removes the temporary file.
+func (w *spoolWriter) close() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
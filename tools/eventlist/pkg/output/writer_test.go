@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func Test_recordWriter(t *testing.T) { //nolint:golint,paralleltest
+	records := []EventRecord{
+		{Index: 0, Time: 1.5, Component: "c", EventProperty: "p", Value: "v, w"},
+		{Index: 2, Time: 2.5, Component: "0xFE", EventProperty: "0xFE00", Value: "hello", quoted: true},
+		{Index: 3, Time: 3.5, Component: "c", EventProperty: "p", Value: "error", Alert: true, Bold: true},
+	}
+	stats := []EventRecordStatistic{{Event: "A(0)", Count: 1}}
+	dropped := []EventsDropped{{Level: "Detail", Count: 5}, {Level: "none", Count: 1}}
+	states := []HandleStates{{Component: "c", Handle: "0x00000001", Name: "h1",
+		Durations: []StateDuration{{State: "s", Count: 1, Total: 0.5}},
+		Timeline:  []StateInterval{{State: "s", Start: 1, Duration: 0.5}}}}
+	diagnostics := []Diagnostic{{Index: 1, ID: "0x0101", Kind: "enum", Message: "m"}}
+
+	txt := "    0 1.50000000 c         p              v, w\n" +
+		"    2 2.50000000 0xFE      0xFE00         \"hello\"\n" +
+		"    3 3.50000000 c         p              error\n"
+	json0 := "{\"events\":[],\"statistics\":[]}"
+	json2 := "{\"events\":[" +
+		"{\"index\":0,\"time\":1.5,\"component\":\"c\",\"eventProperty\":\"p\",\"value\":\"v, w\"}," +
+		"{\"index\":2,\"time\":2.5,\"component\":\"0xFE\",\"eventProperty\":\"0xFE00\",\"value\":\"hello\"}," +
+		"{\"index\":3,\"time\":3.5,\"component\":\"c\",\"eventProperty\":\"p\",\"value\":\"error\",\"alert\":true,\"bold\":true}]," +
+		"\"statistics\":[{\"event\":\"A(0)\",\"count\":1,\"addCount\":\"\",\"start\":\"\",\"minStopTime\":0,\"maxStopTime\":0," +
+		"\"total\":\"\",\"min\":\"\",\"max\":\"\",\"first\":\"\",\"last\":\"\",\"avg\":\"\",\"minTime\":0,\"maxTime\":0," +
+		"\"firstTime\":\"\",\"lastTime\":\"\",\"textB\":\"\",\"textMinB\":\"\",\"textMinE\":\"\",\"textMaxB\":\"\",\"textMaxE\":\"\"}]}"
+	json3 := "{\"events\":[],\"statistics\":[],\"dropped\":[{\"level\":\"Detail\",\"count\":5},{\"level\":\"none\",\"count\":1}]}"
+	json4 := "{\"events\":[],\"statistics\":[],\"states\":[{\"component\":\"c\",\"handle\":\"0x00000001\",\"name\":\"h1\"," +
+		"\"durations\":[{\"state\":\"s\",\"count\":1,\"total\":0.5}],\"timeline\":[{\"state\":\"s\",\"start\":1,\"duration\":0.5}]}]}"
+	json5 := "{\"events\":[],\"statistics\":[],\"diagnostics\":[{\"index\":1,\"id\":\"0x0101\",\"kind\":\"enum\",\"message\":\"m\"}]}"
+	json6 := "{\"events\":[],\"statistics\":[],\"timestamps\":{\"wraparounds\":2,\"decreasing\":1}}"
+	xml0 := "<EventsTable></EventsTable>"
+	xml2 := "<EventsTable><dropped><level>Detail</level><count>5</count></dropped>" +
+		"<dropped><level>none</level><count>1</count></dropped></EventsTable>"
+	xml3 := "<EventsTable><states><component>c</component><handle>0x00000001</handle><name>h1</name>" +
+		"<duration><state>s</state><count>1</count><total>0.5</total></duration>" +
+		"<interval><state>s</state><start>1</start><duration>0.5</duration></interval></states></EventsTable>"
+	xml4 := "<EventsTable><diagnostics><index>1</index><id>0x0101</id><kind>enum</kind><message>m</message></diagnostics></EventsTable>"
+	xml5 := "<EventsTable><timestamps><wraparounds>2</wraparounds><decreasing>1</decreasing></timestamps></EventsTable>"
+	xml1 := "<EventsTable><events><index>0</index><time>1.5</time><component>c</component>" +
+		"<eventProperty>p</eventProperty><value>v, w</value></events>" +
+		"<events><index>3</index><time>3.5</time><component>c</component>" +
+		"<eventProperty>p</eventProperty><value>error</value><alert>true</alert><bold>true</bold></events>" +
+		"<statistics><event>A(0)</event><count>1</count><addCount></addCount><start></start>" +
+		"<minStopTime>0</minStopTime><maxStopTime>0</maxStopTime><total></total><min></min><max></max>" +
+		"<first></first><last></last><avg></avg><minTime>0</minTime><maxTime>0</maxTime>" +
+		"<firstTime></firstTime><lastTime></lastTime><textB></textB><textMinB></textMinB>" +
+		"<textMinE></textMinE><textMaxB></textMaxB><textMaxE></textMaxE></statistics></EventsTable>"
+
+	tests := []struct {
+		name    string
+		format  string
+		records []EventRecord
+		table   EventsTable
+		want    string
+	}{
+		{"txt", "txt", records, EventsTable{Statistics: stats, Dropped: dropped}, txt},
+		{"json empty", "json", nil, EventsTable{}, json0},
+		{"json", "json", records, EventsTable{Statistics: stats}, json2},
+		{"json dropped", "json", nil, EventsTable{Dropped: dropped}, json3},
+		{"json states", "json", nil, EventsTable{States: states}, json4},
+		{"json diagnostics", "json", nil, EventsTable{Diagnostics: diagnostics}, json5},
+		{"json timestamps", "json", nil, EventsTable{Timestamps: &Timestamps{2, 1}}, json6},
+		{"xml empty", "xml", nil, EventsTable{}, xml0},
+		{"xml", "xml", []EventRecord{records[0], records[2]}, EventsTable{Statistics: stats}, xml1},
+		{"xml dropped", "xml", nil, EventsTable{Dropped: dropped}, xml2},
+		{"xml states", "xml", nil, EventsTable{States: states}, xml3},
+		{"xml diagnostics", "xml", nil, EventsTable{Diagnostics: diagnostics}, xml4},
+		{"xml timestamps", "xml", nil, EventsTable{Timestamps: &Timestamps{2, 1}}, xml5},
+	}
+	savedFormat := FormatType
+	defer func() { FormatType = savedFormat }()
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+			FormatType = tt.format
+			o := &Output{componentSize: 9, propertySize: 14}
+			w := newRecordWriter(o, out)
+			if err := w.begin(); err != nil {
+				t.Errorf("recordWriter.begin() %s error = %v", tt.name, err)
+			}
+			for i := range tt.records {
+				if err := w.write(&tt.records[i]); err != nil {
+					t.Errorf("recordWriter.write() %s error = %v", tt.name, err)
+				}
+			}
+			if err := w.end(&tt.table); err != nil {
+				t.Errorf("recordWriter.end() %s error = %v", tt.name, err)
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("recordWriter %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_txtWriter_highlight(t *testing.T) {
+	t.Parallel()
+
+	records := []EventRecord{
+		{Index: 0, Time: 1.5, Component: "c", EventProperty: "p", Value: "v"},
+		{Index: 1, Time: 1.5, Component: "c", EventProperty: "p", Value: "v", Alert: true},
+		{Index: 2, Time: 1.5, Component: "c", EventProperty: "p", Value: "v", Bold: true},
+		{Index: 3, Time: 1.5, Component: "c", EventProperty: "p", Value: "v", Alert: true, Bold: true},
+	}
+	tests := []struct {
+		name      string
+		highlight bool
+		want      string
+	}{
+		{"terminal", true, "    0 1.50000000 c p v\n" +
+			"\x1b[31m    1 1.50000000 c p v\x1b[0m\n" +
+			"\x1b[1m    2 1.50000000 c p v\x1b[0m\n" +
+			"\x1b[31m\x1b[1m    3 1.50000000 c p v\x1b[0m\n"},
+		{"file", false, "    0 1.50000000 c p v\n" +
+			"    1 1.50000000 c p v\n" +
+			"    2 1.50000000 c p v\n" +
+			"    3 1.50000000 c p v\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			out := bufio.NewWriter(&b)
+			w := &txtWriter{o: &Output{componentSize: 1, propertySize: 1, highlight: tt.highlight}, out: out}
+			for i := range records {
+				if err := w.write(&records[i]); err != nil {
+					t.Errorf("txtWriter.write() %s error = %v", tt.name, err)
+				}
+			}
+			out.Flush()
+			if got := b.String(); got != tt.want {
+				t.Errorf("txtWriter.write() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flushWriter(t *testing.T) { //nolint:golint,paralleltest
+	var b bytes.Buffer
+	out := bufio.NewWriter(&b)
+	o := &Output{componentSize: 9, propertySize: 14}
+	w := &flushWriter{&txtWriter{o: o, out: out}, out}
+	record := EventRecord{Index: 1, Time: 0.5, Component: "c", EventProperty: "p", Value: "v"}
+	if err := w.write(&record); err != nil {
+		t.Errorf("flushWriter.write() error = %v", err)
+	}
+	want := "    1 0.50000000 c         p              v\n"
+	if got := b.String(); got != want {
+		t.Errorf("flushWriter.write() = %v, want %v", got, want)
+	}
+}
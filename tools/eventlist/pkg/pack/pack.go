@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pack finds the SCVD files of CMSIS packs and csolution projects:
+// the files of the components in a pack description (.pdsc) and the files
+// listed by the build information of csolution (cbuild-run.yml, *.cbuild.yml).
+package pack
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+var ErrNoPdsc = errors.New("no pack description (.pdsc) in directory")
+var ErrNoSCVD = errors.New("no SCVD files found")
+
+// pdsc holds the component files of a pack description.
+type pdsc struct {
+	Components struct {
+		Components []component `xml:"component"`
+		Bundles    []struct {
+			Components []component `xml:"component"`
+		} `xml:"bundle"`
+	} `xml:"components"`
+}
+
+type component struct {
+	Files []struct {
+		Category string `xml:"category,attr"`
+		Name     string `xml:"name,attr"`
+	} `xml:"files>file"`
+}
+
+// isSCVD checks the extension of an SCVD file.
+func isSCVD(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".scvd")
+}
+
+// localPath converts a path of a pack description or a csolution file,
+// which may use backslashes, and makes it relative to a directory.
+func localPath(dir, name string) string {
+	name = filepath.FromSlash(strings.ReplaceAll(name, "\\", "/"))
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(dir, name)
+}
+
+// pdscFiles returns the SCVD files of all components of a pack description,
+// the files with category "other" and the extension .scvd.
+//
+// Parameters:
+//   - name: The path of the .pdsc file.
+//
+// Returns:
+//   - The paths of the SCVD files.
+//   - An error if the pack description cannot be read.
+func pdscFiles(name string) ([]string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var p pdsc
+	if err = xml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	components := p.Components.Components
+	for _, b := range p.Components.Bundles {
+		components = append(components, b.Components...)
+	}
+	var files []string
+	for _, c := range components {
+		for _, f := range c.Files {
+			if f.Category == "other" && isSCVD(f.Name) {
+				files = append(files, localPath(filepath.Dir(name), f.Name))
+			}
+		}
+	}
+	return files, nil
+}
+
+// dirFiles returns the SCVD files of the pack descriptions in the root
+// directory of a pack.
+func dirFiles(dir string) ([]string, error) {
+	pdscs, err := filepath.Glob(filepath.Join(dir, "*.pdsc"))
+	if err != nil {
+		return nil, err
+	}
+	if len(pdscs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoPdsc, dir)
+	}
+	var files []string
+	for _, name := range pdscs {
+		f, err := pdscFiles(name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f...)
+	}
+	return files, nil
+}
+
+// packRoot returns the directory of the installed packs, CMSIS_PACK_ROOT
+// or the default of the CMSIS-Toolbox.
+func packRoot() string {
+	if root := os.Getenv("CMSIS_PACK_ROOT"); root != "" {
+		return root
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("LOCALAPPDATA"), "Arm", "Packs")
+	}
+	if cache := os.Getenv("XDG_CACHE_HOME"); cache != "" {
+		return filepath.Join(cache, "arm", "packs")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "arm", "packs")
+}
+
+// expand replaces the variables of a csolution path, ${CMSIS_PACK_ROOT}
+// and environment variables.
+func expand(s string) string {
+	return os.Expand(s, func(name string) string {
+		if name == "CMSIS_PACK_ROOT" {
+			return filepath.ToSlash(packRoot())
+		}
+		return os.Getenv(name)
+	})
+}
+
+// unquote removes the quotes of a YAML scalar.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// ymlFiles returns the SCVD files of the build information of csolution:
+// the system descriptions of a cbuild-run.yml file or the component files of
+// a *.cbuild.yml file. Both list the files as "file:" entries, the SCVD files
+// are selected by their extension. Relative paths are relative to the file.
+//
+// Parameters:
+//   - name: The path of the YAML file.
+//
+// Returns:
+//   - The paths of the SCVD files.
+//   - An error if the file cannot be read.
+func ymlFiles(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var files []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimSpace(strings.TrimPrefix(line, "- "))
+		value, ok := strings.CutPrefix(line, "file:")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(value, " #"); i != -1 {
+			value = value[:i] // comment
+		}
+		value = expand(unquote(strings.TrimSpace(value)))
+		if isSCVD(value) {
+			files = append(files, localPath(filepath.Dir(name), value))
+		}
+	}
+	return files, scanner.Err()
+}
+
+// Files returns the SCVD files given with -I. A pack description (.pdsc),
+// the root directory of a pack or the build information of csolution
+// (cbuild-run.yml, *.cbuild.yml) is replaced by the SCVD files it refers
+// to, other names are kept. A file found more than once is used once.
+//
+// Parameters:
+//   - names: The names given with -I.
+//
+// Returns:
+//   - The paths of the SCVD files, in the order of the names.
+//   - An error if a pack description or a csolution file cannot be read or
+//     refers to no SCVD files.
+func Files(names []string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		var found []string
+		var err error
+		info, statErr := os.Stat(name)
+		ext := strings.ToLower(filepath.Ext(name))
+		switch {
+		case statErr == nil && info.IsDir():
+			found, err = dirFiles(name)
+		case ext == ".pdsc":
+			found, err = pdscFiles(name)
+		case ext == ".yml" || ext == ".yaml":
+			found, err = ymlFiles(name)
+		default:
+			found = []string{name}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoSCVD, name)
+		}
+		for _, f := range found {
+			if !seen[f] {
+				seen[f] = true
+				files = append(files, f)
+			}
+		}
+	}
+	return files, nil
+}
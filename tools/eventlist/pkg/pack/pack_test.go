@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pack
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestFiles(t *testing.T) { //nolint:golint,paralleltest
+	t.Setenv("CMSIS_PACK_ROOT", "/packs")
+
+	dir := filepath.FromSlash("../../testdata/pack")
+	events := filepath.Join(dir, "Events", "events.scvd")
+	packEvents := filepath.FromSlash("/packs/Vendor/Test/1.0.0/Events/events.scvd")
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr error
+	}{
+		{"scvd", []string{"a.scvd", "b.xml"}, []string{"a.scvd", "b.xml"}, nil},
+		{"pdsc", []string{filepath.Join(dir, "Vendor.Test.pdsc")}, []string{events}, nil},
+		{"pack", []string{dir}, []string{events}, nil},
+		{"pack and scvd", []string{dir, events, "a.scvd"}, []string{events, "a.scvd"}, nil},
+		{"cbuild", []string{filepath.Join(dir, "out", "test+Target.cbuild.yml")}, []string{packEvents, events}, nil},
+		{"cbuild-run", []string{filepath.Join(dir, "out", "test+Target.cbuild-run.yml")}, []string{events}, nil},
+		{"no scvd", []string{filepath.Join(dir, "out", "empty.cbuild.yml")}, nil, ErrNoSCVD},
+		{"no pdsc", []string{filepath.Join(dir, "Events")}, nil, ErrNoPdsc},
+		{"missing pdsc", []string{filepath.Join(dir, "nix.pdsc")}, nil, os.ErrNotExist},
+		{"missing yml", []string{filepath.Join(dir, "nix.cbuild.yml")}, nil, os.ErrNotExist},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Files(tt.names)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Files() %s error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Files() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_packRoot(t *testing.T) { //nolint:golint,paralleltest
+	t.Setenv("CMSIS_PACK_ROOT", "")
+	t.Setenv("XDG_CACHE_HOME", "/cache")
+	want := filepath.Join("/cache", "arm", "packs")
+	if got := packRoot(); got != want && runtime.GOOS != "windows" {
+		t.Errorf("packRoot() = %v, want %v", got, want)
+	}
+	t.Setenv("CMSIS_PACK_ROOT", "/root")
+	if got := packRoot(); got != "/root" {
+		t.Errorf("packRoot() = %v, want /root", got)
+	}
+}
+
+func Test_pdscFiles(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "bad.pdsc")
+	if err := os.WriteFile(name, []byte("<package><components>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pdscFiles(name); err == nil {
+		t.Errorf("pdscFiles() error = nil")
+	}
+}
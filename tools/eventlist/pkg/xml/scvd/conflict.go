@@ -0,0 +1,249 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"eventlist/pkg/eval"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var ErrConflict = errors.New("conflicting definition")
+var ErrPolicy = errors.New("invalid conflict policy")
+
+// Policy selects the definition used if an event ID or a typedef name is
+// defined differently in the SCVD files.
+type Policy int
+
+const (
+	PolicyError Policy = iota // the SCVD files are rejected
+	PolicyFirst               // the first definition wins
+	PolicyLast                // the last definition wins
+	PolicyWarn                // the last definition wins, the conflicts are reported in Warnings
+)
+
+// Policies are the names of the policies, in the order of their values.
+var Policies = []string{"error", "first-wins", "last-wins", "warn"}
+
+// OnConflict is the policy applied by Get and GetObjects.
+var OnConflict = PolicyWarn
+
+// Warnings are the conflicting definitions found by the last Get or
+// GetObjects with PolicyWarn.
+var Warnings []Conflict
+
+// ParsePolicy parses the name of a policy.
+//
+// Parameters:
+//   - name: The name of the policy, see Policies.
+//
+// Returns:
+//   - The policy.
+//   - ErrPolicy if the name is unknown.
+func ParsePolicy(name string) (Policy, error) {
+	for i, p := range Policies {
+		if name == p {
+			return Policy(i), nil
+		}
+	}
+	return PolicyError, fmt.Errorf("%w: %s", ErrPolicy, name)
+}
+
+// Position is the location of a definition in an SCVD file.
+type Position struct {
+	File   string `json:"file" xml:"file"`
+	Line   int    `json:"line" xml:"line"`
+	Column int    `json:"column,omitempty" xml:"column,omitempty"` // 0 if only the line is known
+}
+
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.File
+	}
+	if p.Column != 0 {
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// Conflict is an event ID or a typedef name defined more than once.
+type Conflict struct {
+	Kind      string   `json:"kind" xml:"kind"` // event or typedef
+	Name      string   `json:"name" xml:"name"` // event ID or typedef name
+	First     Position `json:"first" xml:"first"`
+	Second    Position `json:"second" xml:"second"`
+	Identical bool     `json:"identical,omitempty" xml:"identical,omitempty"` // both definitions are the same
+}
+
+func (c Conflict) String() string {
+	s := fmt.Sprintf("%s %s: %s and %s", c.Kind, c.Name, c.First, c.Second)
+	if c.Identical {
+		s += " (identical)"
+	}
+	return s
+}
+
+// loader collects the definitions of the SCVD files and their positions.
+type loader struct {
+	policy    Policy
+	typedefs  map[string]Position // positions of the typedefs
+	conflicts []Conflict          // all definitions given more than once
+}
+
+func newLoader(policy Policy) *loader {
+	return &loader{policy: policy, typedefs: make(map[string]Position)}
+}
+
+// keep checks a definition which replaces a previous one. Identical
+// definitions are recorded but do not conflict.
+//
+// Parameters:
+//   - c: The definitions, Identical is set by the caller.
+//
+// Returns:
+//   - true if the new definition replaces the previous one.
+//   - ErrConflict if the definitions differ and the policy is PolicyError.
+func (l *loader) keep(c Conflict) (bool, error) {
+	l.conflicts = append(l.conflicts, c)
+	if c.Identical {
+		return true, nil
+	}
+	switch l.policy {
+	case PolicyFirst:
+		return false, nil
+	case PolicyLast, PolicyWarn:
+		return true, nil
+	}
+	return false, fmt.Errorf("%w: %s", ErrConflict, c)
+}
+
+// warnings returns the conflicting definitions if they are reported as warnings.
+func (l *loader) warnings() []Conflict {
+	if l.policy != PolicyWarn {
+		return nil
+	}
+	var warnings []Conflict
+	for _, c := range l.conflicts {
+		if !c.Identical {
+			warnings = append(warnings, c)
+		}
+	}
+	return warnings
+}
+
+// addEvent adds an event definition.
+//
+// Returns:
+//   - ErrConflict if the event ID is already defined differently and the policy is PolicyError.
+func (l *loader) addEvent(events Events, id IDType, event EventType) error {
+	if prev, ok := events[id]; ok {
+		a, b := prev, event
+		a.Position, b.Position = Position{}, Position{}
+		replace, err := l.keep(Conflict{Kind: "event", Name: fmt.Sprintf("0x%04X", uint16(id)),
+			First: prev.Position, Second: event.Position, Identical: reflect.DeepEqual(a, b)})
+		if !replace {
+			return err
+		}
+	}
+	events[id] = event
+	return nil
+}
+
+// addTypedef adds a typedef.
+//
+// Returns:
+//   - ErrConflict if the typedef is already defined differently and the policy is PolicyError.
+func (l *loader) addTypedef(typedefs eval.Typedefs, name string, typedef eval.ITypedef, pos Position) error {
+	if prev, ok := l.typedefs[name]; ok {
+		replace, err := l.keep(Conflict{Kind: "typedef", Name: name,
+			First: prev, Second: pos, Identical: reflect.DeepEqual(typedefs[name], typedef)})
+		if !replace {
+			return err
+		}
+	}
+	typedefs[name] = typedef
+	l.typedefs[name] = pos
+	return nil
+}
+
+// elementLines returns the lines of the <event> and <typedef> elements of
+// an SCVD file, in the order of the file.
+//
+// Parameters:
+//   - data: The content of the SCVD file.
+//
+// Returns:
+//   - The lines of the events.
+//   - The lines of the typedefs.
+//   - An error if the XML is invalid.
+func elementLines(data []byte) ([]int, []int, error) {
+	var events, typedefs []int
+	var path []string
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		line, _ := d.InputPos() // the start of the next token
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return events, typedefs, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			if len(path) == 3 {
+				switch path[1] + ">" + path[2] {
+				case "events>event":
+					events = append(events, line)
+				case "typedefs>typedef":
+					typedefs = append(typedefs, line)
+				}
+			}
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		}
+	}
+}
+
+// Check reads the SCVD files like Get and reports all event IDs and typedef
+// names defined more than once, the last definition wins.
+//
+// Parameters:
+//   - scvdFiles: The paths of the SCVD files.
+//
+// Returns:
+//   - The definitions given more than once, in the order of the files.
+//   - An error if an SCVD file cannot be processed.
+func Check(scvdFiles []string) ([]Conflict, error) {
+	l := newLoader(PolicyLast)
+	events := make(Events)
+	typedefs := make(eval.Typedefs)
+	for _, scvdFile := range scvdFiles {
+		scvdFile := scvdFile
+		if err := getOne(&scvdFile, events, typedefs, nil, l); err != nil {
+			return nil, err
+		}
+	}
+	return l.conflicts, nil
+}
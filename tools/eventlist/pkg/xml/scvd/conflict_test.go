@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"errors"
+	"eventlist/pkg/eval"
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    Policy
+		wantErr error
+	}{
+		{"error", PolicyError, nil},
+		{"first-wins", PolicyFirst, nil},
+		{"last-wins", PolicyLast, nil},
+		{"warn", PolicyWarn, nil},
+		{"first", PolicyError, ErrPolicy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePolicy(tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePolicy() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePolicy() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_elementLines(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("<component_viewer>\n<typedefs>\n  <typedef name=\"a\"/>\n</typedefs>\n" +
+		"<events>\n  <event\n    id=\"1\"/>\n  <event id=\"2\">\n  </event>\n  <group><event id=\"3\"/></group>\n</events>\n" +
+		"<objects><object><event/></object></objects>\n</component_viewer>\n")
+	events, typedefs, err := elementLines(data)
+	if err != nil {
+		t.Fatalf("elementLines() error = %v", err)
+	}
+	if !reflect.DeepEqual(events, []int{6, 8}) || !reflect.DeepEqual(typedefs, []int{3}) {
+		t.Errorf("elementLines() = %v, %v, want [6 8], [3]", events, typedefs)
+	}
+	if _, _, err = elementLines([]byte("<events><event></events>")); err == nil {
+		t.Errorf("elementLines() error = nil, want syntax error")
+	}
+}
+
+func TestGetObjects_conflicts(t *testing.T) { //nolint:golint,paralleltest
+	savedPolicy := OnConflict
+	defer func() { OnConflict = savedPolicy }()
+
+	files := []string{"../../../testdata/test.xml", "../../../testdata/conflict.scvd"}
+	tests := []struct {
+		name    string
+		policy  Policy
+		value   Value
+		enum    string
+		wantErr error
+		warned  int
+	}{
+		{"error", PolicyError, "", "", ErrConflict, 0},
+		{"first-wins", PolicyFirst, "File=fff", "ready", nil, 0},
+		{"last-wins", PolicyLast, "Other=fff", "running", nil, 0},
+		{"warn", PolicyWarn, "Other=fff", "running", nil, 2},
+	}
+	for _, tt := range tests { //nolint:golint,paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			OnConflict = tt.policy
+			events := make(Events)
+			typedefs := make(eval.Typedefs)
+			err := GetObjects(&files, events, typedefs, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetObjects() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := events[0xEF00].Value; got != tt.value {
+				t.Errorf("GetObjects() %s event = %v, want %v", tt.name, got, tt.value)
+			}
+			if got := typedefs["attr"].Members["member"].Enums[1]; got != tt.enum {
+				t.Errorf("GetObjects() %s typedef = %v, want %v", tt.name, got, tt.enum)
+			}
+			if len(Warnings) != tt.warned {
+				t.Errorf("GetObjects() %s warnings = %v, want %d", tt.name, Warnings, tt.warned)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	test := "../../../testdata/test.xml"
+	conflict := "../../../testdata/conflict.scvd"
+	conflicts, err := Check([]string{test, conflict})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	want := []Conflict{
+		{"event", "0xEF00", Position{File: test, Line: 18}, Position{File: conflict, Line: 18}, false},
+		{"event", "0xFE00", Position{File: test, Line: 23}, Position{File: conflict, Line: 23}, true},
+		{"typedef", "attr", Position{File: test, Line: 7}, Position{File: conflict, Line: 7}, false},
+	}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("Check() = %v, want %v", conflicts, want)
+	}
+	if got := want[1].String(); got != "event 0xFE00: "+test+":23 and "+conflict+":23 (identical)" {
+		t.Errorf("Conflict.String() = %v", got)
+	}
+	if conflicts, err = Check([]string{test}); err != nil || len(conflicts) != 0 {
+		t.Errorf("Check() = %v, %v, want no conflicts", conflicts, err)
+	}
+	if _, err = Check([]string{"../../../testdata/xxxxx"}); err == nil {
+		t.Errorf("Check() error = nil, want error")
+	}
+}
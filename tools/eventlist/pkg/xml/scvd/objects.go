@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"encoding/xml"
+)
+
+type ReadType struct {
+	Name   string `xml:"name,attr"`
+	Type   string `xml:"type,attr"`
+	Size   string `xml:"size,attr"`
+	Offset string `xml:"offset,attr"`
+	Symbol string `xml:"symbol,attr"`
+	Const  bool   `xml:"const,attr"`
+	Info   string `xml:"info,attr"`
+	Cond   string `xml:"cond,attr"`
+	Endian string `xml:"endian,attr"`
+}
+
+type ReadlistType struct {
+	Name   string `xml:"name,attr"`
+	Type   string `xml:"type,attr"`
+	Count  string `xml:"count,attr"`
+	Next   string `xml:"next,attr"`
+	Offset string `xml:"offset,attr"`
+	Symbol string `xml:"symbol,attr"`
+	Const  string `xml:"const,attr"`
+	Info   string `xml:"info,attr"`
+	While  string `xml:"while,attr"`
+	Cond   string `xml:"cond,attr"`
+	Init   bool   `xml:"init,attr"`
+	Based  bool   `xml:"based,attr"`
+}
+
+type CalcType struct {
+	Cond string `xml:"cond,attr"`
+	Expr string `xml:",chardata"`
+}
+
+// StatementType is one child element of an <object> or of a <list> inside
+// an object. Exactly one of the fields is set.
+type StatementType struct {
+	List     *ObjectListType
+	Readlist *ReadlistType
+	Read     *ReadType
+	Addr     *ReadType
+	Var      *VarType
+	Calc     *CalcType
+	Out      *OutType
+}
+
+type ObjectListType struct {
+	Name       string
+	Start      string
+	Limit      string
+	While      string
+	Cond       string
+	Statements []StatementType
+}
+
+type ObjectType struct {
+	Name       string
+	Statements []StatementType
+}
+
+type ObjectsType struct {
+	Objects []ObjectType `xml:"object"`
+}
+
+type OutputType struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+	Cond  string `xml:"cond,attr"`
+}
+
+// OutElementType is one child element of an <out>, <item> or of a <list>
+// inside an output. Exactly one of the fields is set.
+type OutElementType struct {
+	Item   *ItemType
+	List   *OutListType
+	Output *OutputType
+}
+
+type ItemType struct {
+	Property string
+	Value    string
+	Info     string
+	Cond     string
+	Alert    string
+	Bold     string
+	Prints   []PrintType
+	Elements []OutElementType
+}
+
+type OutListType struct {
+	Name     string
+	Start    string
+	Limit    string
+	While    string
+	Cond     string
+	Alert    string
+	Bold     string
+	Elements []OutElementType
+}
+
+type OutType struct {
+	Name     string
+	Value    string
+	Type     string
+	Cond     string
+	Alert    string
+	Bold     string
+	Elements []OutElementType
+}
+
+// attrs returns the attributes of an element by name.
+func attrs(start xml.StartElement) map[string]string {
+	m := make(map[string]string, len(start.Attr))
+	for _, a := range start.Attr {
+		m[a.Name.Local] = a.Value
+	}
+	return m
+}
+
+// decodeChildren decodes the child elements of start in document order,
+// child is called for each child element and decodes it.
+func decodeChildren(d *xml.Decoder, start xml.StartElement, child func(xml.StartElement) error) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if err = child(t); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if t.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+// decodeStatement decodes one child element of an object or of a list inside an object.
+func decodeStatement(d *xml.Decoder, start xml.StartElement) (stmt StatementType, err error) {
+	switch start.Name.Local {
+	case "list":
+		stmt.List = new(ObjectListType)
+		err = d.DecodeElement(stmt.List, &start)
+	case "readlist":
+		stmt.Readlist = new(ReadlistType)
+		err = d.DecodeElement(stmt.Readlist, &start)
+	case "read":
+		stmt.Read = new(ReadType)
+		err = d.DecodeElement(stmt.Read, &start)
+	case "addr":
+		stmt.Addr = new(ReadType)
+		err = d.DecodeElement(stmt.Addr, &start)
+	case "var":
+		stmt.Var = new(VarType)
+		err = d.DecodeElement(stmt.Var, &start)
+	case "calc":
+		stmt.Calc = new(CalcType)
+		err = d.DecodeElement(stmt.Calc, &start)
+	case "out":
+		stmt.Out = new(OutType)
+		err = d.DecodeElement(stmt.Out, &start)
+	default:
+		err = d.Skip()
+	}
+	return
+}
+
+// decodeOutElement decodes one child element of an output element.
+func decodeOutElement(d *xml.Decoder, start xml.StartElement) (el OutElementType, err error) {
+	switch start.Name.Local {
+	case "item":
+		el.Item = new(ItemType)
+		err = d.DecodeElement(el.Item, &start)
+	case "list":
+		el.List = new(OutListType)
+		err = d.DecodeElement(el.List, &start)
+	case "output":
+		el.Output = new(OutputType)
+		err = d.DecodeElement(el.Output, &start)
+	default:
+		err = d.Skip()
+	}
+	return
+}
+
+// UnmarshalXML decodes an <object> keeping the order of its statements.
+func (o *ObjectType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	o.Name = attrs(start)["name"]
+	return decodeChildren(d, start, func(child xml.StartElement) error {
+		stmt, err := decodeStatement(d, child)
+		if err == nil && stmt != (StatementType{}) {
+			o.Statements = append(o.Statements, stmt)
+		}
+		return err
+	})
+}
+
+// UnmarshalXML decodes a <list> of an object keeping the order of its statements.
+func (l *ObjectListType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a := attrs(start)
+	l.Name, l.Start, l.Limit, l.While, l.Cond = a["name"], a["start"], a["limit"], a["while"], a["cond"]
+	return decodeChildren(d, start, func(child xml.StartElement) error {
+		stmt, err := decodeStatement(d, child)
+		if err == nil && stmt != (StatementType{}) {
+			l.Statements = append(l.Statements, stmt)
+		}
+		return err
+	})
+}
+
+// UnmarshalXML decodes an <out> keeping the order of its elements.
+func (o *OutType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a := attrs(start)
+	o.Name, o.Value, o.Type, o.Cond, o.Alert, o.Bold = a["name"], a["value"], a["type"], a["cond"], a["alert"], a["bold"]
+	return decodeChildren(d, start, func(child xml.StartElement) error {
+		el, err := decodeOutElement(d, child)
+		if err == nil && el != (OutElementType{}) {
+			o.Elements = append(o.Elements, el)
+		}
+		return err
+	})
+}
+
+// UnmarshalXML decodes an <item> keeping the order of its elements.
+func (it *ItemType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a := attrs(start)
+	it.Property, it.Value, it.Info, it.Cond, it.Alert, it.Bold = a["property"], a["value"], a["info"], a["cond"], a["alert"], a["bold"]
+	return decodeChildren(d, start, func(child xml.StartElement) error {
+		if child.Name.Local == "print" {
+			var p PrintType
+			if err := d.DecodeElement(&p, &child); err != nil {
+				return err
+			}
+			it.Prints = append(it.Prints, p)
+			return nil
+		}
+		el, err := decodeOutElement(d, child)
+		if err == nil && el != (OutElementType{}) {
+			it.Elements = append(it.Elements, el)
+		}
+		return err
+	})
+}
+
+// UnmarshalXML decodes a <list> of an output keeping the order of its elements.
+func (l *OutListType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a := attrs(start)
+	l.Name, l.Start, l.Limit, l.While, l.Cond, l.Alert, l.Bold = a["name"], a["start"], a["limit"], a["while"], a["cond"], a["alert"], a["bold"]
+	return decodeChildren(d, start, func(child xml.StartElement) error {
+		el, err := decodeOutElement(d, child)
+		if err == nil && el != (OutElementType{}) {
+			l.Elements = append(l.Elements, el)
+		}
+		return err
+	})
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"encoding/xml"
+	"eventlist/pkg/eval"
+	"reflect"
+	"testing"
+)
+
+func TestGetObjects(t *testing.T) {
+	files := []string{"../../../testdata/objects.scvd", "../../../testdata/test.xml"}
+	var objects Objects
+	if err := GetObjects(&files, make(Events), make(eval.Typedefs), &objects); err != nil {
+		t.Fatalf("GetObjects() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].Name != "Threads" {
+		t.Fatalf("GetObjects() = %+v, want object Threads", objects)
+	}
+	stmts := objects[0].Statements
+	if len(stmts) != 9 {
+		t.Fatalf("GetObjects() statements = %d, want 9", len(stmts))
+	}
+	if stmts[0].Var == nil || stmts[2].Readlist == nil || stmts[3].Read == nil || stmts[5].Addr == nil ||
+		stmts[6].List == nil || stmts[7].Calc == nil || stmts[8].Out == nil {
+		t.Errorf("GetObjects() order = %+v", stmts)
+	}
+	rl := stmts[2].Readlist
+	if rl.Type != "Thread_t" || !rl.Based || rl.Next != "next" || rl.Symbol != "ThreadList" {
+		t.Errorf("GetObjects() readlist = %+v", *rl)
+	}
+	if stmts[4].Read.Endian != "B" || stmts[4].Read.Offset != "6" {
+		t.Errorf("GetObjects() read = %+v", *stmts[4].Read)
+	}
+	calc := stmts[6].List.Statements[0].Calc
+	if calc == nil || calc.Cond != "TCB[i].state == 2" || calc.Expr != "running = TCB[i].id;" {
+		t.Errorf("GetObjects() list calc = %+v", stmts[6].List.Statements)
+	}
+	out := stmts[8].Out
+	if out.Name != "Threads" || len(out.Elements) != 8 || out.Elements[1].List == nil {
+		t.Fatalf("GetObjects() out = %+v", *out)
+	}
+	item := out.Elements[1].List.Elements[0].Item
+	if item == nil || item.Bold != "TCB[i].state == 2" || len(item.Prints) != 1 || len(item.Elements) != 1 {
+		t.Errorf("GetObjects() list item = %+v", item)
+	}
+
+	// objects are not collected by Get
+	if err := GetObjects(&files, make(Events), make(eval.Typedefs), nil); err != nil {
+		t.Errorf("GetObjects() nil error = %v", err)
+	}
+}
+
+func TestObjectType_UnmarshalXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		xml     string
+		want    ObjectType
+		wantErr bool
+	}{
+		{"empty", `<object name="o"/>`, ObjectType{Name: "o"}, false},
+		{"order", `<object name="o"><calc>a=1;</calc><unknown/><var name="a" value="2"/></object>`,
+			ObjectType{Name: "o", Statements: []StatementType{
+				{Calc: &CalcType{Expr: "a=1;"}},
+				{Var: &VarType{Name: "a", Value: "2"}},
+			}}, false},
+		{"out", `<object name="o"><out name="v"><item property="p" value="%d[a]"><print cond="a" value="x"/><item property="s"/></item></out></object>`,
+			ObjectType{Name: "o", Statements: []StatementType{
+				{Out: &OutType{Name: "v", Elements: []OutElementType{
+					{Item: &ItemType{Property: "p", Value: "%d[a]", Prints: []PrintType{{Cond: "a", Value: "x"}},
+						Elements: []OutElementType{{Item: &ItemType{Property: "s"}}}}},
+				}}},
+			}}, false},
+		{"syntax", `<object name="o"><var>`, ObjectType{}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got ObjectType
+			err := xml.Unmarshal([]byte(tt.xml), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ObjectType.UnmarshalXML() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ObjectType.UnmarshalXML() %s = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// attrType checks the value of an attribute, nil for xs:string.
+type attrType struct {
+	name  string         // name of the type in the messages
+	match *regexp.Regexp // pattern of the value
+}
+
+// Attribute types of Component_Viewer.xsd.
+var (
+	nonNegativeInteger = &attrType{"NonNegativeInteger", regexp.MustCompile(`^[+]?((0x|0X)?[0-9a-fA-F]+|[0-9]+)$`)}
+	integer            = &attrType{"Integer", regexp.MustCompile(`^[+-]?((0x|0X)[0-9a-fA-F]+|[0-9]+)$`)}
+	boolean            = &attrType{"true, false, 1 or 0", regexp.MustCompile(`^(true|false|1|0)$`)}
+	endianEnum         = &attrType{"B, b, L or l", regexp.MustCompile(`^\s*[BbLl]\s*$`)}
+	levelEnum          = &attrType{"Error, API, Op or Detail", regexp.MustCompile(`^\s*(Error|API|Op|Detail)\s*$`)}
+	plotEnum           = &attrType{"line, box or off", regexp.MustCompile(`^(line|box|off)$`)}
+	colorEnum          = &attrType{"red, green, black or blue", regexp.MustCompile(`^(red|green|black|blue)$`)}
+	trackingEnum       = &attrType{"Start or Stop", regexp.MustCompile(`^(Start|Stop)$`)}
+)
+
+// attrRule is an attribute of an element type.
+type attrRule struct {
+	typ      *attrType
+	required bool
+}
+
+// elementRule is an element type of Component_Viewer.xsd: its attributes
+// and the element types of its child elements.
+type elementRule struct {
+	attrs    map[string]attrRule
+	children map[string]string
+}
+
+// optional and required are shortcuts for the attribute rules.
+var (
+	optional = attrRule{}
+	required = attrRule{required: true}
+)
+
+// schema holds the element types of Component_Viewer.xsd.
+var schema = map[string]elementRule{
+	"component_viewer": {
+		attrs:    map[string]attrRule{"schemaVersion": optional},
+		children: map[string]string{"component": "ComponentsType", "typedefs": "TypedefsType", "objects": "ObjectsType", "events": "EventsType"},
+	},
+	"ComponentsType": {
+		attrs: map[string]attrRule{"name": required, "shortname": optional, "version": optional},
+	},
+	"TypedefsType": {
+		children: map[string]string{"typedef": "TypedefType"},
+	},
+	"TypedefType": {
+		attrs: map[string]attrRule{"name": required, "size": {typ: nonNegativeInteger}, "const": {typ: boolean},
+			"info": optional, "endian": {typ: endianEnum}, "import": optional},
+		children: map[string]string{"member": "MemberType", "var": "VarType"},
+	},
+	"MemberType": {
+		attrs: map[string]attrRule{"name": required, "type": required, "offset": required, "size": {typ: nonNegativeInteger},
+			"info": optional, "endian": {typ: endianEnum}},
+		children: map[string]string{"enum": "EnumType"},
+	},
+	"EnumType": {
+		attrs: map[string]attrRule{"name": optional, "value": optional, "info": optional},
+	},
+	"VarType": {
+		attrs: map[string]attrRule{"name": optional, "value": optional, "type": optional, "size": {typ: nonNegativeInteger}, "info": optional},
+	},
+	"ObjectsType": {
+		children: map[string]string{"object": "ObjectType"},
+	},
+	"ObjectType": {
+		attrs: map[string]attrRule{"name": required},
+		children: map[string]string{"list": "ListTypeO", "readlist": "ReadlistType", "read": "ReadType", "addr": "ReadType",
+			"var": "VarType", "calc": "CalcType", "out": "OutType"},
+	},
+	"ListTypeO": {
+		attrs:    map[string]attrRule{"name": required, "start": required, "limit": optional, "while": optional, "cond": optional},
+		children: map[string]string{"list": "ListTypeO", "readlist": "ReadlistType", "read": "ReadType", "var": "VarType", "calc": "CalcType"},
+	},
+	"ReadType": {
+		attrs: map[string]attrRule{"name": required, "type": required, "size": optional, "offset": optional, "symbol": optional,
+			"const": {typ: boolean}, "info": optional, "cond": optional, "endian": {typ: endianEnum}},
+	},
+	"ReadlistType": {
+		attrs: map[string]attrRule{"name": required, "type": required, "count": optional, "next": optional, "offset": optional,
+			"symbol": optional, "const": {typ: integer}, "info": optional, "while": optional, "cond": optional,
+			"init": {typ: boolean}, "based": {typ: boolean}},
+	},
+	"CalcType": {
+		attrs: map[string]attrRule{"cond": optional},
+	},
+	"OutType": {
+		attrs:    map[string]attrRule{"name": optional, "value": optional, "type": optional, "cond": optional, "alert": optional, "bold": optional},
+		children: map[string]string{"item": "ItemType", "output": "OutputType", "list": "ListType"},
+	},
+	"ItemType": {
+		attrs:    map[string]attrRule{"property": optional, "value": optional, "info": optional, "cond": optional, "alert": optional, "bold": optional},
+		children: map[string]string{"item": "ItemType", "print": "PrintType", "output": "OutputType", "list": "ListType"},
+	},
+	"ListType": {
+		attrs: map[string]attrRule{"name": required, "start": required, "limit": optional, "while": optional, "cond": optional,
+			"alert": optional, "bold": optional},
+		children: map[string]string{"item": "ItemType", "output": "OutputType", "list": "ListType"},
+	},
+	"OutputType": {
+		attrs: map[string]attrRule{"name": required, "value": optional, "cond": optional},
+	},
+	"PrintType": {
+		attrs: map[string]attrRule{"cond": required, "property": required, "value": required, "alert": optional, "bold": optional},
+	},
+	"EventsType": {
+		children: map[string]string{"group": "GroupType", "event": "EventType"},
+	},
+	"GroupType": {
+		attrs:    map[string]attrRule{"name": optional},
+		children: map[string]string{"group": "GroupType", "component": "ComponentType"},
+	},
+	"ComponentType": {
+		attrs: map[string]attrRule{"no": {typ: nonNegativeInteger}, "name": optional, "prefix": optional, "brief": optional,
+			"info": optional},
+		children: map[string]string{"state": "StateType"},
+	},
+	"StateType": {
+		attrs: map[string]attrRule{"name": required, "plot": {typ: plotEnum, required: true}, "bold": {typ: boolean},
+			"dormant": {typ: boolean}, "unique": {typ: boolean}, "reset": {typ: boolean}, "color": {typ: colorEnum},
+			"tracking": {typ: trackingEnum}},
+	},
+	"EventType": {
+		attrs: map[string]attrRule{"name": optional, "id": required, "level": {typ: levelEnum, required: true},
+			"val1": optional, "val2": optional, "val3": optional, "val4": optional, "val5": optional, "val6": optional,
+			"value": optional, "property": optional, "info": optional, "doc": optional, "alert": optional, "bold": optional,
+			"state": optional, "handle": optional, "hname": optional, "reset": {typ: boolean}, "tracking": optional},
+		children: map[string]string{"print": "PrintType"},
+	},
+}
+
+// validate checks an element against the rules of its type.
+//
+// Parameters:
+//   - el: The element.
+//   - typ: The element type, empty if the element is unknown in its parent.
+//   - parent: The name of the parent element, empty for the root element.
+//
+// Returns:
+//   - The problems of the element.
+func validate(el *Element, typ string, parent string) []Problem {
+	if typ == "" {
+		if parent == "" {
+			return []Problem{{el.Pos, fmt.Sprintf("root element <%s>, want <component_viewer>", el.Name)}}
+		}
+		return []Problem{{el.Pos, fmt.Sprintf("unknown element <%s> in <%s>", el.Name, parent)}}
+	}
+	rule := schema[typ]
+	var problems []Problem
+	for _, a := range el.Attrs {
+		r, ok := rule.attrs[a.Name]
+		switch {
+		case !ok:
+			problems = append(problems, Problem{a.Pos, fmt.Sprintf("unknown attribute %s of <%s>", a.Name, el.Name)})
+		case r.typ != nil && !r.typ.match.MatchString(a.Value):
+			problems = append(problems, Problem{a.Pos, fmt.Sprintf("invalid %s=%q of <%s>, want %s", a.Name, a.Value, el.Name, r.typ.name)})
+		}
+	}
+	var missing []string
+	for name, r := range rule.attrs {
+		if _, ok := el.Attr(name); r.required && !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		problems = append(problems, Problem{el.Pos, fmt.Sprintf("missing attribute %s of <%s>", strings.Join(missing, ", "), el.Name)})
+	}
+	return problems
+}
+
+// Validate checks the structure of an SCVD file against the rules of
+// Component_Viewer.xsd: the elements, their attributes, the required
+// attributes and the values of attributes with numeric or enumerated types.
+//
+// Parameters:
+//   - data: The content of the SCVD file.
+//   - file: The name of the SCVD file for the positions.
+//
+// Returns:
+//   - The problems in the order of the file. An XML syntax error ends the check.
+func Validate(data []byte, file string) []Problem {
+	var problems []Problem
+	var types []string // element types of the open elements
+	err := Walk(data, file, func(path []*Element) {
+		el := path[len(path)-1]
+		var typ, parent string
+		switch {
+		case len(path) == 1:
+			if el.Name == "component_viewer" {
+				typ = el.Name
+			}
+		case types[len(path)-2] != "":
+			parent = path[len(path)-2].Name
+			typ = schema[types[len(path)-2]].children[el.Name]
+		default: // inside an unknown element, which is reported
+			types = append(types[:len(path)-1], "")
+			return
+		}
+		types = append(types[:len(path)-1], typ)
+		problems = append(problems, validate(el, typ, parent)...)
+	})
+	if err != nil {
+		problems = append(problems, syntaxProblem(file, err))
+	}
+	return problems
+}
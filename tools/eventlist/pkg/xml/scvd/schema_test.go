@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"valid", "<component_viewer><events><event id=\"1\" level=\"Op\"><print cond=\"1\" property=\"p\" value=\"v\"/></event></events></component_viewer>", nil},
+		{"root", "<viewer/>", []string{"f:1:1: root element <viewer>, want <component_viewer>"}},
+		{"element", "<component_viewer><event id=\"1\" level=\"Op\"/></component_viewer>", []string{"f:1:19: unknown element <event> in <component_viewer>"}},
+		{"inside unknown", "<component_viewer><x><y a=\"1\"/></x></component_viewer>", []string{"f:1:19: unknown element <x> in <component_viewer>"}},
+		{"attribute", "<component_viewer>\n <typedefs><typedef name=\"t\" sizes=\"4\"/></typedefs></component_viewer>",
+			[]string{"f:2:30: unknown attribute sizes of <typedef>"}},
+		{"type", "<component_viewer><typedefs><typedef name=\"t\" size=\"-4\" endian=\"x\"/></typedefs></component_viewer>",
+			[]string{"f:1:47: invalid size=\"-4\" of <typedef>, want NonNegativeInteger", "f:1:57: invalid endian=\"x\" of <typedef>, want B, b, L or l"}},
+		{"required", "<component_viewer><events><event/></events></component_viewer>", []string{"f:1:27: missing attribute id, level of <event>"}},
+		{"syntax", "<component_viewer>\n<events></component_viewer>", []string{"f:2: element <events> closed by </component_viewer>"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, p := range Validate([]byte(tt.data), "f") {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -20,12 +20,17 @@ package scvd
 
 import (
 	"encoding/xml"
+	"errors"
 	"eventlist/pkg/eval"
+	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
+var ErrBitField = errors.New("invalid bit-field")
+
 type Value string
 type ID string
 type Endian string // B, b, L, l
@@ -84,34 +89,47 @@ type PrintType struct {
 }
 
 type EventType struct {
-	ID       ID          `xml:"id,attr"`    // limits to 16 bit
-	Level    string      `xml:"level,attr"` // Enum: Error, API, Op, Detail
-	Name     string      `xml:"name,attr"`
-	Brief    string      `xml:"brief,attr"`
-	Val1     string      `xml:"val1,attr"`
-	Val2     string      `xml:"val2,attr"`
-	Val3     string      `xml:"val3,attr"`
-	Val4     string      `xml:"val4,attr"`
-	Val5     string      `xml:"val5,attr"`
-	Val6     string      `xml:"val6,attr"`
-	Value    Value       `xml:"value,attr"`
-	Property string      `xml:"property,attr"`
-	Info     string      `xml:"info,attr"`
-	Doc      string      `xml:"doc,attr"`
-	Alert    string      `xml:"alert,attr"` // expression resolves to bool
-	Bold     string      `xml:"bold,attr"`  // expression resolves to bool
-	State    string      `xml:"state,attr"`
-	Handle   string      `xml:"handle,attr"`
-	HName    string      `xml:"hname,attr"`
-	Tracking string      `xml:"tracking,attr"`
-	Reset    bool        `xml:"reset,attr"`
-	Prints   []PrintType `xml:"print"`
+	ID        ID            `xml:"id,attr"`    // limits to 16 bit
+	Level     string        `xml:"level,attr"` // Enum: Error, API, Op, Detail
+	Name      string        `xml:"name,attr"`
+	Brief     string        `xml:"brief,attr"`
+	Val1      string        `xml:"val1,attr"`
+	Val2      string        `xml:"val2,attr"`
+	Val3      string        `xml:"val3,attr"`
+	Val4      string        `xml:"val4,attr"`
+	Val5      string        `xml:"val5,attr"`
+	Val6      string        `xml:"val6,attr"`
+	Value     Value         `xml:"value,attr"`
+	Property  string        `xml:"property,attr"`
+	Info      string        `xml:"info,attr"`
+	Doc       string        `xml:"doc,attr"`
+	Alert     string        `xml:"alert,attr"` // expression resolves to bool
+	Bold      string        `xml:"bold,attr"`  // expression resolves to bool
+	State     string        `xml:"state,attr"`
+	Handle    string        `xml:"handle,attr"`
+	HName     string        `xml:"hname,attr"`
+	Tracking  string        `xml:"tracking,attr"`
+	Reset     bool          `xml:"reset,attr"`
+	Prints    []PrintType   `xml:"print"`
+	States    []State       `xml:"-"` // states of the component, for the state tracking
+	Component ComponentInfo `xml:"-"` // component of the event, Brief is its short name
+	Position  Position      `xml:"-"` // definition in the SCVD file
+}
+
+// ComponentInfo describes the component of an event and the group containing it.
+type ComponentInfo struct {
+	Group  string // name of the group
+	Name   string
+	Prefix string
+	Info   string
 }
 
 type State struct {
-	Name string `xml:"name,attr"`
-	Plot string `xml:"plot,attr"`
-	Bold bool   `xml:"bold,attr"`
+	Name    string `xml:"name,attr"`
+	Plot    string `xml:"plot,attr"` // Enum: off, line, box
+	Bold    bool   `xml:"bold,attr"`
+	Unique  bool   `xml:"unique,attr"`  // only one handle of the component has this state
+	Dormant bool   `xml:"dormant,attr"` // state of the handles which lose the unique state
 }
 
 type ComponentType struct {
@@ -129,7 +147,7 @@ type GroupType struct {
 }
 
 type EventsType struct {
-	Group  GroupType   `xml:"group"`
+	Groups []GroupType `xml:"group"`
 	Events []EventType `xml:"event"`
 }
 
@@ -137,15 +155,21 @@ type ComponentViewer struct {
 	Component     ComponentsType `xml:"component"`
 	Typedefs      TypedefsType   `xml:"typedefs"`
 	Events        EventsType     `xml:"events"`
+	Objects       ObjectsType    `xml:"objects"`
 	SchemaVersion string         `xml:"schemaVersion,attr"`
+	eventLines    []int          // lines of the events
+	typedefLines  []int          // lines of the typedefs
 }
 
 type IDType uint16
 type Events map[IDType]EventType
 
+// Objects holds the <object> definitions of all SCVD files in the order of the files.
+type Objects []ObjectType
+
 // getFromFile reads an XML file specified by the given filename and decodes its content
-// into the ComponentViewer receiver. It returns an error if the file cannot be read or
-// if the XML decoding fails.
+// into the ComponentViewer receiver, together with the lines of the events and typedefs.
+// It returns an error if the file cannot be read or if the XML decoding fails.
 //
 // Parameters:
 //   - name: A pointer to a string containing the filename of the XML file to be read.
@@ -158,9 +182,42 @@ func (viewer *ComponentViewer) getFromFile(name *string) error {
 		d := xml.NewDecoder(strings.NewReader(string(data)))
 		err = d.Decode(&viewer)
 	}
+	if err == nil {
+		viewer.eventLines, viewer.typedefLines, err = elementLines(data)
+	}
 	return err
 }
 
+// bitField matches the offset of a bit-field member: the offset of the
+// member followed by the first and the optional last bit, e.g. "4:3..5".
+var bitField = regexp.MustCompile(`^([^?:]+):\s*(\d+)\s*(?:\.\.\s*(\d+))?\s*$`)
+
+// splitOffset splits the offset of a member into the offset expression
+// and the bits of a bit-field.
+//
+// Parameters:
+//   - offset: The offset attribute, e.g. "4" or "4:3..5" for bits 3 to 5.
+//
+// Returns:
+//   - The offset expression.
+//   - The first bit and the number of bits, 0 if the member is not a bit-field.
+//   - ErrBitField if the bits are invalid.
+func splitOffset(offset string) (string, uint32, uint32, error) {
+	m := bitField.FindStringSubmatch(offset)
+	if m == nil {
+		return offset, 0, 0, nil
+	}
+	low, err := strconv.ParseUint(m[2], 10, 8)
+	high := low
+	if err == nil && m[3] != "" {
+		high, err = strconv.ParseUint(m[3], 10, 8)
+	}
+	if err != nil || high < low || high > 63 {
+		return "", 0, 0, fmt.Errorf("%w: %s", ErrBitField, offset)
+	}
+	return strings.TrimSpace(m[1]), uint32(low), uint32(high - low + 1), nil
+}
+
 // getOne reads and processes event and typedef data from a specified file.
 // It populates the provided Events and Typedefs structures with the extracted data.
 //
@@ -168,38 +225,52 @@ func (viewer *ComponentViewer) getFromFile(name *string) error {
 //   - filename: A pointer to the name of the file to read from.
 //   - events: An Events structure to be populated with event data.
 //   - typedefs: A Typedefs structure to be populated with typedef data.
+//   - objects: Receives the object definitions, nil if they are not needed.
+//   - l: The loader checking definitions given more than once.
 //
 // Returns:
 //   - error: An error if any issues occur during file reading or data processing, otherwise nil.
-func getOne(filename *string, events Events, typedefs eval.Typedefs) error {
+func getOne(filename *string, events Events, typedefs eval.Typedefs, objects *Objects, l *loader) error {
 	var viewer ComponentViewer
 	var err error
 	if err = viewer.getFromFile(filename); err == nil {
-		// create a components map indexed by "no" to speed up things
+		// create a components map of all groups indexed by "no" to speed up things
 		components := make(map[uint8]*ComponentType)
-		for _, component := range viewer.Events.Group.Component {
-			var no uint64
-			no, err = strconv.ParseUint(component.No, 0, 8)
-			if err != nil {
-				break
+		groups := make(map[uint8]string)
+		for _, group := range viewer.Events.Groups {
+			for _, component := range group.Component {
+				component := component
+				var no uint64
+				if no, err = strconv.ParseUint(component.No, 0, 8); err != nil {
+					return err // cannot decode component number
+				}
+				components[uint8(no)] = &component
+				groups[uint8(no)] = group.Name
 			}
-			components[uint8(no)] = &component
 		}
-		if err != nil {
-			return err // cannot decode component number
-		}
-		for _, event := range viewer.Events.Events {
+		for i, event := range viewer.Events.Events {
 			id, err := eval.GetIdValue(string(event.ID), typedefs)
 			if err != nil {
 				return err // cannot decode IdValue
 			}
-			if components[uint8(id>>8)] != nil {
-				event.Brief = components[uint8(id>>8)].Brief
+			if component := components[uint8(id>>8)]; component != nil {
+				event.Brief = component.Brief
+				event.States = component.States
+				event.Component = ComponentInfo{
+					Group:  groups[uint8(id>>8)],
+					Name:   component.Name,
+					Prefix: component.Prefix,
+					Info:   component.Info,
+				}
+			}
+			event.Position = Position{File: *filename, Line: viewer.eventLines[i]}
+			if err = l.addEvent(events, IDType(id), event); err != nil {
+				return err
 			}
-			events[IDType(id)] = event
 		}
-		// extract enums from typedefs
-		for _, typedef := range viewer.Typedefs.Typedef {
+		// extract enums from typedefs, the variables and imports are not
+		// supported and reported by the lint of the SCVD files
+		for i, typedef := range viewer.Typedefs.Typedef {
 			if len(typedef.Members) > 0 {
 				members := make(map[string]eval.Member)
 				for _, member := range typedef.Members {
@@ -214,15 +285,27 @@ func getOne(filename *string, events Events, typedefs eval.Typedefs) error {
 							mem.Enums[enu] = enum.Name
 						}
 					}
-					mem.IType = eval.ITypes[member.Type]
-					mem.Offset = member.Offset
+					mem.Type = strings.TrimSpace(member.Type)
+					mem.IType = eval.ITypes[mem.Type]
+					if mem.Offset, mem.BitLow, mem.BitWidth, err = splitOffset(member.Offset); err != nil {
+						return err
+					}
+					mem.Size = uint32(member.Size)
+					mem.Endian = member.Endian
 					members[member.Name] = mem
 				}
 				if len(members) > 0 {
-					typedefs[typedef.Name] = eval.ITypedef{Size: uint32(typedef.Size), BigEndian: typedef.Endian == "B" || typedef.Endian == "b", Members: members}
+					itypedef := eval.ITypedef{Size: uint32(typedef.Size), BigEndian: typedef.Endian == "B" || typedef.Endian == "b", Members: members}
+					pos := Position{File: *filename, Line: viewer.typedefLines[i]}
+					if err = l.addTypedef(typedefs, typedef.Name, itypedef, pos); err != nil {
+						return err
+					}
 				}
 			}
 		}
+		if objects != nil {
+			*objects = append(*objects, viewer.Objects.Objects...)
+		}
 	}
 	return err
 }
@@ -239,12 +322,50 @@ func getOne(filename *string, events Events, typedefs eval.Typedefs) error {
 //
 //	An error if any of the SCVD files could not be processed, otherwise nil.
 func Get(scvdFiles *[]string, events Events, typedefs eval.Typedefs) error {
+	return GetObjects(scvdFiles, events, typedefs, nil)
+}
+
+// GetObjects processes a list of SCVD files like Get and additionally
+// collects the <object> definitions of the component viewer. Event IDs and
+// typedef names defined differently in the files are handled by OnConflict,
+// with PolicyWarn they are reported in Warnings.
+//
+// Parameters:
+//
+//	scvdFiles - A pointer to a slice of strings, where each string is a path to an SCVD file.
+//	events - An Events object to be populated with data from the SCVD files.
+//	typedefs - A Typedefs object to be populated with data from the SCVD files.
+//	objects - Receives the object definitions, nil if they are not needed.
+//
+// Returns:
+//
+//	An error if any of the SCVD files could not be processed, otherwise nil.
+func GetObjects(scvdFiles *[]string, events Events, typedefs eval.Typedefs, objects *Objects) error {
+	Warnings = nil
 	if scvdFiles != nil {
+		l := newLoader(OnConflict)
 		for _, scvdFile := range *scvdFiles {
-			if err := getOne(&scvdFile, events, typedefs); err != nil {
+			if err := getOne(&scvdFile, events, typedefs, objects, l); err != nil {
 				return err
 			}
 		}
+		Warnings = l.warnings()
 	}
 	return nil
 }
+
+// Load reads the events and typedefs of a single SCVD file like Get, a
+// definition given before is replaced.
+//
+// Parameters:
+//
+//	scvdFile - The path of the SCVD file.
+//	events - Receives the events of the file.
+//	typedefs - Receives the typedefs of the file.
+//
+// Returns:
+//
+//	An error if the SCVD file could not be processed, otherwise nil.
+func Load(scvdFile string, events Events, typedefs eval.Typedefs) error {
+	return getOne(&scvdFile, events, typedefs, nil, newLoader(PolicyLast))
+}
@@ -21,6 +21,8 @@ package scvd
 
 import (
 	"eventlist/pkg/eval"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -94,7 +96,7 @@ func Test_getOne(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := getOne(tt.args.filename, tt.args.events, tt.args.typedefs); (err != nil) != tt.wantErr {
+			if err := getOne(tt.args.filename, tt.args.events, tt.args.typedefs, nil, newLoader(PolicyLast)); (err != nil) != tt.wantErr {
 				t.Errorf("getOne() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if string(evs[tt.ev].Value) != tt.evWant {
@@ -143,3 +145,146 @@ func TestGet(t *testing.T) {
 		})
 	}
 }
+
+func Test_getOne_states(t *testing.T) {
+	t.Parallel()
+
+	name := "../../../testdata/states.scvd"
+	evs := make(Events)
+	if err := getOne(&name, evs, make(eval.Typedefs), nil, newLoader(PolicyError)); err != nil {
+		t.Fatalf("getOne() error = %v", err)
+	}
+	tests := []struct {
+		id     IDType
+		brief  string
+		states int
+		first  State
+	}{
+		{0xB00, "Thread", 4, State{Name: "Ready", Plot: "line", Dormant: true}},
+		{0xB01, "Thread", 4, State{Name: "Ready", Plot: "line", Dormant: true}},
+		{0xC00, "Mutex", 2, State{Name: "Free", Plot: "line"}},
+	}
+	for _, tt := range tests {
+		ev := evs[tt.id]
+		if ev.Brief != tt.brief || len(ev.States) != tt.states || ev.States[0] != tt.first {
+			t.Errorf("getOne() event 0x%04X = %s %v, want %s %d states, first %v", tt.id, ev.Brief, ev.States, tt.brief, tt.states, tt.first)
+		}
+	}
+	if s := evs[0xB01].States[1]; !s.Unique || !s.Bold || s.Plot != "box" {
+		t.Errorf("getOne() state = %v, want unique bold box", s)
+	}
+	if ev := evs[0xB03]; ev.Handle != "val1" || ev.Tracking != "Stop" || ev.State != "Terminated" {
+		t.Errorf("getOne() event 0xB03 = %v", ev)
+	}
+}
+
+func Test_getOne_groups(t *testing.T) {
+	t.Parallel()
+
+	name := "../../../testdata/groups.scvd"
+	evs := make(Events)
+	if err := getOne(&name, evs, make(eval.Typedefs), nil, newLoader(PolicyError)); err != nil {
+		t.Fatalf("getOne() error = %v", err)
+	}
+	tests := []struct {
+		id        IDType
+		brief     string
+		component ComponentInfo
+	}{
+		{0xEF00, "EvStat", ComponentInfo{"Event Statistics", "Start/Stop Statistics", "Event", "Event Statistics for EventStart/EventStop"}},
+		{0xFE00, "STDIO", ComponentInfo{"STDIO", "C Standard I/O", "", "C Standard I/O Events"}},
+		{0xFF03, "EvCtrl", ComponentInfo{"Recorder Control", "Event Recorder Control", "", "Event Recorder Control Events"}},
+		{0x0100, "", ComponentInfo{}},
+	}
+	for _, tt := range tests {
+		ev := evs[tt.id]
+		if ev.Brief != tt.brief || ev.Component != tt.component {
+			t.Errorf("getOne() event 0x%04X = %s %v, want %s %v", tt.id, ev.Brief, ev.Component, tt.brief, tt.component)
+		}
+	}
+}
+
+func Test_splitOffset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		offset    string
+		want      string
+		wantLow   uint32
+		wantWidth uint32
+		wantErr   bool
+	}{
+		{"4", "4", 0, 0, false},
+		{"val1 ? 4 : 8", "val1 ? 4 : 8", 0, 0, false},
+		{"4:3..5", "4", 3, 3, false},
+		{" 4 : 7", "4", 7, 1, false},
+		{"4:0..63", "4", 0, 64, false},
+		{"4:5..3", "", 0, 0, true},
+		{"4:64", "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.offset, func(t *testing.T) {
+			t.Parallel()
+
+			got, low, width, err := splitOffset(tt.offset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitOffset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || low != tt.wantLow || width != tt.wantWidth {
+				t.Errorf("splitOffset() = %q, %d, %d, want %q, %d, %d", got, low, width, tt.want, tt.wantLow, tt.wantWidth)
+			}
+		})
+	}
+}
+
+func Test_getOne_typedefs(t *testing.T) {
+	t.Parallel()
+
+	name := "../../../testdata/typedefs.scvd"
+	tds := make(eval.Typedefs)
+	if err := getOne(&name, make(Events), tds, nil, newLoader(PolicyError)); err != nil {
+		t.Fatalf("getOne() error = %v", err)
+	}
+	tests := []struct {
+		member string
+		want   eval.Member
+	}{
+		{"flags", eval.Member{Offset: "0", IType: eval.Uint8, Type: "uint8_t"}},
+		{"ver", eval.Member{Offset: "1", IType: eval.Uint8, Type: "uint8_t", BitLow: 4, BitWidth: 4}},
+		{"ack", eval.Member{Offset: "2", IType: eval.Uint16, Type: "uint16_t", BitLow: 15, BitWidth: 1}},
+		{"dst", eval.Member{Offset: "4", Type: "Addr"}},
+		{"port", eval.Member{Offset: "10", IType: eval.Uint16, Type: "uint16_t", Endian: "B"}},
+	}
+	for _, tt := range tests {
+		got := tds["Frame"].Members[tt.member]
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("getOne() member %s = %+v, want %+v", tt.member, got, tt.want)
+		}
+	}
+	if m := tds["Addr"].Members["mac"]; m.Size != 6 {
+		t.Errorf("getOne() member mac size = %d, want 6", m.Size)
+	}
+}
+
+func Test_getOne_typedefVars(t *testing.T) {
+	t.Parallel()
+
+	// the members are read, the variables and the import are reported by lint
+	name := "../../../testdata/typedef_vars.scvd"
+	tds := make(eval.Typedefs)
+	if err := getOne(&name, make(Events), tds, nil, newLoader(PolicyError)); err != nil {
+		t.Fatalf("getOne() error = %v", err)
+	}
+	want := map[string][]string{"Msg": {"id", "len"}, "Tcb": {"state"}}
+	for td, members := range want {
+		var got []string
+		for name := range tds[td].Members {
+			got = append(got, name)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, members) {
+			t.Errorf("getOne() typedef %s members = %v, want %v", td, got, members)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+)
+
+// Problem is a problem found in an SCVD file.
+type Problem struct {
+	Pos     Position `json:"position" xml:"position"`
+	Message string   `json:"message" xml:"message"`
+}
+
+func (p Problem) String() string {
+	return p.Pos.String() + ": " + p.Message
+}
+
+// Attr is an attribute of an element with its position.
+type Attr struct {
+	Name     string
+	Value    string
+	Pos      Position // position of the name
+	ValuePos Position // position of the value after the quote
+	verbatim bool     // the value is written without references and line breaks
+}
+
+// At returns the position of a byte of the value. If the value is not
+// written as is, e.g. with a character reference, the position of the
+// value is returned.
+//
+// Parameters:
+//   - offset: The offset of the byte in the value.
+//
+// Returns:
+//   - The position of the byte in the file.
+func (a Attr) At(offset int) Position {
+	pos := a.ValuePos
+	if a.verbatim && pos.Column != 0 {
+		pos.Column += offset
+	}
+	return pos
+}
+
+// Element is an element of an SCVD file with the positions of the element
+// and its attributes.
+type Element struct {
+	Name  string
+	Pos   Position
+	Attrs []Attr
+}
+
+// Attr returns an attribute of the element.
+func (e *Element) Attr(name string) (Attr, bool) {
+	for _, a := range e.Attrs {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Attr{}, false
+}
+
+// lineStarts are the offsets of the lines of a file.
+type lineStarts []int
+
+func newLineStarts(data []byte) lineStarts {
+	starts := lineStarts{0}
+	for i, c := range data {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// position returns the line and column of an offset, both starting at 1.
+func (l lineStarts) position(file string, offset int) Position {
+	line := sort.SearchInts(l, offset+1) - 1
+	return Position{File: file, Line: line + 1, Column: offset - l[line] + 1}
+}
+
+// isSpace checks for the white space of XML.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// attrOffset is the location of an attribute in a start tag.
+type attrOffset struct {
+	name  int // offset of the name
+	value int // offset of the value after the quote
+	end   int // offset of the closing quote
+}
+
+// attrOffsets returns the locations of the attributes of a start tag.
+//
+// Parameters:
+//   - tag: The start tag from '<' to '>'.
+//
+// Returns:
+//   - The locations in the tag, in the order of the attributes.
+func attrOffsets(tag []byte) []attrOffset {
+	var offsets []attrOffset
+	i := 1
+	for i < len(tag) && !isSpace(tag[i]) && tag[i] != '>' && tag[i] != '/' {
+		i++ // element name
+	}
+	for i < len(tag) {
+		for i < len(tag) && isSpace(tag[i]) {
+			i++
+		}
+		if i >= len(tag) || tag[i] == '>' || tag[i] == '/' {
+			break
+		}
+		offset := attrOffset{name: i}
+		for i < len(tag) && tag[i] != '=' {
+			i++
+		}
+		for i < len(tag) && tag[i] != '"' && tag[i] != '\'' {
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		quote := tag[i]
+		i++
+		offset.value = i
+		for i < len(tag) && tag[i] != quote {
+			i++
+		}
+		offset.end = i
+		offsets = append(offsets, offset)
+		i++
+	}
+	return offsets
+}
+
+// Walk reads the elements of an SCVD file and calls a function for each
+// start of an element. Namespace declarations and attributes with a
+// namespace prefix, e.g. of the schema location, are not passed.
+//
+// Parameters:
+//   - data: The content of the SCVD file.
+//   - file: The name of the SCVD file for the positions.
+//   - fn: The function called with the path from the root to the element.
+//
+// Returns:
+//   - An *xml.SyntaxError if the file is not well-formed.
+func Walk(data []byte, file string, fn func(path []*Element)) error {
+	lines := newLineStarts(data)
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var path []*Element
+	for {
+		start := int(d.InputOffset())
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := &Element{Name: t.Name.Local, Pos: lines.position(file, start)}
+			tag := data[start:d.InputOffset()]
+			offsets := attrOffsets(tag)
+			for i, a := range t.Attr {
+				if a.Name.Space != "" || a.Name.Local == "xmlns" {
+					continue
+				}
+				attr := Attr{Name: a.Name.Local, Value: a.Value, Pos: el.Pos, ValuePos: el.Pos}
+				if i < len(offsets) {
+					o := offsets[i]
+					raw := tag[o.value:o.end]
+					attr.Pos = lines.position(file, start+o.name)
+					attr.ValuePos = lines.position(file, start+o.value)
+					attr.verbatim = string(raw) == a.Value && bytes.IndexByte(raw, '\n') == -1
+				}
+				el.Attrs = append(el.Attrs, attr)
+			}
+			path = append(path, el)
+			fn(path)
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		}
+	}
+}
+
+// syntaxProblem returns the problem of an XML syntax error.
+func syntaxProblem(file string, err error) Problem {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return Problem{Position{File: file, Line: syntaxErr.Line}, syntaxErr.Msg}
+	}
+	return Problem{Position{File: file}, err.Error()}
+}
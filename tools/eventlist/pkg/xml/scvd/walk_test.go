@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the License); you may
+ * not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an AS IS BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scvd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("<a xmlns:xs=\"x\" xs:b=\"1\">\n" +
+		"  <b  c = 'x%d' d=\"&lt;%d\"/>\n" +
+		"  <c e=\"1\n2\"></c>\n" +
+		"</a>\n")
+	pos := func(line, column int) Position {
+		return Position{File: "f", Line: line, Column: column}
+	}
+	var got []string
+	var elements []Element
+	if err := Walk(data, "f", func(path []*Element) {
+		name := ""
+		for _, el := range path {
+			name += "/" + el.Name
+		}
+		got = append(got, name)
+		elements = append(elements, *path[len(path)-1])
+	}); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	if want := []string{"/a", "/a/b", "/a/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk() paths = %v, want %v", got, want)
+	}
+	if len(elements[0].Attrs) != 0 {
+		t.Errorf("Walk() attributes = %v, want none", elements[0].Attrs)
+	}
+	b := elements[1]
+	if b.Pos != pos(2, 3) || len(b.Attrs) != 2 {
+		t.Fatalf("Walk() element = %v", b)
+	}
+	c, _ := b.Attr("c")
+	if c.Pos != pos(2, 7) || c.At(1) != pos(2, 13) {
+		t.Errorf("Walk() attribute c = %v, At(1) = %v", c, c.At(1))
+	}
+	d, _ := b.Attr("d")
+	if d.Value != "<%d" || d.At(1) != pos(2, 20) {
+		t.Errorf("Walk() attribute d = %v, At(1) = %v", d, d.At(1))
+	}
+	e, _ := elements[2].Attr("e")
+	if e.At(2) != pos(3, 9) {
+		t.Errorf("Walk() attribute e At(2) = %v", e.At(2))
+	}
+	if _, ok := b.Attr("nix"); ok {
+		t.Errorf("Element.Attr() nix found")
+	}
+
+	err := Walk([]byte("<a>\n<b></a>"), "f", func([]*Element) {})
+	if p := syntaxProblem("f", err); p.Pos != (Position{File: "f", Line: 2}) || p.String() != "f:2: "+p.Message {
+		t.Errorf("syntaxProblem() = %v", p)
+	}
+}